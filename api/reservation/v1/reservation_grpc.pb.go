@@ -0,0 +1,570 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: api/reservation/v1/reservation.proto
+
+package reservationv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CandidateService_ListCandidates_FullMethodName   = "/reservation.v1.CandidateService/ListCandidates"
+	CandidateService_AddCandidate_FullMethodName     = "/reservation.v1.CandidateService/AddCandidate"
+	CandidateService_GetCandidate_FullMethodName     = "/reservation.v1.CandidateService/GetCandidate"
+	CandidateService_UpdateCandidate_FullMethodName  = "/reservation.v1.CandidateService/UpdateCandidate"
+	CandidateService_DeleteCandidate_FullMethodName  = "/reservation.v1.CandidateService/DeleteCandidate"
+	CandidateService_RestoreCandidate_FullMethodName = "/reservation.v1.CandidateService/RestoreCandidate"
+)
+
+// CandidateServiceClient is the client API for CandidateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CandidateServiceClient interface {
+	ListCandidates(ctx context.Context, in *ListCandidatesRequest, opts ...grpc.CallOption) (*ListCandidatesResponse, error)
+	AddCandidate(ctx context.Context, in *AddCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+	GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+	UpdateCandidate(ctx context.Context, in *UpdateCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+	DeleteCandidate(ctx context.Context, in *DeleteCandidateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreCandidate(ctx context.Context, in *RestoreCandidateRequest, opts ...grpc.CallOption) (*Candidate, error)
+}
+
+type candidateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCandidateServiceClient(cc grpc.ClientConnInterface) CandidateServiceClient {
+	return &candidateServiceClient{cc}
+}
+
+func (c *candidateServiceClient) ListCandidates(ctx context.Context, in *ListCandidatesRequest, opts ...grpc.CallOption) (*ListCandidatesResponse, error) {
+	out := new(ListCandidatesResponse)
+	err := c.cc.Invoke(ctx, CandidateService_ListCandidates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) AddCandidate(ctx context.Context, in *AddCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_AddCandidate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) GetCandidate(ctx context.Context, in *GetCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_GetCandidate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) UpdateCandidate(ctx context.Context, in *UpdateCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_UpdateCandidate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) DeleteCandidate(ctx context.Context, in *DeleteCandidateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CandidateService_DeleteCandidate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *candidateServiceClient) RestoreCandidate(ctx context.Context, in *RestoreCandidateRequest, opts ...grpc.CallOption) (*Candidate, error) {
+	out := new(Candidate)
+	err := c.cc.Invoke(ctx, CandidateService_RestoreCandidate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CandidateServiceServer is the server API for CandidateService service.
+// All implementations must embed UnimplementedCandidateServiceServer
+// for forward compatibility
+type CandidateServiceServer interface {
+	ListCandidates(context.Context, *ListCandidatesRequest) (*ListCandidatesResponse, error)
+	AddCandidate(context.Context, *AddCandidateRequest) (*Candidate, error)
+	GetCandidate(context.Context, *GetCandidateRequest) (*Candidate, error)
+	UpdateCandidate(context.Context, *UpdateCandidateRequest) (*Candidate, error)
+	DeleteCandidate(context.Context, *DeleteCandidateRequest) (*emptypb.Empty, error)
+	RestoreCandidate(context.Context, *RestoreCandidateRequest) (*Candidate, error)
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+// UnimplementedCandidateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCandidateServiceServer struct {
+}
+
+func (UnimplementedCandidateServiceServer) ListCandidates(context.Context, *ListCandidatesRequest) (*ListCandidatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCandidates not implemented")
+}
+func (UnimplementedCandidateServiceServer) AddCandidate(context.Context, *AddCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) GetCandidate(context.Context, *GetCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) UpdateCandidate(context.Context, *UpdateCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) DeleteCandidate(context.Context, *DeleteCandidateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) RestoreCandidate(context.Context, *RestoreCandidateRequest) (*Candidate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCandidate not implemented")
+}
+func (UnimplementedCandidateServiceServer) mustEmbedUnimplementedCandidateServiceServer() {}
+
+// UnsafeCandidateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CandidateServiceServer will
+// result in compilation errors.
+type UnsafeCandidateServiceServer interface {
+	mustEmbedUnimplementedCandidateServiceServer()
+}
+
+func RegisterCandidateServiceServer(s grpc.ServiceRegistrar, srv CandidateServiceServer) {
+	s.RegisterService(&CandidateService_ServiceDesc, srv)
+}
+
+func _CandidateService_ListCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).ListCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_ListCandidates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).ListCandidates(ctx, req.(*ListCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_AddCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).AddCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_AddCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).AddCandidate(ctx, req.(*AddCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_GetCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_GetCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).GetCandidate(ctx, req.(*GetCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_UpdateCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).UpdateCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_UpdateCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).UpdateCandidate(ctx, req.(*UpdateCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_DeleteCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).DeleteCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_DeleteCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).DeleteCandidate(ctx, req.(*DeleteCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CandidateService_RestoreCandidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CandidateServiceServer).RestoreCandidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CandidateService_RestoreCandidate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CandidateServiceServer).RestoreCandidate(ctx, req.(*RestoreCandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CandidateService_ServiceDesc is the grpc.ServiceDesc for CandidateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CandidateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reservation.v1.CandidateService",
+	HandlerType: (*CandidateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCandidates",
+			Handler:    _CandidateService_ListCandidates_Handler,
+		},
+		{
+			MethodName: "AddCandidate",
+			Handler:    _CandidateService_AddCandidate_Handler,
+		},
+		{
+			MethodName: "GetCandidate",
+			Handler:    _CandidateService_GetCandidate_Handler,
+		},
+		{
+			MethodName: "UpdateCandidate",
+			Handler:    _CandidateService_UpdateCandidate_Handler,
+		},
+		{
+			MethodName: "DeleteCandidate",
+			Handler:    _CandidateService_DeleteCandidate_Handler,
+		},
+		{
+			MethodName: "RestoreCandidate",
+			Handler:    _CandidateService_RestoreCandidate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/reservation/v1/reservation.proto",
+}
+
+const (
+	RecruiterService_ListRecruiters_FullMethodName   = "/reservation.v1.RecruiterService/ListRecruiters"
+	RecruiterService_AddRecruiter_FullMethodName     = "/reservation.v1.RecruiterService/AddRecruiter"
+	RecruiterService_GetRecruiter_FullMethodName     = "/reservation.v1.RecruiterService/GetRecruiter"
+	RecruiterService_UpdateRecruiter_FullMethodName  = "/reservation.v1.RecruiterService/UpdateRecruiter"
+	RecruiterService_DeleteRecruiter_FullMethodName  = "/reservation.v1.RecruiterService/DeleteRecruiter"
+	RecruiterService_RestoreRecruiter_FullMethodName = "/reservation.v1.RecruiterService/RestoreRecruiter"
+)
+
+// RecruiterServiceClient is the client API for RecruiterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RecruiterServiceClient interface {
+	ListRecruiters(ctx context.Context, in *ListRecruitersRequest, opts ...grpc.CallOption) (*ListRecruitersResponse, error)
+	AddRecruiter(ctx context.Context, in *AddRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error)
+	GetRecruiter(ctx context.Context, in *GetRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error)
+	UpdateRecruiter(ctx context.Context, in *UpdateRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error)
+	DeleteRecruiter(ctx context.Context, in *DeleteRecruiterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreRecruiter(ctx context.Context, in *RestoreRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error)
+}
+
+type recruiterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRecruiterServiceClient(cc grpc.ClientConnInterface) RecruiterServiceClient {
+	return &recruiterServiceClient{cc}
+}
+
+func (c *recruiterServiceClient) ListRecruiters(ctx context.Context, in *ListRecruitersRequest, opts ...grpc.CallOption) (*ListRecruitersResponse, error) {
+	out := new(ListRecruitersResponse)
+	err := c.cc.Invoke(ctx, RecruiterService_ListRecruiters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recruiterServiceClient) AddRecruiter(ctx context.Context, in *AddRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error) {
+	out := new(Recruiter)
+	err := c.cc.Invoke(ctx, RecruiterService_AddRecruiter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recruiterServiceClient) GetRecruiter(ctx context.Context, in *GetRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error) {
+	out := new(Recruiter)
+	err := c.cc.Invoke(ctx, RecruiterService_GetRecruiter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recruiterServiceClient) UpdateRecruiter(ctx context.Context, in *UpdateRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error) {
+	out := new(Recruiter)
+	err := c.cc.Invoke(ctx, RecruiterService_UpdateRecruiter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recruiterServiceClient) DeleteRecruiter(ctx context.Context, in *DeleteRecruiterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RecruiterService_DeleteRecruiter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *recruiterServiceClient) RestoreRecruiter(ctx context.Context, in *RestoreRecruiterRequest, opts ...grpc.CallOption) (*Recruiter, error) {
+	out := new(Recruiter)
+	err := c.cc.Invoke(ctx, RecruiterService_RestoreRecruiter_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RecruiterServiceServer is the server API for RecruiterService service.
+// All implementations must embed UnimplementedRecruiterServiceServer
+// for forward compatibility
+type RecruiterServiceServer interface {
+	ListRecruiters(context.Context, *ListRecruitersRequest) (*ListRecruitersResponse, error)
+	AddRecruiter(context.Context, *AddRecruiterRequest) (*Recruiter, error)
+	GetRecruiter(context.Context, *GetRecruiterRequest) (*Recruiter, error)
+	UpdateRecruiter(context.Context, *UpdateRecruiterRequest) (*Recruiter, error)
+	DeleteRecruiter(context.Context, *DeleteRecruiterRequest) (*emptypb.Empty, error)
+	RestoreRecruiter(context.Context, *RestoreRecruiterRequest) (*Recruiter, error)
+	mustEmbedUnimplementedRecruiterServiceServer()
+}
+
+// UnimplementedRecruiterServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRecruiterServiceServer struct {
+}
+
+func (UnimplementedRecruiterServiceServer) ListRecruiters(context.Context, *ListRecruitersRequest) (*ListRecruitersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecruiters not implemented")
+}
+func (UnimplementedRecruiterServiceServer) AddRecruiter(context.Context, *AddRecruiterRequest) (*Recruiter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRecruiter not implemented")
+}
+func (UnimplementedRecruiterServiceServer) GetRecruiter(context.Context, *GetRecruiterRequest) (*Recruiter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecruiter not implemented")
+}
+func (UnimplementedRecruiterServiceServer) UpdateRecruiter(context.Context, *UpdateRecruiterRequest) (*Recruiter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecruiter not implemented")
+}
+func (UnimplementedRecruiterServiceServer) DeleteRecruiter(context.Context, *DeleteRecruiterRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecruiter not implemented")
+}
+func (UnimplementedRecruiterServiceServer) RestoreRecruiter(context.Context, *RestoreRecruiterRequest) (*Recruiter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRecruiter not implemented")
+}
+func (UnimplementedRecruiterServiceServer) mustEmbedUnimplementedRecruiterServiceServer() {}
+
+// UnsafeRecruiterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RecruiterServiceServer will
+// result in compilation errors.
+type UnsafeRecruiterServiceServer interface {
+	mustEmbedUnimplementedRecruiterServiceServer()
+}
+
+func RegisterRecruiterServiceServer(s grpc.ServiceRegistrar, srv RecruiterServiceServer) {
+	s.RegisterService(&RecruiterService_ServiceDesc, srv)
+}
+
+func _RecruiterService_ListRecruiters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecruitersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecruiterServiceServer).ListRecruiters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecruiterService_ListRecruiters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecruiterServiceServer).ListRecruiters(ctx, req.(*ListRecruitersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecruiterService_AddRecruiter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRecruiterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecruiterServiceServer).AddRecruiter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecruiterService_AddRecruiter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecruiterServiceServer).AddRecruiter(ctx, req.(*AddRecruiterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecruiterService_GetRecruiter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecruiterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecruiterServiceServer).GetRecruiter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecruiterService_GetRecruiter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecruiterServiceServer).GetRecruiter(ctx, req.(*GetRecruiterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecruiterService_UpdateRecruiter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecruiterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecruiterServiceServer).UpdateRecruiter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecruiterService_UpdateRecruiter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecruiterServiceServer).UpdateRecruiter(ctx, req.(*UpdateRecruiterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecruiterService_DeleteRecruiter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecruiterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecruiterServiceServer).DeleteRecruiter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecruiterService_DeleteRecruiter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecruiterServiceServer).DeleteRecruiter(ctx, req.(*DeleteRecruiterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RecruiterService_RestoreRecruiter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRecruiterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RecruiterServiceServer).RestoreRecruiter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RecruiterService_RestoreRecruiter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RecruiterServiceServer).RestoreRecruiter(ctx, req.(*RestoreRecruiterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RecruiterService_ServiceDesc is the grpc.ServiceDesc for RecruiterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RecruiterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reservation.v1.RecruiterService",
+	HandlerType: (*RecruiterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRecruiters",
+			Handler:    _RecruiterService_ListRecruiters_Handler,
+		},
+		{
+			MethodName: "AddRecruiter",
+			Handler:    _RecruiterService_AddRecruiter_Handler,
+		},
+		{
+			MethodName: "GetRecruiter",
+			Handler:    _RecruiterService_GetRecruiter_Handler,
+		},
+		{
+			MethodName: "UpdateRecruiter",
+			Handler:    _RecruiterService_UpdateRecruiter_Handler,
+		},
+		{
+			MethodName: "DeleteRecruiter",
+			Handler:    _RecruiterService_DeleteRecruiter_Handler,
+		},
+		{
+			MethodName: "RestoreRecruiter",
+			Handler:    _RecruiterService_RestoreRecruiter_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/reservation/v1/reservation.proto",
+}
@@ -5,14 +5,28 @@ import (
 	"flag"
 	"fmt"
 	"go.uber.org/zap"
+	"net"
 	"os"
 	"os/signal"
 	"reservation-system/internal/config"
+	"reservation-system/internal/domain/availability"
+	domainNotification "reservation-system/internal/domain/notification"
 	"reservation-system/internal/handler"
 	"reservation-system/internal/repository"
+	"reservation-system/internal/service/event"
+	"reservation-system/internal/service/idempotency"
+	"reservation-system/internal/service/notification"
+	"reservation-system/internal/service/organization"
+	"reservation-system/internal/service/outbox"
+	"reservation-system/internal/service/reminder"
 	"reservation-system/internal/service/reservation"
+	"reservation-system/internal/service/webhook"
+	"reservation-system/pkg/blob"
+	"reservation-system/pkg/egress"
 	"reservation-system/pkg/log"
+	"reservation-system/pkg/mail"
 	"reservation-system/pkg/server"
+	"reservation-system/pkg/sms"
 	"syscall"
 	"time"
 )
@@ -26,35 +40,244 @@ func Run() {
 		return
 	}
 
-	repositories, err := repository.New(
-		repository.WithMemoryStore())
+	store := repository.WithMemoryStore()
+	if configs.POSTGRES.DSN != "" {
+		store = repository.WithPostgresStore(configs.POSTGRES.DSN)
+	}
+
+	repositories, err := repository.New(store)
 	if err != nil {
 		logger.Error("ERR_INIT_REPOSITORIES", zap.Error(err))
 		return
 	}
 	defer repositories.Close()
 
-	reservationService, err := reservation.New(
+	eventBus, err := event.New(
+		event.WithBufferSize(configs.EVENTS.BufferSize))
+	if err != nil {
+		logger.Error("ERR_INIT_EVENT_BUS", zap.Error(err))
+		return
+	}
+
+	workingHours, err := availability.ParseWorkingHours(
+		configs.WORKING_HOURS.Start,
+		configs.WORKING_HOURS.End,
+		configs.WORKING_HOURS.Days,
+		configs.WORKING_HOURS.TimeZone)
+	if err != nil {
+		logger.Error("ERR_INIT_WORKING_HOURS", zap.Error(err))
+		return
+	}
+
+	notificationConfigs := []notification.Configuration{
+		notification.WithNotificationRepository(repositories.Notification),
+		notification.WithOptOutRepository(repositories.NotificationOptOut),
+		notification.WithLocale(configs.NOTIFICATION.Locale),
+		notification.WithRetries(configs.NOTIFICATION.MaxAttempts, configs.NOTIFICATION.Backoff),
+		notification.WithPollInterval(configs.NOTIFICATION.PollInterval),
+	}
+	if configs.SMTP.Host != "" {
+		notificationConfigs = append(notificationConfigs, notification.WithSender(domainNotification.ChannelEmail, notification.NewEmailSender(mail.SMTP{
+			Host:     configs.SMTP.Host,
+			Port:     configs.SMTP.Port,
+			Username: configs.SMTP.Username,
+			Password: configs.SMTP.Password,
+			From:     configs.SMTP.From,
+			Timeout:  configs.SMTP.Timeout,
+		})))
+	}
+
+	switch configs.SMS.Provider {
+	case "":
+	case "console":
+		notificationConfigs = append(notificationConfigs, notification.WithSender(domainNotification.ChannelSMS,
+			notification.NewSMSSender(sms.NewConsole(), configs.SMS.RateLimit, configs.SMS.RateWindow)))
+	case "file":
+		provider, err := sms.NewFile(configs.SMS.File)
+		if err != nil {
+			logger.Error("ERR_INIT_SMS_PROVIDER", zap.Error(err))
+			return
+		}
+		notificationConfigs = append(notificationConfigs, notification.WithSender(domainNotification.ChannelSMS,
+			notification.NewSMSSender(provider, configs.SMS.RateLimit, configs.SMS.RateWindow)))
+	default:
+		logger.Error("ERR_INIT_SMS_PROVIDER", zap.String("provider", configs.SMS.Provider))
+		return
+	}
+
+	notificationService, err := notification.New(notificationConfigs...)
+	if err != nil {
+		logger.Error("ERR_INIT_NOTIFICATION_SERVICE", zap.Error(err))
+		return
+	}
+	reservationConfigs := []reservation.Configuration{
 		reservation.WithCandidateRepository(repositories.Candidate),
-		reservation.WithRecruiterRepository(repositories.Recruiter))
+		reservation.WithRecruiterRepository(repositories.Recruiter),
+		reservation.WithInterviewRepository(repositories.Interview),
+		reservation.WithFeedTokenRepository(repositories.FeedToken),
+		reservation.WithResourceRepository(repositories.Resource),
+		reservation.WithVacancyRepository(repositories.Vacancy),
+		reservation.WithApplicationRepository(repositories.Application),
+		reservation.WithScorecardTemplateRepository(repositories.ScorecardTemplate),
+		reservation.WithScorecardRepository(repositories.Scorecard),
+		reservation.WithTeamRepository(repositories.Team),
+		reservation.WithTeamMemberRepository(repositories.TeamMember),
+		reservation.WithNoteRepository(repositories.CandidateNote),
+		reservation.WithAttachmentRepository(repositories.CandidateAttachment),
+		reservation.WithMaxAttachmentSize(configs.BLOB.MaxSize),
+		reservation.WithBusyRepository(repositories.Busy),
+		reservation.WithBusyFeedRepository(repositories.BusyFeed),
+		reservation.WithHTTPClient(egress.NewPolicy(configs.BUSY.AllowedHosts...).Client(configs.BUSY.Timeout)),
+		reservation.WithBusyHorizon(configs.BUSY.Horizon),
+		reservation.WithWorkingHours(workingHours),
+		reservation.WithAuditRepository(repositories.Audit),
+		reservation.WithOutboxRepository(repositories.Outbox),
+		reservation.WithUnitOfWork(repositories.UnitOfWork),
+		reservation.WithNotifier(notificationService),
+	}
+
+	switch configs.MEETING.Provider {
+	case "":
+	case "jitsi":
+		provider, err := reservation.NewJitsiProvider(configs.MEETING.BaseURL, configs.MEETING.Secret)
+		if err != nil {
+			logger.Error("ERR_INIT_MEETING_PROVIDER", zap.Error(err))
+			return
+		}
+		reservationConfigs = append(reservationConfigs, reservation.WithMeetingProvider(provider))
+	default:
+		logger.Error("ERR_INIT_MEETING_PROVIDER", zap.String("provider", configs.MEETING.Provider))
+		return
+	}
+
+	switch configs.BLOB.Provider {
+	case "":
+	case "local":
+		blobStore, err := blob.NewLocal(configs.BLOB.Path)
+		if err != nil {
+			logger.Error("ERR_INIT_BLOB_STORE", zap.Error(err))
+			return
+		}
+		reservationConfigs = append(reservationConfigs, reservation.WithBlobStore(blobStore))
+	default:
+		logger.Error("ERR_INIT_BLOB_STORE", zap.String("provider", configs.BLOB.Provider))
+		return
+	}
+
+	reservationService, err := reservation.New(reservationConfigs...)
 	if err != nil {
 		logger.Error("ERR_INIT_LIBRARY_SERVICE", zap.Error(err))
 		return
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reservationService.StartPurge(ctx, configs.PURGE.Interval, configs.PURGE.Retention)
+	reservationService.StartBusySync(ctx, configs.BUSY.SyncInterval)
+
+	notificationService.Start(ctx)
+
+	reminderService, err := reminder.New(
+		reminder.WithInterviewRepository(repositories.Interview),
+		reminder.WithReminderRepository(repositories.Reminder),
+		reminder.WithCandidateRepository(repositories.Candidate),
+		reminder.WithRecruiterRepository(repositories.Recruiter),
+		reminder.WithUnitOfWork(repositories.UnitOfWork),
+		reminder.WithNotifier(notificationService),
+		reminder.WithOffsets(configs.REMINDER.Offsets...),
+		reminder.WithInterval(configs.REMINDER.Interval))
+	if err != nil {
+		logger.Error("ERR_INIT_REMINDER_SERVICE", zap.Error(err))
+		return
+	}
+	reminderService.Start(ctx)
+
+	webhookPolicy := egress.NewPolicy(configs.WEBHOOK.AllowedHosts...)
+	webhookService, err := webhook.New(
+		webhook.WithSubscriptionRepository(repositories.WebhookSubscription),
+		webhook.WithDeliveryRepository(repositories.WebhookDelivery),
+		webhook.WithPolicy(webhookPolicy),
+		webhook.WithHTTPClient(webhookPolicy.Client(configs.WEBHOOK.Timeout)),
+		webhook.WithRetries(configs.WEBHOOK.MaxAttempts, configs.WEBHOOK.Backoff),
+		webhook.WithPollInterval(configs.WEBHOOK.PollInterval))
+	if err != nil {
+		logger.Error("ERR_INIT_WEBHOOK_SERVICE", zap.Error(err))
+		return
+	}
+	webhookService.Start(ctx)
+
+	relayConfigs := []outbox.Configuration{
+		outbox.WithOutboxRepository(repositories.Outbox),
+		outbox.WithUnitOfWork(repositories.UnitOfWork),
+		outbox.WithInterval(configs.OUTBOX.Interval),
+		outbox.WithBatchSize(configs.OUTBOX.BatchSize),
+		outbox.WithRetention(configs.OUTBOX.Retention),
+		outbox.WithSink(notificationService),
+		outbox.WithSink(webhookService),
+	}
+	for _, name := range configs.OUTBOX.Sinks {
+		switch name {
+		case "bus":
+			relayConfigs = append(relayConfigs, outbox.WithSink(outbox.NewPublisherSink(eventBus)))
+		case "log":
+			relayConfigs = append(relayConfigs, outbox.WithSink(outbox.NewLogSink()))
+		default:
+			logger.Error("ERR_INIT_OUTBOX_RELAY", zap.String("sink", name))
+			return
+		}
+	}
+
+	outboxRelay, err := outbox.New(relayConfigs...)
+	if err != nil {
+		logger.Error("ERR_INIT_OUTBOX_RELAY", zap.Error(err))
+		return
+	}
+	outboxRelay.Start(ctx)
+
+	idempotencyService, err := idempotency.New(
+		idempotency.WithIdempotencyRepository(repositories.Idempotency),
+		idempotency.WithTTL(configs.IDEMPOTENCY.TTL))
+	if err != nil {
+		logger.Error("ERR_INIT_IDEMPOTENCY_SERVICE", zap.Error(err))
+		return
+	}
+	idempotencyService.StartPurge(ctx)
+
+	organizationService, err := organization.New(
+		organization.WithOrganizationRepository(repositories.Organization),
+		organization.WithKeyRepository(repositories.OrganizationKey),
+		organization.WithRecruiterRepository(repositories.Recruiter))
+	if err != nil {
+		logger.Error("ERR_INIT_ORGANIZATION_SERVICE", zap.Error(err))
+		return
+	}
+
 	handlers, err := handler.New(
 		handler.Dependencies{
-			Configs:            configs,
-			ReservationService: reservationService,
+			Configs:             configs,
+			ReservationService:  reservationService,
+			IdempotencyService:  idempotencyService,
+			EventBus:            eventBus,
+			WebhookService:      webhookService,
+			NotificationService: notificationService,
+			OrganizationService: organizationService,
 		},
-		handler.WithHTTPHandler())
+		handler.WithHTTPHandler(),
+		handler.WithGRPCHandler())
 	if err != nil {
 		logger.Error("ERR_INIT_HANDLERS", zap.Error(err))
 		return
 	}
 
-	servers, err := server.New(
-		server.WithHTTPServer(handlers.HTTP, configs.APP.Port))
+	serverConfigs := []server.Configuration{
+		server.WithHTTPServer(handlers.HTTP, configs.APP.Port),
+	}
+	if configs.APP.GRPCPort != "" {
+		serverConfigs = append(serverConfigs, server.WithGRPCServer(handlers.GRPC, configs.APP.GRPCHost, configs.APP.GRPCPort))
+	}
+
+	servers, err := server.New(serverConfigs...)
 	if err != nil {
 		logger.Error("ERR_INIT_SERVERS", zap.Error(err))
 		return
@@ -64,6 +287,9 @@ func Run() {
 		return
 	}
 	logger.Info("http server started on http://localhost:" + configs.APP.Port + "/swagger/index.html")
+	if configs.APP.GRPCPort != "" {
+		logger.Info("grpc server started on " + net.JoinHostPort(configs.APP.GRPCHost, configs.APP.GRPCPort))
+	}
 
 	var wait time.Duration
 	flag.DurationVar(&wait, "graceful-timeout", time.Second*15, "the duration for which the httpServer gracefully wait for existing connections to finish - e.g. 15s or 1m")
@@ -79,16 +305,20 @@ func Run() {
 	fmt.Println("gracefully shutting down...")
 
 	// Create a deadline to wait for
-	ctx, cancel := context.WithTimeout(context.Background(), wait)
-	defer cancel()
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), wait)
+	defer shutdownCancel()
+
+	// the event streams never finish on their own, closing the bus ends them
+	eventBus.Close()
 
 	// Doesn't block if no connections, but will otherwise wait until the timeout deadline
-	if err = servers.Stop(ctx); err != nil {
+	if err = servers.Stop(shutdownCtx); err != nil {
 		panic(err) // failure/timeout shutting down the httpServer gracefully
 	}
 
 	fmt.Println("running cleanup tasks...")
 	// Your cleanup tasks go here
+	cancel()
 
 	fmt.Println("server was successful shutdown.")
 }
@@ -11,26 +11,190 @@ import (
 const (
 	defaultAppMode    = "dev"
 	defaultAppPort    = "8080"
+	defaultGRPCPort   = "9090"
+	defaultGRPCHost   = "127.0.0.1"
 	defaultAppPath    = "/"
 	defaultAppTimeout = 60 * time.Second
+
+	defaultPurgeInterval  = time.Hour
+	defaultPurgeRetention = 30 * 24 * time.Hour
+
+	defaultIdempotencyTTL = 24 * time.Hour
+
+	defaultEventsBufferSize = 1000
+
+	defaultOutboxInterval  = 500 * time.Millisecond
+	defaultOutboxBatchSize = 100
+	defaultOutboxRetention = 7 * 24 * time.Hour
+
+	defaultSMTPPort    = "587"
+	defaultSMTPTimeout = 10 * time.Second
+
+	defaultSMSRateLimit  = 5
+	defaultSMSRateWindow = time.Hour
+
+	defaultNotificationLocale       = "en"
+	defaultNotificationPollInterval = time.Second
+	defaultNotificationMaxAttempts  = 5
+	defaultNotificationBackoff      = 30 * time.Second
+
+	defaultReminderInterval = time.Minute
+
+	defaultBusyHorizon      = 180 * 24 * time.Hour
+	defaultBusySyncInterval = 15 * time.Minute
+	defaultBusyTimeout      = 30 * time.Second
+
+	defaultWorkingHoursStart    = "09:00"
+	defaultWorkingHoursEnd      = "17:00"
+	defaultWorkingHoursTimeZone = "UTC"
+
+	defaultMeetingBaseURL = "https://meet.jit.si"
+
+	defaultBlobProvider = "local"
+	defaultBlobPath     = "data/blobs"
+	defaultBlobMaxSize  = 10 << 20
+
+	defaultWebhookPollInterval = time.Second
+	defaultWebhookMaxAttempts  = 8
+	defaultWebhookBackoff      = 10 * time.Second
+	defaultWebhookTimeout      = 10 * time.Second
 )
 
 type (
 	Configs struct {
-		APP      AppConfig
-		POSTGRES StoreConfig
+		APP         AppConfig
+		AUTH        AuthConfig
+		POSTGRES    StoreConfig
+		PURGE       PurgeConfig
+		IDEMPOTENCY IdempotencyConfig
+		EVENTS      EventsConfig
+		OUTBOX      OutboxConfig
+		WEBHOOK     WebhookConfig
+
+		SMTP         SMTPConfig
+		SMS          SMSConfig
+		NOTIFICATION NotificationConfig
+		REMINDER     ReminderConfig
+
+		BUSY          BusyConfig
+		WORKING_HOURS WorkingHoursConfig
+		MEETING       MeetingConfig
+
+		BLOB BlobConfig
 	}
 
+	// AppConfig holds the listeners of the API, the gRPC server only listens
+	// on GRPCHost, the loopback unless it is set to expose the server
 	AppConfig struct {
-		Mode    string
-		Port    string
-		Path    string
-		Timeout time.Duration
+		Mode     string
+		Port     string
+		GRPCHost string `envconfig:"GRPC_HOST"`
+		GRPCPort string `envconfig:"GRPC_PORT"`
+		Path     string
+		Timeout  time.Duration
+	}
+
+	// AuthConfig holds the authentication of the API, the requests without
+	// an organization key belong to the default organization unless Required,
+	// AdminKey lets the tenant admin endpoints in
+	AuthConfig struct {
+		Required bool
+		AdminKey string `envconfig:"ADMIN_KEY"`
 	}
 
 	StoreConfig struct {
 		DSN string
 	}
+
+	PurgeConfig struct {
+		Interval  time.Duration
+		Retention time.Duration
+	}
+
+	IdempotencyConfig struct {
+		TTL time.Duration
+	}
+
+	EventsConfig struct {
+		BufferSize int `envconfig:"BUFFER_SIZE"`
+	}
+
+	OutboxConfig struct {
+		Interval  time.Duration
+		BatchSize int `envconfig:"BATCH_SIZE"`
+		Retention time.Duration
+		Sinks     []string
+	}
+
+	SMTPConfig struct {
+		Host     string
+		Port     string
+		Username string
+		Password string
+		From     string
+		Timeout  time.Duration
+	}
+
+	SMSConfig struct {
+		Provider   string
+		File       string
+		RateLimit  int           `envconfig:"RATE_LIMIT"`
+		RateWindow time.Duration `envconfig:"RATE_WINDOW"`
+	}
+
+	NotificationConfig struct {
+		Locale       string
+		PollInterval time.Duration `envconfig:"POLL_INTERVAL"`
+		MaxAttempts  int           `envconfig:"MAX_ATTEMPTS"`
+		Backoff      time.Duration
+	}
+
+	ReminderConfig struct {
+		Offsets  []time.Duration
+		Interval time.Duration
+	}
+
+	// BusyConfig holds the calendar feeds, the feeds may only be fetched from
+	// public addresses except for the AllowedHosts
+	BusyConfig struct {
+		Horizon      time.Duration
+		SyncInterval time.Duration `envconfig:"SYNC_INTERVAL"`
+		Timeout      time.Duration
+		AllowedHosts []string `envconfig:"ALLOWED_HOSTS"`
+	}
+
+	// WorkingHoursConfig holds the working hours of recruiters, Days are
+	// given as MO, TU, WE, TH, FR, SA and SU
+	WorkingHoursConfig struct {
+		Start    string
+		End      string
+		Days     []string
+		TimeZone string `envconfig:"TIME_ZONE"`
+	}
+
+	MeetingConfig struct {
+		Provider string
+		BaseURL  string `envconfig:"BASE_URL"`
+		Secret   string
+	}
+
+	// BlobConfig holds the store of the candidate attachments, MaxSize is
+	// the largest accepted file in bytes
+	BlobConfig struct {
+		Provider string
+		Path     string
+		MaxSize  int64 `envconfig:"MAX_SIZE"`
+	}
+
+	// WebhookConfig holds the webhook deliveries, the subscriptions may only
+	// point to public addresses except for the AllowedHosts
+	WebhookConfig struct {
+		PollInterval time.Duration `envconfig:"POLL_INTERVAL"`
+		MaxAttempts  int           `envconfig:"MAX_ATTEMPTS"`
+		Backoff      time.Duration
+		Timeout      time.Duration
+		AllowedHosts []string `envconfig:"ALLOWED_HOSTS"`
+	}
 )
 
 // New populates Configs struct with values from config file
@@ -43,19 +207,149 @@ func New() (cfg Configs, err error) {
 	godotenv.Load(filepath.Join(root, ".env"))
 
 	cfg.APP = AppConfig{
-		Mode:    defaultAppMode,
-		Port:    defaultAppPort,
-		Path:    defaultAppPath,
-		Timeout: defaultAppTimeout,
+		Mode:     defaultAppMode,
+		Port:     defaultAppPort,
+		GRPCHost: defaultGRPCHost,
+		GRPCPort: defaultGRPCPort,
+		Path:     defaultAppPath,
+		Timeout:  defaultAppTimeout,
 	}
 
 	if err = envconfig.Process("APP", &cfg.APP); err != nil {
 		return
 	}
 
+	if err = envconfig.Process("AUTH", &cfg.AUTH); err != nil {
+		return
+	}
+
 	if err = envconfig.Process("POSTGRES", &cfg.POSTGRES); err != nil {
 		return
 	}
 
+	cfg.PURGE = PurgeConfig{
+		Interval:  defaultPurgeInterval,
+		Retention: defaultPurgeRetention,
+	}
+
+	if err = envconfig.Process("PURGE", &cfg.PURGE); err != nil {
+		return
+	}
+
+	cfg.IDEMPOTENCY = IdempotencyConfig{
+		TTL: defaultIdempotencyTTL,
+	}
+
+	if err = envconfig.Process("IDEMPOTENCY", &cfg.IDEMPOTENCY); err != nil {
+		return
+	}
+
+	cfg.EVENTS = EventsConfig{
+		BufferSize: defaultEventsBufferSize,
+	}
+
+	if err = envconfig.Process("EVENTS", &cfg.EVENTS); err != nil {
+		return
+	}
+
+	cfg.OUTBOX = OutboxConfig{
+		Interval:  defaultOutboxInterval,
+		BatchSize: defaultOutboxBatchSize,
+		Retention: defaultOutboxRetention,
+		Sinks:     []string{"bus"},
+	}
+
+	if err = envconfig.Process("OUTBOX", &cfg.OUTBOX); err != nil {
+		return
+	}
+
+	cfg.WEBHOOK = WebhookConfig{
+		PollInterval: defaultWebhookPollInterval,
+		MaxAttempts:  defaultWebhookMaxAttempts,
+		Backoff:      defaultWebhookBackoff,
+		Timeout:      defaultWebhookTimeout,
+	}
+
+	if err = envconfig.Process("WEBHOOK", &cfg.WEBHOOK); err != nil {
+		return
+	}
+
+	cfg.SMTP = SMTPConfig{
+		Port:    defaultSMTPPort,
+		Timeout: defaultSMTPTimeout,
+	}
+
+	if err = envconfig.Process("SMTP", &cfg.SMTP); err != nil {
+		return
+	}
+
+	cfg.SMS = SMSConfig{
+		RateLimit:  defaultSMSRateLimit,
+		RateWindow: defaultSMSRateWindow,
+	}
+
+	if err = envconfig.Process("SMS", &cfg.SMS); err != nil {
+		return
+	}
+
+	cfg.NOTIFICATION = NotificationConfig{
+		Locale:       defaultNotificationLocale,
+		PollInterval: defaultNotificationPollInterval,
+		MaxAttempts:  defaultNotificationMaxAttempts,
+		Backoff:      defaultNotificationBackoff,
+	}
+
+	if err = envconfig.Process("NOTIFICATION", &cfg.NOTIFICATION); err != nil {
+		return
+	}
+
+	cfg.REMINDER = ReminderConfig{
+		Offsets:  []time.Duration{24 * time.Hour, time.Hour},
+		Interval: defaultReminderInterval,
+	}
+
+	if err = envconfig.Process("REMINDER", &cfg.REMINDER); err != nil {
+		return
+	}
+
+	cfg.BUSY = BusyConfig{
+		Horizon:      defaultBusyHorizon,
+		SyncInterval: defaultBusySyncInterval,
+		Timeout:      defaultBusyTimeout,
+	}
+
+	if err = envconfig.Process("BUSY", &cfg.BUSY); err != nil {
+		return
+	}
+
+	cfg.WORKING_HOURS = WorkingHoursConfig{
+		Start:    defaultWorkingHoursStart,
+		End:      defaultWorkingHoursEnd,
+		Days:     []string{"MO", "TU", "WE", "TH", "FR"},
+		TimeZone: defaultWorkingHoursTimeZone,
+	}
+
+	if err = envconfig.Process("WORKING_HOURS", &cfg.WORKING_HOURS); err != nil {
+		return
+	}
+
+	cfg.MEETING = MeetingConfig{
+		BaseURL: defaultMeetingBaseURL,
+	}
+
+	if err = envconfig.Process("MEETING", &cfg.MEETING); err != nil {
+		return
+	}
+
+	cfg.BLOB = BlobConfig{
+		Provider: defaultBlobProvider,
+		Path:     defaultBlobPath,
+		MaxSize:  defaultBlobMaxSize,
+	}
+
+	if err = envconfig.Process("BLOB", &cfg.BLOB); err != nil {
+		return
+	}
+
 	return
 }
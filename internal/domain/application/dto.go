@@ -0,0 +1,102 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Request struct {
+	CandidateID string `json:"candidateId"`
+	VacancyID   string `json:"vacancyId"`
+	Note        string `json:"note"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.CandidateID == "" {
+		return errors.New("candidateId: cannot be blank")
+	}
+
+	if s.VacancyID == "" {
+		return errors.New("vacancyId: cannot be blank")
+	}
+
+	return nil
+}
+
+// MoveRequest moves the application to another stage of the pipeline or
+// closes it with a final status, or both
+type MoveRequest struct {
+	Stage  string `json:"stage"`
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
+
+func (s *MoveRequest) Bind(r *http.Request) error {
+	s.Stage = strings.ToLower(strings.TrimSpace(s.Stage))
+	if s.Stage == "" && s.Status == "" {
+		return errors.New("stage: cannot be blank unless status is set")
+	}
+
+	if s.Status != "" && !IsFinal(s.Status) {
+		return fmt.Errorf("status: must be %s, %s or %s", StatusHired, StatusRejected, StatusWithdrawn)
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID          string               `json:"id"`
+	CandidateID string               `json:"candidateId"`
+	VacancyID   string               `json:"vacancyId"`
+	Stage       string               `json:"stage"`
+	Status      string               `json:"status"`
+	AppliedAt   time.Time            `json:"appliedAt"`
+	History     []TransitionResponse `json:"history"`
+	Version     int                  `json:"version"`
+}
+
+type TransitionResponse struct {
+	FromStage string    `json:"fromStage,omitempty"`
+	Stage     string    `json:"stage"`
+	Status    string    `json:"status"`
+	Note      string    `json:"note,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:          data.ID,
+		CandidateID: *data.CandidateID,
+		VacancyID:   *data.VacancyID,
+		Stage:       *data.Stage,
+		Status:      *data.Status,
+		History:     make([]TransitionResponse, 0, len(data.History)),
+		Version:     data.Version,
+	}
+	if data.AppliedAt != nil {
+		res.AppliedAt = *data.AppliedAt
+	}
+	for _, transition := range data.History {
+		res.History = append(res.History, TransitionResponse{
+			FromStage: transition.FromStage,
+			Stage:     transition.Stage,
+			Status:    transition.Status,
+			Note:      transition.Note,
+			Actor:     transition.Actor,
+			ChangedAt: transition.ChangedAt,
+		})
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package application
+
+import "time"
+
+const (
+	StatusActive    = "active"
+	StatusHired     = "hired"
+	StatusRejected  = "rejected"
+	StatusWithdrawn = "withdrawn"
+)
+
+// Entity is the application of a candidate to a vacancy, an active
+// application is at one of the stages of the pipeline of the vacancy until
+// the candidate is hired, rejected or withdraws
+type Entity struct {
+	ID          string     `db:"id" bson:"_id"`
+	TenantID    string     `db:"tenant_id" bson:"tenant_id"`
+	CandidateID *string    `db:"candidate_id" bson:"candidate_id"`
+	VacancyID   *string    `db:"vacancy_id" bson:"vacancy_id"`
+	Stage       *string    `db:"stage" bson:"stage"`
+	Status      *string    `db:"status" bson:"status"`
+	AppliedAt   *time.Time `db:"applied_at" bson:"applied_at"`
+	Version     int        `db:"version" bson:"version"`
+	// History are the transitions of the application, the oldest first
+	History []Transition `db:"-" bson:"history"`
+}
+
+// Transition is a change of the stage or the status of an application,
+// FromStage is blank for the transition the application is created with
+type Transition struct {
+	ApplicationID string    `db:"application_id" bson:"application_id"`
+	FromStage     string    `db:"from_stage" bson:"from_stage"`
+	Stage         string    `db:"stage" bson:"stage"`
+	Status        string    `db:"status" bson:"status"`
+	Note          string    `db:"note" bson:"note"`
+	Actor         string    `db:"actor" bson:"actor"`
+	ChangedAt     time.Time `db:"changed_at" bson:"changed_at"`
+}
+
+// Filter narrows the entities returned by Repository.List, empty fields are
+// not applied
+type Filter struct {
+	CandidateID string
+	VacancyID   string
+	Stage       string
+	Status      string
+}
+
+func (f Filter) Match(e Entity) bool {
+	if f.CandidateID != "" && *e.CandidateID != f.CandidateID {
+		return false
+	}
+	if f.VacancyID != "" && *e.VacancyID != f.VacancyID {
+		return false
+	}
+	if f.Stage != "" && *e.Stage != f.Stage {
+		return false
+	}
+	if f.Status != "" && *e.Status != f.Status {
+		return false
+	}
+	return true
+}
+
+// IsFinal reports whether no further transitions are allowed from the status
+func IsFinal(status string) bool {
+	return status == StatusHired || status == StatusRejected || status == StatusWithdrawn
+}
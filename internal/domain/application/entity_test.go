@@ -0,0 +1,68 @@
+package application
+
+import "testing"
+
+func TestIsFinal(t *testing.T) {
+	tests := map[string]bool{
+		StatusActive:    false,
+		StatusHired:     true,
+		StatusRejected:  true,
+		StatusWithdrawn: true,
+		"":              false,
+	}
+
+	for status, want := range tests {
+		if got := IsFinal(status); got != want {
+			t.Errorf("IsFinal(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestRequestBind(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     Request
+		wantErr bool
+	}{
+		{name: "valid", req: Request{CandidateID: "candidate", VacancyID: "vacancy"}},
+		{name: "no candidate", req: Request{VacancyID: "vacancy"}, wantErr: true},
+		{name: "no vacancy", req: Request{CandidateID: "candidate"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Bind(nil); (err != nil) != tt.wantErr {
+				t.Errorf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMoveRequestBind(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       MoveRequest
+		wantErr   bool
+		wantStage string
+	}{
+		{name: "stage", req: MoveRequest{Stage: " Onsite "}, wantStage: "onsite"},
+		{name: "final status", req: MoveRequest{Status: StatusRejected}},
+		{name: "stage and final status", req: MoveRequest{Stage: "offer", Status: StatusHired}, wantStage: "offer"},
+		{name: "neither", req: MoveRequest{Note: "moving on"}, wantErr: true},
+		{name: "blank stage", req: MoveRequest{Stage: " "}, wantErr: true},
+		{name: "active status", req: MoveRequest{Stage: "offer", Status: StatusActive}, wantErr: true},
+		{name: "unknown status", req: MoveRequest{Status: "paused"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Bind(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.req.Stage != tt.wantStage {
+				t.Errorf("Bind() stage = %q, want %q", tt.req.Stage, tt.wantStage)
+			}
+		})
+	}
+}
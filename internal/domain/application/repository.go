@@ -0,0 +1,16 @@
+package application
+
+import "context"
+
+// Repository stores entities under optimistic concurrency control, Move
+// fails with store.ErrorConflict when the version of the stored entity
+// differs from the expected one, version 0 skips the check.
+type Repository interface {
+	List(ctx context.Context, filter Filter) (dest []Entity, err error)
+	// Add stores the application along with its History
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	// Move sets the stage and the status of the application to the ones of
+	// the transition and appends it to the history
+	Move(ctx context.Context, id string, version int, data Transition) (err error)
+}
@@ -0,0 +1,68 @@
+package audit
+
+import "context"
+
+const anonymous = "anonymous"
+
+// PrincipalAdmin is the principal of the requests bearing the admin key
+const PrincipalAdmin = "admin"
+
+type (
+	actor     struct{}
+	principal struct{}
+)
+
+// ContextWithActor adds the actor named by the client to context, it is
+// advisory and only recorded alongside the authenticated principal
+func ContextWithActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, actor{}, name)
+}
+
+// ContextWithPrincipal adds the authenticated principal of the request to context
+func ContextWithPrincipal(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, principal{}, name)
+}
+
+// PrincipalFromContext returns the authenticated principal from context,
+// it is empty when the request was not authenticated
+func PrincipalFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(principal{}).(string)
+	return name
+}
+
+// IsAdmin reports whether the request of ctx bears the admin key
+func IsAdmin(ctx context.Context) bool {
+	return PrincipalFromContext(ctx) == PrincipalAdmin
+}
+
+// ActorFromContext returns the actor recorded in the audit log, the principal
+// followed by the actor named by the client when both are known
+func ActorFromContext(ctx context.Context) string {
+	by := PrincipalFromContext(ctx)
+	name, _ := ctx.Value(actor{}).(string)
+
+	switch {
+	case by != "" && name != "":
+		return by + " as " + name
+	case by != "":
+		return by
+	case name != "":
+		return name
+	}
+
+	return anonymous
+}
+
+type recruiter struct{}
+
+// ContextWithRecruiter adds the recruiter the authenticated principal acts as to context
+func ContextWithRecruiter(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, recruiter{}, id)
+}
+
+// RecruiterFromContext returns the recruiter the authenticated principal acts
+// as, it is empty unless the request bears a key issued to a recruiter
+func RecruiterFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(recruiter{}).(string)
+	return id
+}
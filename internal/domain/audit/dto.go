@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Response struct {
+	ID         string            `json:"id"`
+	Actor      string            `json:"actor"`
+	Action     string            `json:"action"`
+	EntityType string            `json:"entityType"`
+	EntityID   string            `json:"entityId"`
+	Changes    map[string]Change `json:"changes"`
+	RequestID  string            `json:"requestId,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:         data.ID,
+		Actor:      data.Actor,
+		Action:     data.Action,
+		EntityType: data.EntityType,
+		EntityID:   data.EntityID,
+		RequestID:  data.RequestID,
+		CreatedAt:  data.CreatedAt,
+	}
+	json.Unmarshal(data.Changes, &res.Changes)
+
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
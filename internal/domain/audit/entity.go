@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionPurge   = "purge"
+	ActionCancel  = "cancel"
+	ActionRespond = "respond"
+	ActionMove    = "move"
+)
+
+type Entity struct {
+	ID         string          `db:"id" bson:"_id"`
+	TenantID   string          `db:"tenant_id" bson:"tenant_id"`
+	Actor      string          `db:"actor" bson:"actor"`
+	Action     string          `db:"action" bson:"action"`
+	EntityType string          `db:"entity_type" bson:"entity_type"`
+	EntityID   string          `db:"entity_id" bson:"entity_id"`
+	Changes    json.RawMessage `db:"changes" bson:"changes"`
+	RequestID  string          `db:"request_id" bson:"request_id"`
+	CreatedAt  time.Time       `db:"created_at" bson:"created_at"`
+}
+
+// Filter narrows the audit entries returned by Repository.List,
+// empty fields are not applied.
+type Filter struct {
+	EntityType string
+	EntityID   string
+}
+
+// Change holds the old and the new value of a single field.
+type Change struct {
+	Old any `json:"old,omitempty"`
+	New any `json:"new,omitempty"`
+}
+
+// Diff returns the fields that differ between before and after, both values
+// are compared by their JSON representation so any response struct can be passed.
+// A nil before or after stands for a created or a deleted entity.
+func Diff(before, after any) (changes map[string]Change, err error) {
+	old, err := toMap(before)
+	if err != nil {
+		return
+	}
+
+	cur, err := toMap(after)
+	if err != nil {
+		return
+	}
+
+	changes = make(map[string]Change)
+	for key, value := range old {
+		if !equal(value, cur[key]) {
+			changes[key] = Change{Old: value, New: cur[key]}
+		}
+	}
+	for key, value := range cur {
+		if _, ok := old[key]; !ok {
+			changes[key] = Change{New: value}
+		}
+	}
+
+	return
+}
+
+func toMap(data any) (dest map[string]any, err error) {
+	dest = make(map[string]any)
+	if data == nil {
+		return
+	}
+
+	src, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(src, &dest)
+
+	return
+}
+
+func equal(a, b any) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+
+	return string(x) == string(y)
+}
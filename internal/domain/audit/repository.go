@@ -0,0 +1,8 @@
+package audit
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+}
@@ -0,0 +1,36 @@
+package availability
+
+import "time"
+
+// Request asks for the windows of at least Duration within [From, To) in
+// which all the recruiters are free
+type Request struct {
+	RecruiterIDs []string
+	From         time.Time
+	To           time.Time
+	Duration     time.Duration
+	Limit        int
+}
+
+type Response struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	Minutes  int       `json:"minutes"`
+}
+
+func ParseFromWindow(data Window) (res Response) {
+	res = Response{
+		StartsAt: data.StartsAt,
+		EndsAt:   data.EndsAt,
+		Minutes:  int(data.Duration() / time.Minute),
+	}
+	return
+}
+
+func ParseFromWindows(data []Window) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromWindow(object))
+	}
+	return
+}
@@ -0,0 +1,193 @@
+package availability
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Window is the half-open interval of time [StartsAt, EndsAt)
+type Window struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+func (w Window) Duration() time.Duration {
+	return w.EndsAt.Sub(w.StartsAt)
+}
+
+// WorkingHours are the daily hours [Start, End) after midnight in Location
+// on the working days of the week
+type WorkingHours struct {
+	Days     []time.Weekday
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+// ParseWorkingHours reads working hours from clock times such as 09:00, the
+// days MO, TU, WE, TH, FR, SA and SU and an IANA time zone name
+func ParseWorkingHours(start, end string, days []string, zone string) (dest WorkingHours, err error) {
+	if dest.Start, err = parseClock(start); err != nil {
+		return
+	}
+	if dest.End, err = parseClock(end); err != nil {
+		return
+	}
+	if dest.End <= dest.Start {
+		return dest, fmt.Errorf("working hours end %s must be after their start %s", end, start)
+	}
+
+	for _, day := range days {
+		weekday, ok := weekdays[strings.ToUpper(strings.TrimSpace(day))]
+		if !ok {
+			return dest, fmt.Errorf("unknown working day %q", day)
+		}
+		dest.Days = append(dest.Days, weekday)
+	}
+
+	if dest.Location, err = time.LoadLocation(zone); err != nil {
+		return
+	}
+
+	return
+}
+
+func parseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock time %q, expected HH:MM", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// Windows returns the working hours within [from, to)
+func (h WorkingHours) Windows(from, to time.Time) (dest []Window) {
+	loc := h.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	working := make(map[time.Weekday]bool, len(h.Days))
+	for _, day := range h.Days {
+		working[day] = true
+	}
+
+	local := from.In(loc)
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !working[day.Weekday()] {
+			continue
+		}
+		// build the clock times from the date so that they hold across daylight saving changes
+		start := time.Date(day.Year(), day.Month(), day.Day(), int(h.Start.Hours()), int(h.Start.Minutes())%60, 0, 0, loc)
+		end := time.Date(day.Year(), day.Month(), day.Day(), int(h.End.Hours()), int(h.End.Minutes())%60, 0, 0, loc)
+
+		dest = append(dest, Intersect([]Window{{StartsAt: start, EndsAt: end}}, []Window{{StartsAt: from, EndsAt: to}})...)
+	}
+
+	return
+}
+
+// Day returns the calendar day of t in loc
+func Day(t time.Time, loc *time.Location) Window {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return Window{StartsAt: start, EndsAt: start.AddDate(0, 0, 1)}
+}
+
+// Week returns the calendar week of t in loc, starting on Monday
+func Week(t time.Time, loc *time.Location) Window {
+	day := Day(t, loc)
+	offset := (int(day.StartsAt.Weekday()) + 6) % 7
+	start := day.StartsAt.AddDate(0, 0, -offset)
+	return Window{StartsAt: start, EndsAt: start.AddDate(0, 0, 7)}
+}
+
+// Merge returns the union of the windows as sorted, disjoint windows
+func Merge(windows []Window) (dest []Window) {
+	sorted := append([]Window(nil), windows...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartsAt.Before(sorted[j].StartsAt)
+	})
+
+	for _, window := range sorted {
+		if !window.EndsAt.After(window.StartsAt) {
+			continue
+		}
+		if n := len(dest); n > 0 && !window.StartsAt.After(dest[n-1].EndsAt) {
+			if window.EndsAt.After(dest[n-1].EndsAt) {
+				dest[n-1].EndsAt = window.EndsAt
+			}
+			continue
+		}
+		dest = append(dest, window)
+	}
+
+	return
+}
+
+// Subtract returns the parts of the windows not covered by any of the taken ones
+func Subtract(windows, taken []Window) (dest []Window) {
+	taken = Merge(taken)
+
+	for _, window := range Merge(windows) {
+		start := window.StartsAt
+		for _, t := range taken {
+			if !t.EndsAt.After(start) {
+				continue
+			}
+			if !t.StartsAt.Before(window.EndsAt) {
+				break
+			}
+			if t.StartsAt.After(start) {
+				dest = append(dest, Window{StartsAt: start, EndsAt: t.StartsAt})
+			}
+			start = t.EndsAt
+		}
+		if window.EndsAt.After(start) {
+			dest = append(dest, Window{StartsAt: start, EndsAt: window.EndsAt})
+		}
+	}
+
+	return
+}
+
+// Intersect returns the parts of time covered by both a and b
+func Intersect(a, b []Window) (dest []Window) {
+	a, b = Merge(a), Merge(b)
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].StartsAt, a[i].EndsAt
+		if b[j].StartsAt.After(start) {
+			start = b[j].StartsAt
+		}
+		if b[j].EndsAt.Before(end) {
+			end = b[j].EndsAt
+		}
+		if end.After(start) {
+			dest = append(dest, Window{StartsAt: start, EndsAt: end})
+		}
+
+		if a[i].EndsAt.Before(b[j].EndsAt) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return
+}
@@ -0,0 +1,208 @@
+package availability
+
+import (
+	"testing"
+	"time"
+)
+
+// at returns 5 January 2026 at the hour in UTC
+func at(hour int) time.Time {
+	return time.Date(2026, 1, 5, hour, 0, 0, 0, time.UTC)
+}
+
+func window(start, end int) Window {
+	return Window{StartsAt: at(start), EndsAt: at(end)}
+}
+
+func equalWindows(t *testing.T, got, want []Window) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].StartsAt.Equal(want[i].StartsAt) || !got[i].EndsAt.Equal(want[i].EndsAt) {
+			t.Errorf("window %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []Window
+		want    []Window
+	}{
+		{name: "empty", windows: nil, want: nil},
+		{name: "unsorted and disjoint", windows: []Window{window(13, 14), window(9, 10)}, want: []Window{window(9, 10), window(13, 14)}},
+		{name: "overlapping", windows: []Window{window(9, 11), window(10, 12)}, want: []Window{window(9, 12)}},
+		{name: "adjacent", windows: []Window{window(9, 10), window(10, 11)}, want: []Window{window(9, 11)}},
+		{name: "contained", windows: []Window{window(9, 17), window(10, 11)}, want: []Window{window(9, 17)}},
+		{name: "empty windows are dropped", windows: []Window{window(9, 9), window(11, 10)}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equalWindows(t, Merge(tt.windows), tt.want)
+		})
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []Window
+		taken   []Window
+		want    []Window
+	}{
+		{name: "nothing taken", windows: []Window{window(9, 17)}, taken: nil, want: []Window{window(9, 17)}},
+		{name: "taken in the middle", windows: []Window{window(9, 17)}, taken: []Window{window(12, 13)}, want: []Window{window(9, 12), window(13, 17)}},
+		{name: "taken at the edges", windows: []Window{window(9, 17)}, taken: []Window{window(8, 10), window(16, 18)}, want: []Window{window(10, 16)}},
+		{name: "all taken", windows: []Window{window(9, 17)}, taken: []Window{window(8, 18)}, want: nil},
+		{name: "taken outside", windows: []Window{window(9, 12)}, taken: []Window{window(13, 14)}, want: []Window{window(9, 12)}},
+		{
+			name:    "several windows",
+			windows: []Window{window(9, 12), window(13, 17)},
+			taken:   []Window{window(11, 14), window(15, 16)},
+			want:    []Window{window(9, 11), window(14, 15), window(16, 17)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equalWindows(t, Subtract(tt.windows, tt.taken), tt.want)
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []Window
+		want []Window
+	}{
+		{name: "one side empty", a: []Window{window(9, 17)}, b: nil, want: nil},
+		{name: "disjoint", a: []Window{window(9, 10)}, b: []Window{window(11, 12)}, want: nil},
+		{name: "adjacent", a: []Window{window(9, 10)}, b: []Window{window(10, 11)}, want: nil},
+		{name: "overlapping", a: []Window{window(9, 12)}, b: []Window{window(11, 14)}, want: []Window{window(11, 12)}},
+		{name: "contained", a: []Window{window(9, 17)}, b: []Window{window(10, 11)}, want: []Window{window(10, 11)}},
+		{
+			name: "several windows on both sides",
+			a:    []Window{window(9, 12), window(13, 17)},
+			b:    []Window{window(8, 10), window(11, 14), window(16, 18)},
+			want: []Window{window(9, 10), window(11, 12), window(13, 14), window(16, 17)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equalWindows(t, Intersect(tt.a, tt.b), tt.want)
+			equalWindows(t, Intersect(tt.b, tt.a), tt.want)
+		})
+	}
+}
+
+func TestParseWorkingHours(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		days    []string
+		zone    string
+		wantErr bool
+	}{
+		{name: "weekdays", start: "09:00", end: "17:30", days: []string{"MO", "tu", " WE "}, zone: "UTC"},
+		{name: "end before start", start: "17:00", end: "09:00", days: []string{"MO"}, zone: "UTC", wantErr: true},
+		{name: "bad clock", start: "9am", end: "17:00", days: []string{"MO"}, zone: "UTC", wantErr: true},
+		{name: "bad day", start: "09:00", end: "17:00", days: []string{"Monday"}, zone: "UTC", wantErr: true},
+		{name: "bad zone", start: "09:00", end: "17:00", days: []string{"MO"}, zone: "Mars/Olympus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWorkingHours(tt.start, tt.end, tt.days, tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWorkingHours() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursWindows(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	tests := []struct {
+		name     string
+		hours    WorkingHours
+		from, to time.Time
+		want     []Window
+	}{
+		{
+			name:  "working days only",
+			hours: WorkingHours{Days: []time.Weekday{time.Monday, time.Wednesday}, Start: 9 * time.Hour, End: 17 * time.Hour},
+			from:  at(0),
+			to:    at(0).AddDate(0, 0, 3),
+			want: []Window{
+				{StartsAt: at(9), EndsAt: at(17)},
+				{StartsAt: at(9).AddDate(0, 0, 2), EndsAt: at(17).AddDate(0, 0, 2)},
+			},
+		},
+		{
+			name:  "clipped to the range",
+			hours: WorkingHours{Days: []time.Weekday{time.Monday}, Start: 9 * time.Hour, End: 17 * time.Hour},
+			from:  at(12),
+			to:    at(15),
+			want:  []Window{window(12, 15)},
+		},
+		{
+			name:  "clock times hold across daylight saving",
+			hours: WorkingHours{Days: []time.Weekday{time.Saturday, time.Monday}, Start: 9 * time.Hour, End: 17 * time.Hour, Location: berlin},
+			from:  time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			want: []Window{
+				{StartsAt: time.Date(2026, 3, 28, 8, 0, 0, 0, time.UTC), EndsAt: time.Date(2026, 3, 28, 16, 0, 0, 0, time.UTC)},
+				{StartsAt: time.Date(2026, 3, 30, 7, 0, 0, 0, time.UTC), EndsAt: time.Date(2026, 3, 30, 15, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equalWindows(t, tt.hours.Windows(tt.from, tt.to), tt.want)
+		})
+	}
+}
+
+func TestDayAndWeek(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		loc      *time.Location
+		wantDay  time.Time
+		wantWeek time.Time
+	}{
+		{name: "monday", t: at(15), wantDay: at(0), wantWeek: at(0)},
+		{name: "sunday belongs to the week before", t: at(15).AddDate(0, 0, -1), wantDay: at(0).AddDate(0, 0, -1), wantWeek: at(0).AddDate(0, 0, -7)},
+		{name: "time zone", t: at(16), loc: tokyo, wantDay: time.Date(2026, 1, 6, 0, 0, 0, 0, tokyo), wantWeek: time.Date(2026, 1, 5, 0, 0, 0, 0, tokyo)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day, week := Day(tt.t, tt.loc), Week(tt.t, tt.loc)
+			if !day.StartsAt.Equal(tt.wantDay) || day.Duration() != 24*time.Hour {
+				t.Errorf("Day() = %v, want the day starting %v", day, tt.wantDay)
+			}
+			if !week.StartsAt.Equal(tt.wantWeek) || week.Duration() != 7*24*time.Hour {
+				t.Errorf("Week() = %v, want the week starting %v", week, tt.wantWeek)
+			}
+		})
+	}
+}
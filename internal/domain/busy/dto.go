@@ -0,0 +1,78 @@
+package busy
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type FeedRequest struct {
+	URL string `json:"url"`
+}
+
+func (s *FeedRequest) Bind(r *http.Request) error {
+	if s.URL == "" {
+		return errors.New("url: cannot be blank")
+	}
+
+	target, err := url.Parse(s.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errors.New("url: must be an absolute http(s) url")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID          string    `json:"id"`
+	RecruiterID string    `json:"recruiterId"`
+	Source      string    `json:"source"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+}
+
+func ParseFromEntity(data Block) (res Response) {
+	res = Response{
+		ID:          data.ID,
+		RecruiterID: data.RecruiterID,
+		Source:      data.Source,
+		StartsAt:    data.StartsAt,
+		EndsAt:      data.EndsAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Block) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+type FeedResponse struct {
+	RecruiterID string     `json:"recruiterId"`
+	URL         string     `json:"url"`
+	SyncedAt    *time.Time `json:"syncedAt,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func ParseFromFeed(data Feed) (res FeedResponse) {
+	res = FeedResponse{
+		RecruiterID: data.RecruiterID,
+		URL:         data.URL,
+		SyncedAt:    data.SyncedAt,
+		LastError:   data.LastError,
+		CreatedAt:   data.CreatedAt,
+	}
+	return
+}
+
+// ImportResponse tells how many busy blocks an import stored for the source
+type ImportResponse struct {
+	RecruiterID string `json:"recruiterId"`
+	Source      string `json:"source"`
+	Count       int    `json:"count"`
+}
@@ -0,0 +1,60 @@
+package busy
+
+import "time"
+
+const (
+	// SourceUpload marks the blocks imported from an uploaded calendar
+	SourceUpload = "upload"
+	// SourceFeed marks the blocks synchronized from the calendar feed of the recruiter
+	SourceFeed = "feed"
+)
+
+// Block is a period [StartsAt, EndsAt) a recruiter is busy in outside of
+// interviews, imported from an external calendar. The blocks of a source are
+// replaced as a whole by every import, so they carry no details of the events.
+type Block struct {
+	ID          string    `db:"id" bson:"_id"`
+	TenantID    string    `db:"tenant_id" bson:"tenant_id"`
+	RecruiterID string    `db:"recruiter_id" bson:"recruiter_id"`
+	Source      string    `db:"source" bson:"source"`
+	StartsAt    time.Time `db:"starts_at" bson:"starts_at"`
+	EndsAt      time.Time `db:"ends_at" bson:"ends_at"`
+	CreatedAt   time.Time `db:"created_at" bson:"created_at"`
+}
+
+// Feed is the external calendar of a recruiter synchronized periodically
+type Feed struct {
+	RecruiterID string     `db:"recruiter_id" bson:"_id"`
+	TenantID    string     `db:"tenant_id" bson:"tenant_id"`
+	URL         string     `db:"url" bson:"url"`
+	SyncedAt    *time.Time `db:"synced_at" bson:"synced_at"`
+	LastError   string     `db:"last_error" bson:"last_error"`
+	CreatedAt   time.Time  `db:"created_at" bson:"created_at"`
+}
+
+// Filter narrows the blocks returned by Repository.List, From and To select
+// the blocks overlapping [From, To), empty fields are not applied
+type Filter struct {
+	RecruiterIDs []string
+	From         time.Time
+	To           time.Time
+}
+
+func (f Filter) Match(b Block) bool {
+	if len(f.RecruiterIDs) > 0 {
+		found := false
+		for _, id := range f.RecruiterIDs {
+			found = found || b.RecruiterID == id
+		}
+		if !found {
+			return false
+		}
+	}
+	if !f.From.IsZero() && !b.EndsAt.After(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !b.StartsAt.Before(f.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,17 @@
+package busy
+
+import "context"
+
+type Repository interface {
+	List(ctx context.Context, filter Filter) (dest []Block, err error)
+	// Replace swaps the blocks of the recruiter imported from the source for data
+	Replace(ctx context.Context, recruiterID, source string, data []Block) (err error)
+}
+
+type FeedRepository interface {
+	List(ctx context.Context) (dest []Feed, err error)
+	Get(ctx context.Context, recruiterID string) (dest Feed, err error)
+	// Save stores the feed of the recruiter, replacing the previous one
+	Save(ctx context.Context, data Feed) (err error)
+	Delete(ctx context.Context, recruiterID string) (err error)
+}
@@ -0,0 +1,7 @@
+package calendar
+
+// FeedTokenResponse holds the token of a calendar feed, it is returned only when issued
+type FeedTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
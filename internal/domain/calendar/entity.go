@@ -0,0 +1,33 @@
+package calendar
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	OwnerCandidate = "candidate"
+	OwnerRecruiter = "recruiter"
+)
+
+// FeedToken authenticates the calendar feed of a candidate or recruiter, only
+// the hash of the token is stored so that it is shown once when issued
+type FeedToken struct {
+	OwnerType string    `db:"owner_type" bson:"owner_type"`
+	TenantID  string    `db:"tenant_id" bson:"tenant_id"`
+	OwnerID   string    `db:"owner_id" bson:"owner_id"`
+	TokenHash string    `db:"token_hash" bson:"token_hash"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify reports whether the token is the one the feed token was issued with
+func (e FeedToken) Verify(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(e.TokenHash)) == 1
+}
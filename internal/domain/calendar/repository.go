@@ -0,0 +1,9 @@
+package calendar
+
+import "context"
+
+type FeedTokenRepository interface {
+	Get(ctx context.Context, ownerType, ownerID string) (dest FeedToken, err error)
+	// Save stores the feed token of the owner, replacing the previous one
+	Save(ctx context.Context, data FeedToken) (err error)
+}
@@ -2,13 +2,28 @@ package candidate
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	maxSkills          = 50
+	maxLinks           = 10
+	maxExperienceYears = 70
+	maxNoteLength      = 10000
 )
 
 type Request struct {
-	FullName string `json:"fullname"`
-	Email    string `json:"email"`
-	Phone    int    `json:"phone"`
+	FullName        string   `json:"fullname"`
+	Email           string   `json:"email"`
+	Phone           int      `json:"phone"`
+	Skills          []string `json:"skills"`
+	ExperienceYears int      `json:"experienceYears"`
+	Location        string   `json:"location"`
+	Links           []string `json:"links"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
@@ -17,29 +32,87 @@ func (s *Request) Bind(r *http.Request) error {
 	}
 
 	if s.Email == "" {
-		return errors.New("pseudonym: cannot be blank")
+		return errors.New("email: cannot be blank")
 	}
 
 	if s.Phone == 0 {
 		return errors.New("phone: cannot be blank")
 	}
 
+	skills := make([]string, 0, len(s.Skills))
+	for _, skill := range s.Skills {
+		skill = strings.TrimSpace(skill)
+		if skill == "" {
+			return errors.New("skills: skill cannot be blank")
+		}
+		if !Strings(skills).Has(skill) {
+			skills = append(skills, skill)
+		}
+	}
+	if len(skills) > maxSkills {
+		return fmt.Errorf("skills: at most %d skills", maxSkills)
+	}
+	s.Skills = skills
+
+	if s.ExperienceYears < 0 || s.ExperienceYears > maxExperienceYears {
+		return fmt.Errorf("experienceYears: must be between 0 and %d", maxExperienceYears)
+	}
+
+	s.Location = strings.TrimSpace(s.Location)
+
+	links := make([]string, 0, len(s.Links))
+	for _, link := range s.Links {
+		link = strings.TrimSpace(link)
+		if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("links: %s is not an http or https URL", link)
+		}
+		if !Strings(links).Has(link) {
+			links = append(links, link)
+		}
+	}
+	if len(links) > maxLinks {
+		return fmt.Errorf("links: at most %d links", maxLinks)
+	}
+	s.Links = links
+
 	return nil
 }
 
 type Response struct {
-	ID       string `json:"id"`
-	FullName string `json:"fullName"`
-	Email    string `json:"email"`
-	Phone    int    `json:"phone"`
+	ID              string     `json:"id"`
+	FullName        string     `json:"fullName"`
+	Email           string     `json:"email"`
+	Phone           int        `json:"phone"`
+	Skills          []string   `json:"skills"`
+	ExperienceYears int        `json:"experienceYears,omitempty"`
+	Location        string     `json:"location,omitempty"`
+	Links           []string   `json:"links"`
+	DeletedAt       *time.Time `json:"deletedAt,omitempty"`
+	Version         int        `json:"version"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
 	res = Response{
-		ID:       data.ID,
-		FullName: *data.FullName,
-		Email:    *data.Email,
-		Phone:    *data.Phone,
+		ID:        data.ID,
+		FullName:  *data.FullName,
+		Email:     *data.Email,
+		Phone:     *data.Phone,
+		Skills:    []string{},
+		Links:     []string{},
+		DeletedAt: data.DeletedAt,
+		Version:   data.Version,
+	}
+	if data.Skills != nil {
+		res.Skills = append(res.Skills, *data.Skills...)
+	}
+	if data.ExperienceYears != nil {
+		res.ExperienceYears = *data.ExperienceYears
+	}
+	if data.Location != nil {
+		res.Location = *data.Location
+	}
+	if data.Links != nil {
+		res.Links = append(res.Links, *data.Links...)
 	}
 	return
 }
@@ -51,3 +124,91 @@ func ParseFromEntities(data []Entity) (res []Response) {
 	}
 	return
 }
+
+type NoteRequest struct {
+	AuthorID string `json:"authorId"`
+	Text     string `json:"text"`
+}
+
+func (s *NoteRequest) Bind(r *http.Request) error {
+	if s.AuthorID == "" {
+		return errors.New("authorId: cannot be blank")
+	}
+
+	s.Text = strings.TrimSpace(s.Text)
+	if s.Text == "" {
+		return errors.New("text: cannot be blank")
+	}
+	if len(s.Text) > maxNoteLength {
+		return fmt.Errorf("text: at most %d bytes", maxNoteLength)
+	}
+
+	return nil
+}
+
+type NoteResponse struct {
+	ID          string    `json:"id"`
+	CandidateID string    `json:"candidateId"`
+	AuthorID    string    `json:"authorId"`
+	Text        string    `json:"text"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func ParseFromNote(data Note) NoteResponse {
+	return NoteResponse{
+		ID:          data.ID,
+		CandidateID: data.CandidateID,
+		AuthorID:    data.AuthorID,
+		Text:        data.Text,
+		CreatedAt:   data.CreatedAt,
+	}
+}
+
+func ParseFromNotes(data []Note) (res []NoteResponse) {
+	res = make([]NoteResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromNote(object))
+	}
+	return
+}
+
+// AttachmentTypes are the content types of the files accepted as
+// attachments by their extension
+var AttachmentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".odt":  "application/vnd.oasis.opendocument.text",
+	".rtf":  "application/rtf",
+	".txt":  "text/plain",
+}
+
+type AttachmentResponse struct {
+	ID          string    `json:"id"`
+	CandidateID string    `json:"candidateId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	UploadedAt  time.Time `json:"uploadedAt"`
+}
+
+func ParseFromAttachment(data Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          data.ID,
+		CandidateID: data.CandidateID,
+		FileName:    data.FileName,
+		ContentType: data.ContentType,
+		Size:        data.Size,
+		Checksum:    data.Checksum,
+		UploadedAt:  data.UploadedAt,
+	}
+}
+
+func ParseFromAttachments(data []Attachment) (res []AttachmentResponse) {
+	res = make([]AttachmentResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromAttachment(object))
+	}
+	return
+}
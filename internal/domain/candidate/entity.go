@@ -1,8 +1,112 @@
 package candidate
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
 type Entity struct {
-	ID       string  `db:"id" bson:"_id"`
-	FullName *string `db:"full_name" bson:"full_name"`
-	Email    *string `db:"email" bson:"email"`
-	Phone    *int    `db:"phone" bson:"phone"`
+	ID              string     `db:"id" bson:"_id"`
+	TenantID        string     `db:"tenant_id" bson:"tenant_id"`
+	FullName        *string    `db:"full_name" bson:"full_name"`
+	Email           *string    `db:"email" bson:"email"`
+	Phone           *int       `db:"phone" bson:"phone"`
+	Skills          *Strings   `db:"skills" bson:"skills"`
+	ExperienceYears *int       `db:"experience_years" bson:"experience_years"`
+	Location        *string    `db:"location" bson:"location"`
+	Links           *Strings   `db:"links" bson:"links"`
+	DeletedAt       *time.Time `db:"deleted_at" bson:"deleted_at"`
+	Version         int        `db:"version" bson:"version"`
+}
+
+// Filter narrows the entities returned by Repository.List, Skills selects
+// the candidates having all the skills
+type Filter struct {
+	IncludeDeleted bool
+	Skills         []string
+	Location       string
+}
+
+// Match applies the profile fields of the filter, deleted candidates are
+// left to the repository
+func (f Filter) Match(e Entity) bool {
+	if f.Location != "" && (e.Location == nil || !strings.EqualFold(*e.Location, f.Location)) {
+		return false
+	}
+	for _, skill := range f.Skills {
+		if e.Skills == nil || !e.Skills.Has(skill) {
+			return false
+		}
+	}
+	return true
+}
+
+// Strings are stored as a JSON array
+type Strings []string
+
+// Has reports whether the value is among the strings, ignoring case
+func (s Strings) Has(value string) bool {
+	for _, item := range s {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Strings) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(s)
+	return string(src), err
+}
+
+func (s *Strings) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, s)
+	case string:
+		return json.Unmarshal([]byte(value), s)
+	}
+	return errors.New("candidate: unsupported strings value")
+}
+
+// Note is a free-form note of a recruiter on the candidate
+type Note struct {
+	ID          string    `db:"id" bson:"_id"`
+	TenantID    string    `db:"tenant_id" bson:"tenant_id"`
+	CandidateID string    `db:"candidate_id" bson:"candidate_id"`
+	AuthorID    string    `db:"author_id" bson:"author_id"`
+	Text        string    `db:"text" bson:"text"`
+	CreatedAt   time.Time `db:"created_at" bson:"created_at"`
+}
+
+// Attachment is a file of the candidate, such as their CV, the content is
+// kept in the blob store under Key
+type Attachment struct {
+	ID          string    `db:"id" bson:"_id"`
+	TenantID    string    `db:"tenant_id" bson:"tenant_id"`
+	CandidateID string    `db:"candidate_id" bson:"candidate_id"`
+	FileName    string    `db:"file_name" bson:"file_name"`
+	ContentType string    `db:"content_type" bson:"content_type"`
+	Size        int64     `db:"size" bson:"size"`
+	Checksum    string    `db:"checksum" bson:"checksum"`
+	UploadedAt  time.Time `db:"uploaded_at" bson:"uploaded_at"`
+}
+
+// Key returns the key of the content of the attachment in the blob store
+func (a Attachment) Key() string {
+	return AttachmentPrefix(a.CandidateID) + "/" + a.ID
+}
+
+// AttachmentPrefix returns the prefix of the keys of the attachments of the candidate
+func AttachmentPrefix(candidateID string) string {
+	return "candidates/" + candidateID
 }
@@ -0,0 +1,88 @@
+package candidate
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	location := "Berlin"
+	skills := Strings{"Go", "PostgreSQL", "Kubernetes"}
+	data := Entity{Location: &location, Skills: &skills}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		data   Entity
+		want   bool
+	}{
+		{name: "empty filter", filter: Filter{}, data: Entity{}, want: true},
+		{name: "location ignoring case", filter: Filter{Location: "berlin"}, data: data, want: true},
+		{name: "other location", filter: Filter{Location: "Paris"}, data: data, want: false},
+		{name: "no location", filter: Filter{Location: "Berlin"}, data: Entity{}, want: false},
+		{name: "all the skills ignoring case", filter: Filter{Skills: []string{"go", "kubernetes"}}, data: data, want: true},
+		{name: "a missing skill", filter: Filter{Skills: []string{"go", "rust"}}, data: data, want: false},
+		{name: "no skills", filter: Filter{Skills: []string{"go"}}, data: Entity{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.data); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestBind(t *testing.T) {
+	valid := func() Request {
+		return Request{FullName: "Jane Doe", Email: "jane@example.com", Phone: 4915112345678}
+	}
+
+	tests := []struct {
+		name       string
+		change     func(req *Request)
+		wantErr    bool
+		wantSkills []string
+		wantLinks  []string
+	}{
+		{
+			name: "skills and links are trimmed and deduplicated",
+			change: func(req *Request) {
+				req.Skills = []string{" Go ", "go", "SQL"}
+				req.Links = []string{"https://example.com/jane ", "https://example.com/jane"}
+			},
+			wantSkills: []string{"Go", "SQL"},
+			wantLinks:  []string{"https://example.com/jane"},
+		},
+		{name: "blank skill", change: func(req *Request) { req.Skills = []string{"Go", " "} }, wantErr: true},
+		{name: "too many skills", change: func(req *Request) {
+			for i := 0; i <= maxSkills; i++ {
+				req.Skills = append(req.Skills, fmt.Sprint("skill ", i))
+			}
+		}, wantErr: true},
+		{name: "negative experience", change: func(req *Request) { req.ExperienceYears = -1 }, wantErr: true},
+		{name: "too much experience", change: func(req *Request) { req.ExperienceYears = maxExperienceYears + 1 }, wantErr: true},
+		{name: "link without scheme", change: func(req *Request) { req.Links = []string{"example.com/jane"} }, wantErr: true},
+		{name: "link of another scheme", change: func(req *Request) { req.Links = []string{"javascript:alert(1)"} }, wantErr: true},
+		{name: "no phone", change: func(req *Request) { req.Phone = 0 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.change(&req)
+
+			err := req.Bind(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if fmt.Sprint(req.Skills) != fmt.Sprint(tt.wantSkills) || fmt.Sprint(req.Links) != fmt.Sprint(tt.wantLinks) {
+				t.Errorf("Bind() skills %q and links %q, want %q and %q", req.Skills, req.Links, tt.wantSkills, tt.wantLinks)
+			}
+		})
+	}
+}
@@ -1,11 +1,39 @@
 package candidate
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
+// Repository stores entities under optimistic concurrency control,
+// Update and Delete fail with store.ErrorConflict when the version of the
+// stored entity differs from the expected one, version 0 skips the check.
 type Repository interface {
-	List(ctx context.Context) (dest []Entity, err error)
+	List(ctx context.Context, filter Filter) (dest []Entity, err error)
 	Add(ctx context.Context, data Entity) (id string, err error)
 	Get(ctx context.Context, id string) (dest Entity, err error)
 	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string, version int) (err error)
+	Restore(ctx context.Context, id string) (err error)
+	// Purge removes the entities deleted before, they are returned with only
+	// their id and organization set
+	Purge(ctx context.Context, before time.Time) (dest []Entity, err error)
+}
+
+// NoteRepository stores the notes of the candidates, the newest first
+type NoteRepository interface {
+	List(ctx context.Context, candidateID string) (dest []Note, err error)
+	Add(ctx context.Context, data Note) (id string, err error)
+	Get(ctx context.Context, id string) (dest Note, err error)
+	Delete(ctx context.Context, id string) (err error)
+}
+
+// AttachmentRepository stores the metadata of the attachments of the
+// candidates, their ids are chosen by the caller so that the content can be
+// stored first
+type AttachmentRepository interface {
+	List(ctx context.Context, candidateID string) (dest []Attachment, err error)
+	Add(ctx context.Context, data Attachment) (err error)
+	Get(ctx context.Context, id string) (dest Attachment, err error)
 	Delete(ctx context.Context, id string) (err error)
 }
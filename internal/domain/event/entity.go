@@ -0,0 +1,62 @@
+package event
+
+import (
+	"strings"
+	"time"
+)
+
+// Event is a change of an entity published by the services, ID grows
+// monotonically within the process and is used to resume subscriptions.
+type Event struct {
+	ID         uint64    `json:"id"`
+	TenantID   string    `json:"tenantId,omitempty"`
+	Type       string    `json:"type"`
+	EntityType string    `json:"entityType"`
+	EntityID   string    `json:"entityId"`
+	Action     string    `json:"action"`
+	Actor      string    `json:"actor,omitempty"`
+	RequestID  string    `json:"requestId,omitempty"`
+	Data       any       `json:"data,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// Type returns the event type of the action on the entity, e.g. candidate.create
+func Type(entityType, action string) string {
+	return entityType + "." + action
+}
+
+// Filter selects the events of a subscription, empty fields match any event
+type Filter struct {
+	TenantID    string
+	EntityTypes []string
+	Types       []string
+}
+
+func (f Filter) Match(e Event) bool {
+	if f.TenantID != "" && f.TenantID != e.TenantID {
+		return false
+	}
+	return contains(f.EntityTypes, e.EntityType) && contains(f.Types, e.Type)
+}
+
+// ParseList splits a comma separated query value into its non-empty items
+func ParseList(value string) (dest []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			dest = append(dest, item)
+		}
+	}
+	return
+}
+
+func contains(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package event
+
+import "context"
+
+// Publisher delivers events to the subscribers, Publish assigns the ID
+// and must not block on slow subscribers
+type Publisher interface {
+	Publish(ctx context.Context, data Event) (dest Event)
+}
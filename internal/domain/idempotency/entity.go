@@ -0,0 +1,28 @@
+package idempotency
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrorMismatch   = errors.New("idempotency key was used with a different request")
+	ErrorInProgress = errors.New("request with the idempotency key is in progress")
+)
+
+// Entity is a stored idempotency key with the fingerprint of the request
+// it was first used with and the response to replay, StatusCode stays 0
+// until the original request completes.
+type Entity struct {
+	Key         string    `db:"key" bson:"_id"`
+	Fingerprint string    `db:"fingerprint" bson:"fingerprint"`
+	StatusCode  int       `db:"status_code" bson:"status_code"`
+	ContentType string    `db:"content_type" bson:"content_type"`
+	Body        []byte    `db:"body" bson:"body"`
+	CreatedAt   time.Time `db:"created_at" bson:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at" bson:"expires_at"`
+}
+
+func (e Entity) Completed() bool {
+	return e.StatusCode != 0
+}
@@ -0,0 +1,17 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Repository stores idempotency keys, expired keys are treated as absent.
+type Repository interface {
+	// Add stores a new key and fails with store.ErrorConflict when it already exists
+	Add(ctx context.Context, data Entity) (err error)
+	Get(ctx context.Context, key string) (dest Entity, err error)
+	// Update stores the response fields of data for the key
+	Update(ctx context.Context, key string, data Entity) (err error)
+	Delete(ctx context.Context, key string) (err error)
+	Purge(ctx context.Context, before time.Time) (count int64, err error)
+}
@@ -0,0 +1,184 @@
+package interview
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	maxPanel     = 10
+	maxResources = 10
+)
+
+// Request is an interview of the candidate with the panel of RecruiterIDs,
+// RecruiterID leads the panel and defaults to the first of RecruiterIDs.
+// ResourceIDs are the rooms and equipment to reserve for it, a Remote
+// interview gets a video meeting. Every recruiter of the panel has to conduct
+// the InterviewType when it is given.
+type Request struct {
+	CandidateID   string    `json:"candidateId"`
+	RecruiterID   string    `json:"recruiterId"`
+	RecruiterIDs  []string  `json:"recruiterIds"`
+	ResourceIDs   []string  `json:"resourceIds"`
+	InterviewType string    `json:"interviewType"`
+	Title         string    `json:"title"`
+	Location      string    `json:"location"`
+	StartsAt      time.Time `json:"startsAt"`
+	EndsAt        time.Time `json:"endsAt"`
+	Remote        bool      `json:"remote"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.CandidateID == "" {
+		return errors.New("candidateId: cannot be blank")
+	}
+
+	if s.RecruiterID == "" && len(s.RecruiterIDs) > 0 {
+		s.RecruiterID = s.RecruiterIDs[0]
+	}
+	if s.RecruiterID == "" {
+		return errors.New("recruiterId: cannot be blank")
+	}
+
+	panel := []string{s.RecruiterID}
+	for _, id := range s.RecruiterIDs {
+		if id == "" {
+			return errors.New("recruiterIds: id cannot be blank")
+		}
+		if !contains(panel, id) {
+			panel = append(panel, id)
+		}
+	}
+	if len(panel) > maxPanel {
+		return fmt.Errorf("recruiterIds: at most %d recruiters", maxPanel)
+	}
+	s.RecruiterIDs = panel
+
+	resources := make([]string, 0, len(s.ResourceIDs))
+	for _, id := range s.ResourceIDs {
+		if id == "" {
+			return errors.New("resourceIds: id cannot be blank")
+		}
+		if !contains(resources, id) {
+			resources = append(resources, id)
+		}
+	}
+	if len(resources) > maxResources {
+		return fmt.Errorf("resourceIds: at most %d resources", maxResources)
+	}
+	sort.Strings(resources)
+	s.ResourceIDs = resources
+
+	if s.StartsAt.IsZero() {
+		return errors.New("startsAt: cannot be blank")
+	}
+
+	if !s.EndsAt.After(s.StartsAt) {
+		return errors.New("endsAt: must be after startsAt")
+	}
+
+	return nil
+}
+
+// ResponseRequest is the response of the candidate or a recruiter of the panel to the interview
+type ResponseRequest struct {
+	ParticipantType string `json:"participantType"`
+	ParticipantID   string `json:"participantId"`
+	Status          string `json:"status"`
+}
+
+func (s *ResponseRequest) Bind(r *http.Request) error {
+	if s.ParticipantType != ParticipantCandidate && s.ParticipantType != ParticipantRecruiter {
+		return fmt.Errorf("participantType: must be %s or %s", ParticipantCandidate, ParticipantRecruiter)
+	}
+
+	if s.ParticipantID == "" {
+		return errors.New("participantId: cannot be blank")
+	}
+
+	switch s.Status {
+	case ResponseAccepted, ResponseTentative, ResponseDeclined:
+	default:
+		return fmt.Errorf("status: must be %s, %s or %s", ResponseAccepted, ResponseTentative, ResponseDeclined)
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID           string                `json:"id"`
+	CandidateID  string                `json:"candidateId"`
+	RecruiterID  string                `json:"recruiterId"`
+	RecruiterIDs []string              `json:"recruiterIds"`
+	ResourceIDs  []string              `json:"resourceIds"`
+	Title        string                `json:"title,omitempty"`
+	Location     string                `json:"location,omitempty"`
+	MeetingURL   string                `json:"meetingUrl,omitempty"`
+	StartsAt     time.Time             `json:"startsAt"`
+	EndsAt       time.Time             `json:"endsAt"`
+	Status       string                `json:"status"`
+	Participants []ParticipantResponse `json:"participants"`
+	Version      int                   `json:"version"`
+}
+
+type ParticipantResponse struct {
+	Type        string     `json:"type"`
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	RespondedAt *time.Time `json:"respondedAt,omitempty"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:           data.ID,
+		CandidateID:  *data.CandidateID,
+		RecruiterIDs: data.RecruiterIDs(),
+		ResourceIDs:  append([]string{}, data.ResourceIDs...),
+		StartsAt:     *data.StartsAt,
+		EndsAt:       *data.EndsAt,
+		Status:       *data.Status,
+		Participants: make([]ParticipantResponse, 0, len(data.Participants)),
+		Version:      data.Version,
+	}
+	if data.RecruiterID != nil {
+		res.RecruiterID = *data.RecruiterID
+	}
+	for _, participant := range data.Participants {
+		res.Participants = append(res.Participants, ParticipantResponse{
+			Type:        participant.Type,
+			ID:          participant.ID,
+			Status:      participant.Status,
+			RespondedAt: participant.RespondedAt,
+		})
+	}
+	if data.Title != nil {
+		res.Title = *data.Title
+	}
+	if data.Location != nil {
+		res.Location = *data.Location
+	}
+	if data.MeetingURL != nil {
+		res.MeetingURL = *data.MeetingURL
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
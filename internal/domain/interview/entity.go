@@ -0,0 +1,147 @@
+package interview
+
+import "time"
+
+const (
+	StatusScheduled = "scheduled"
+	StatusCancelled = "cancelled"
+)
+
+const (
+	ParticipantCandidate = "candidate"
+	ParticipantRecruiter = "recruiter"
+)
+
+// Responses of the participants to the interview invitation
+const (
+	ResponsePending   = "pending"
+	ResponseAccepted  = "accepted"
+	ResponseTentative = "tentative"
+	ResponseDeclined  = "declined"
+)
+
+// Entity is an interview of a candidate with a panel of recruiters led by
+// RecruiterID, it takes the half-open interval [StartsAt, EndsAt) of the
+// schedules of all the participants
+type Entity struct {
+	ID          string     `db:"id" bson:"_id"`
+	TenantID    string     `db:"tenant_id" bson:"tenant_id"`
+	CandidateID *string    `db:"candidate_id" bson:"candidate_id"`
+	RecruiterID *string    `db:"recruiter_id" bson:"recruiter_id"`
+	Title       *string    `db:"title" bson:"title"`
+	Location    *string    `db:"location" bson:"location"`
+	MeetingURL  *string    `db:"meeting_url" bson:"meeting_url"`
+	StartsAt    *time.Time `db:"starts_at" bson:"starts_at"`
+	EndsAt      *time.Time `db:"ends_at" bson:"ends_at"`
+	Status      *string    `db:"status" bson:"status"`
+	Version     int        `db:"version" bson:"version"`
+	// Participants are the candidate and then the recruiters, the lead one first
+	Participants []Participant `db:"-" bson:"participants"`
+	// ResourceIDs are the rooms and equipment reserved for the interview
+	ResourceIDs []string `db:"-" bson:"resource_ids"`
+}
+
+// Participant is the candidate or a recruiter of an interview with their
+// response to the invitation
+type Participant struct {
+	InterviewID string     `db:"interview_id" bson:"interview_id"`
+	Type        string     `db:"participant_type" bson:"participant_type"`
+	ID          string     `db:"participant_id" bson:"participant_id"`
+	Position    int        `db:"position" bson:"position"`
+	Status      string     `db:"status" bson:"status"`
+	RespondedAt *time.Time `db:"responded_at" bson:"responded_at"`
+}
+
+// NewParticipants returns the pending participants of an interview of the
+// candidate with the recruiters
+func NewParticipants(candidateID string, recruiterIDs []string) (dest []Participant) {
+	dest = make([]Participant, 0, len(recruiterIDs)+1)
+	dest = append(dest, Participant{Type: ParticipantCandidate, ID: candidateID, Status: ResponsePending})
+	for i, id := range recruiterIDs {
+		dest = append(dest, Participant{Type: ParticipantRecruiter, ID: id, Position: i + 1, Status: ResponsePending})
+	}
+	return
+}
+
+// RecruiterIDs returns the recruiters of the panel, the lead one first
+func (e Entity) RecruiterIDs() (dest []string) {
+	for _, participant := range e.Participants {
+		if participant.Type == ParticipantRecruiter {
+			dest = append(dest, participant.ID)
+		}
+	}
+	if len(dest) == 0 && e.RecruiterID != nil {
+		dest = append(dest, *e.RecruiterID)
+	}
+	return
+}
+
+// HasResource reports whether the resource is reserved for the interview
+func (e Entity) HasResource(id string) bool {
+	for _, resourceID := range e.ResourceIDs {
+		if resourceID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRecruiter reports whether the recruiter is on the panel of the interview
+func (e Entity) HasRecruiter(id string) bool {
+	for _, recruiterID := range e.RecruiterIDs() {
+		if recruiterID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Participant returns the participant of the given type and id
+func (e Entity) Participant(participantType, id string) (dest Participant, ok bool) {
+	for _, participant := range e.Participants {
+		if participant.Type == participantType && participant.ID == id {
+			return participant, true
+		}
+	}
+	return
+}
+
+// Overlaps reports whether the interview takes time within [from, to)
+func (e Entity) Overlaps(from, to time.Time) bool {
+	return e.StartsAt.Before(to) && e.EndsAt.After(from)
+}
+
+// Filter narrows the entities returned by Repository.List, empty fields are
+// not applied. RecruiterID selects the interviews having the recruiter on
+// their panel, ResourceID the ones reserving the resource, From and To the
+// interviews overlapping [From, To).
+type Filter struct {
+	CandidateID string
+	RecruiterID string
+	ResourceID  string
+	From        time.Time
+	To          time.Time
+	Status      string
+}
+
+func (f Filter) Match(e Entity) bool {
+	if f.CandidateID != "" && *e.CandidateID != f.CandidateID {
+		return false
+	}
+	if f.RecruiterID != "" && !e.HasRecruiter(f.RecruiterID) {
+		return false
+	}
+	if f.ResourceID != "" && !e.HasResource(f.ResourceID) {
+		return false
+	}
+	if !f.From.IsZero() && !e.EndsAt.After(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !e.StartsAt.Before(f.To) {
+		return false
+	}
+	if f.Status != "" && *e.Status != f.Status {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package interview
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEntityRecruiterIDs(t *testing.T) {
+	lead := "rita"
+
+	tests := []struct {
+		name string
+		data Entity
+		want []string
+	}{
+		{name: "panel in order", data: Entity{Participants: NewParticipants("jane", []string{"rita", "ralf"})}, want: []string{"rita", "ralf"}},
+		{name: "lead of an interview stored before panels", data: Entity{RecruiterID: &lead}, want: []string{"rita"}},
+		{name: "no recruiters", data: Entity{Participants: NewParticipants("jane", nil)}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.RecruiterIDs(); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("RecruiterIDs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntityOverlaps(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2027, 1, 4, hour, 0, 0, 0, time.UTC) }
+	startsAt, endsAt := at(9), at(10)
+	data := Entity{StartsAt: &startsAt, EndsAt: &endsAt}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     bool
+	}{
+		{name: "same time", from: at(9), to: at(10), want: true},
+		{name: "partly", from: at(8), to: at(10), want: true},
+		{name: "around", from: at(8), to: at(11), want: true},
+		{name: "ends when it starts", from: at(8), to: at(9), want: false},
+		{name: "starts when it ends", from: at(10), to: at(11), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := data.Overlaps(tt.from, tt.to); got != tt.want {
+				t.Errorf("Overlaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestBind(t *testing.T) {
+	valid := func() Request {
+		startsAt := time.Date(2027, 1, 4, 9, 0, 0, 0, time.UTC)
+		return Request{CandidateID: "jane", RecruiterIDs: []string{"rita"}, StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)}
+	}
+
+	tests := []struct {
+		name          string
+		change        func(req *Request)
+		wantErr       bool
+		wantLead      string
+		wantPanel     []string
+		wantResources []string
+	}{
+		{name: "lead defaults to the first of the panel", change: func(req *Request) { req.RecruiterIDs = []string{"rita", "ralf"} }, wantLead: "rita", wantPanel: []string{"rita", "ralf"}},
+		{name: "lead joins the panel first", change: func(req *Request) {
+			req.RecruiterID = "rosa"
+			req.RecruiterIDs = []string{"rita", "rosa", "rita"}
+		}, wantLead: "rosa", wantPanel: []string{"rosa", "rita"}},
+		{name: "resources are deduplicated and sorted", change: func(req *Request) { req.ResourceIDs = []string{"room-2", "room-1", "room-2"} }, wantLead: "rita", wantPanel: []string{"rita"}, wantResources: []string{"room-1", "room-2"}},
+		{name: "no candidate", change: func(req *Request) { req.CandidateID = "" }, wantErr: true},
+		{name: "no recruiter", change: func(req *Request) { req.RecruiterIDs = nil }, wantErr: true},
+		{name: "blank recruiter of the panel", change: func(req *Request) { req.RecruiterIDs = []string{"rita", ""} }, wantErr: true},
+		{name: "too large a panel", change: func(req *Request) {
+			for i := 0; i < maxPanel; i++ {
+				req.RecruiterIDs = append(req.RecruiterIDs, fmt.Sprint("recruiter-", i))
+			}
+		}, wantErr: true},
+		{name: "blank resource", change: func(req *Request) { req.ResourceIDs = []string{""} }, wantErr: true},
+		{name: "too many resources", change: func(req *Request) {
+			for i := 0; i <= maxResources; i++ {
+				req.ResourceIDs = append(req.ResourceIDs, fmt.Sprint("resource-", i))
+			}
+		}, wantErr: true},
+		{name: "no start", change: func(req *Request) { req.StartsAt = time.Time{} }, wantErr: true},
+		{name: "ends when it starts", change: func(req *Request) { req.EndsAt = req.StartsAt }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.change(&req)
+
+			err := req.Bind(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.RecruiterID != tt.wantLead || fmt.Sprint(req.RecruiterIDs) != fmt.Sprint(tt.wantPanel) || fmt.Sprint(req.ResourceIDs) != fmt.Sprint(tt.wantResources) {
+				t.Errorf("Bind() lead %s, panel %q and resources %q, want %s, %q and %q", req.RecruiterID, req.RecruiterIDs, req.ResourceIDs, tt.wantLead, tt.wantPanel, tt.wantResources)
+			}
+		})
+	}
+}
+
+func TestResponseRequestBind(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ResponseRequest
+		wantErr bool
+	}{
+		{name: "candidate accepts", req: ResponseRequest{ParticipantType: ParticipantCandidate, ParticipantID: "jane", Status: ResponseAccepted}},
+		{name: "recruiter declines", req: ResponseRequest{ParticipantType: ParticipantRecruiter, ParticipantID: "rita", Status: ResponseDeclined}},
+		{name: "unknown participant type", req: ResponseRequest{ParticipantType: "observer", ParticipantID: "oscar", Status: ResponseAccepted}, wantErr: true},
+		{name: "no participant", req: ResponseRequest{ParticipantType: ParticipantCandidate, Status: ResponseAccepted}, wantErr: true},
+		{name: "pending is no response", req: ResponseRequest{ParticipantType: ParticipantCandidate, ParticipantID: "jane", Status: ResponsePending}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Bind(nil); (err != nil) != tt.wantErr {
+				t.Errorf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
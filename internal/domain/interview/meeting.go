@@ -0,0 +1,10 @@
+package interview
+
+import "context"
+
+// MeetingProvider provisions the video meeting of a remote interview and
+// returns its link, it is called again when the interview is rescheduled
+// and may return the same link
+type MeetingProvider interface {
+	Provision(ctx context.Context, data Entity) (url string, err error)
+}
@@ -0,0 +1,26 @@
+package interview
+
+import "context"
+
+// Repository stores entities under optimistic concurrency control,
+// Update fails with store.ErrorConflict when the version of the stored
+// entity differs from the expected one, version 0 skips the check.
+// Add keeps the id of the entity when it is set. Update replaces the
+// participants and the resources when they are not nil.
+type Repository interface {
+	List(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	// Respond sets the response of a participant and bumps the version of the
+	// interview, it fails with store.ErrorNotFound for an unknown participant
+	Respond(ctx context.Context, id string, data Participant) (err error)
+	// LockSchedules serializes the units of work booking the schedules of the given
+	// candidates and recruiters until the unit of work in ctx ends
+	LockSchedules(ctx context.Context, ids ...string) (err error)
+	// Purge removes a purged candidate or recruiter from the interviews. The
+	// interviews of a candidate are removed with them, a recruiter only leaves
+	// the panels: the next recruiter of a panel takes the lead and an interview
+	// left without recruiters has none.
+	Purge(ctx context.Context, participantType, id string) (err error)
+}
@@ -0,0 +1,89 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+type Response struct {
+	ID            string     `json:"id"`
+	Channel       string     `json:"channel"`
+	EventType     string     `json:"eventType"`
+	Recipient     string     `json:"recipient"`
+	Locale        string     `json:"locale"`
+	Subject       string     `json:"subject,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"lastError,omitempty"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+	SentAt        *time.Time `json:"sentAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		Channel:   data.Channel,
+		EventType: data.EventType,
+		Recipient: data.Recipient,
+		Locale:    data.Locale,
+		Subject:   data.Subject,
+		Status:    data.Status,
+		Attempts:  data.Attempts,
+		LastError: data.LastError,
+		SentAt:    data.SentAt,
+		CreatedAt: data.CreatedAt,
+	}
+	if data.Status == StatusPending {
+		res.NextAttemptAt = &data.NextAttemptAt
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+type OptOutRequest struct {
+	Channel   string `json:"channel"`
+	Recipient string `json:"recipient"`
+}
+
+func (s *OptOutRequest) Bind(r *http.Request) error {
+	if s.Channel != ChannelEmail && s.Channel != ChannelSMS {
+		return errors.New("channel: must be email or sms")
+	}
+
+	if s.Recipient == "" {
+		return errors.New("recipient: cannot be blank")
+	}
+
+	return nil
+}
+
+type OptOutResponse struct {
+	Channel   string    `json:"channel"`
+	Recipient string    `json:"recipient"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func ParseFromOptOut(data OptOut) OptOutResponse {
+	return OptOutResponse{
+		Channel:   data.Channel,
+		Recipient: data.Recipient,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func ParseFromOptOuts(data []OptOut) (res []OptOutResponse) {
+	res = make([]OptOutResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromOptOut(object))
+	}
+	return
+}
@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	StatusDead    = "dead"
+	// StatusSuppressed is set on notifications whose recipient opted out before they were sent
+	StatusSuppressed = "suppressed"
+)
+
+// Entity is a rendered notification in the send log, it is sent by the
+// Sender of its channel and retried until it is sent or runs out of attempts
+type Entity struct {
+	ID            string     `db:"id" bson:"_id"`
+	TenantID      string     `db:"tenant_id" bson:"tenant_id"`
+	Channel       string     `db:"channel" bson:"channel"`
+	EventType     string     `db:"event_type" bson:"event_type"`
+	Recipient     string     `db:"recipient" bson:"recipient"`
+	Locale        string     `db:"locale" bson:"locale"`
+	Subject       string     `db:"subject" bson:"subject"`
+	Body          string     `db:"body" bson:"body"`
+	Status        string     `db:"status" bson:"status"`
+	Attempts      int        `db:"attempts" bson:"attempts"`
+	LastError     string     `db:"last_error" bson:"last_error"`
+	NextAttemptAt time.Time  `db:"next_attempt_at" bson:"next_attempt_at"`
+	SentAt        *time.Time `db:"sent_at" bson:"sent_at"`
+	CreatedAt     time.Time  `db:"created_at" bson:"created_at"`
+}
+
+// Filter narrows the entities returned by Repository.List, empty fields match any entity
+type Filter struct {
+	Channel   string
+	Recipient string
+	Status    string
+}
+
+// Recipient is someone notifications are addressed to, the JSON names match
+// the responses of candidates and recruiters carried by their events
+type Recipient struct {
+	FullName string `json:"fullName"`
+	Email    string `json:"email"`
+	Phone    int    `json:"phone"`
+	Locale   string `json:"locale"`
+}
+
+// Address returns the address of the recipient on the channel, empty when unknown
+func (r Recipient) Address(channel string) string {
+	switch channel {
+	case ChannelEmail:
+		return r.Email
+	case ChannelSMS:
+		if r.Phone != 0 {
+			return strconv.Itoa(r.Phone)
+		}
+	}
+	return ""
+}
+
+// Notifier queues the notifications of the event type to the recipient
+type Notifier interface {
+	Notify(ctx context.Context, eventType string, recipient Recipient, data map[string]any) error
+}
+
+// OptOut stops the notifications of a channel to the recipient
+type OptOut struct {
+	Channel   string    `db:"channel" bson:"channel"`
+	TenantID  string    `db:"tenant_id" bson:"tenant_id"`
+	Recipient string    `db:"recipient" bson:"recipient"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+}
+
+// Sender delivers a rendered notification over its channel
+type Sender interface {
+	Send(ctx context.Context, data Entity) error
+}
+
+// RateLimitError is returned by a Sender that has to hold back the notification,
+// it is sent again after RetryAfter without using up an attempt
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+}
@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	List(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	// Claim leases up to limit pending notifications due at now until now+lease,
+	// so that concurrent dispatchers do not pick the same notification
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (dest []Entity, err error)
+}
+
+type OptOutRepository interface {
+	List(ctx context.Context, channel string) (dest []OptOut, err error)
+	// Add is a no-op for a recipient that has already opted out
+	Add(ctx context.Context, data OptOut) (err error)
+	Delete(ctx context.Context, channel, recipient string) (err error)
+	Exists(ctx context.Context, channel, recipient string) (exists bool, err error)
+}
@@ -0,0 +1,36 @@
+package organization
+
+import "context"
+
+type (
+	tenant struct{}
+	system struct{}
+)
+
+// ContextWithTenant scopes the repositories to the organization
+func ContextWithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenant{}, id)
+}
+
+// TenantFromContext returns the organization the context is scoped to, it
+// is empty in a system context and in a context that is not scoped at all
+func TenantFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(tenant{}).(string)
+	return id
+}
+
+// ContextWithSystem marks the context of a background job spanning every
+// organization, a tenant added to it afterwards narrows it down again
+func ContextWithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, system{}, true)
+}
+
+// IsSystem reports whether the context spans every organization, a context
+// without a tenant that is not marked as system sees no organization
+func IsSystem(ctx context.Context) bool {
+	if TenantFromContext(ctx) != "" {
+		return false
+	}
+	marked, _ := ctx.Value(system{}).(bool)
+	return marked
+}
@@ -0,0 +1,107 @@
+package organization
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Request struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	if s.Status == "" {
+		s.Status = StatusActive
+	}
+	if s.Status != StatusActive && s.Status != StatusSuspended {
+		return fmt.Errorf("status: must be %s or %s", StatusActive, StatusSuspended)
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	Version   int       `json:"version"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		Name:      *data.Name,
+		Status:    StatusActive,
+		CreatedAt: data.CreatedAt,
+		Version:   data.Version,
+	}
+	if data.Status != nil {
+		res.Status = *data.Status
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+type KeyRequest struct {
+	Name        string `json:"name"`
+	RecruiterID string `json:"recruiterId"`
+}
+
+func (s *KeyRequest) Bind(r *http.Request) error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	return nil
+}
+
+type KeyResponse struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	RecruiterID string     `json:"recruiterId,omitempty"`
+	Prefix      string     `json:"prefix"`
+	Secret      string     `json:"secret,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+}
+
+// ParseFromKey hides the secret, it is only shown once when issued
+func ParseFromKey(data Key) (res KeyResponse) {
+	res = KeyResponse{
+		ID:        data.ID,
+		Name:      data.Name,
+		Prefix:    data.Prefix,
+		CreatedAt: data.CreatedAt,
+		RevokedAt: data.RevokedAt,
+	}
+	if data.RecruiterID != nil {
+		res.RecruiterID = *data.RecruiterID
+	}
+	return
+}
+
+func ParseFromKeys(data []Key) (res []KeyResponse) {
+	res = make([]KeyResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromKey(object))
+	}
+	return
+}
@@ -0,0 +1,56 @@
+package organization
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	StatusActive    = "active"
+	StatusSuspended = "suspended"
+
+	// DefaultID is the organization owning the data created before
+	// organizations were introduced and the requests without credentials
+	// when authentication is not required
+	DefaultID = "00000000-0000-0000-0000-000000000001"
+)
+
+// Entity is a tenant of the deployment, the data of an organization is only
+// visible to the principals authenticated with its keys
+type Entity struct {
+	ID        string    `db:"id" bson:"_id"`
+	Name      *string   `db:"name" bson:"name"`
+	Status    *string   `db:"status" bson:"status"`
+	CreatedAt time.Time `db:"created_at" bson:"created_at"`
+	Version   int       `db:"version" bson:"version"`
+}
+
+// Active reports whether the principals of the organization are let in
+func (e Entity) Active() bool {
+	return e.Status == nil || *e.Status == StatusActive
+}
+
+// Key is an API key authenticating the principals of an organization, only
+// the hash of the secret is stored so that it is shown once when issued. A
+// key issued to a recruiter acts as that recruiter.
+type Key struct {
+	ID             string     `db:"id" bson:"_id"`
+	OrganizationID string     `db:"organization_id" bson:"organization_id"`
+	RecruiterID    *string    `db:"recruiter_id" bson:"recruiter_id"`
+	Name           string     `db:"name" bson:"name"`
+	Prefix         string     `db:"prefix" bson:"prefix"`
+	SecretHash     string     `db:"secret_hash" bson:"secret_hash"`
+	CreatedAt      time.Time  `db:"created_at" bson:"created_at"`
+	RevokedAt      *time.Time `db:"revoked_at" bson:"revoked_at"`
+}
+
+// Principal names the key in the audit log and the idempotency scopes
+func (k Key) Principal() string {
+	return "key:" + k.Prefix
+}
+
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
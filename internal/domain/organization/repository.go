@@ -0,0 +1,22 @@
+package organization
+
+import "context"
+
+// Repository stores entities under optimistic concurrency control, Update
+// fails with store.ErrorConflict when the version of the stored entity
+// differs from the expected one, version 0 skips the check.
+type Repository interface {
+	List(ctx context.Context) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+}
+
+type KeyRepository interface {
+	List(ctx context.Context, organizationID string) (dest []Key, err error)
+	Add(ctx context.Context, data Key) (id string, err error)
+	// GetBySecret returns the key issued with the secret, revoked keys included
+	GetBySecret(ctx context.Context, secretHash string) (dest Key, err error)
+	// Revoke fails with store.ErrorNotFound for an unknown or revoked key
+	Revoke(ctx context.Context, organizationID, id string) (err error)
+}
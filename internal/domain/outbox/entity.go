@@ -0,0 +1,16 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Entity is an event stored together with the entity change it describes,
+// it is handed to the sinks by the relay after the transaction has committed
+type Entity struct {
+	ID          int64           `db:"id" bson:"_id"`
+	EventType   string          `db:"event_type" bson:"event_type"`
+	Payload     json.RawMessage `db:"payload" bson:"payload"`
+	CreatedAt   time.Time       `db:"created_at" bson:"created_at"`
+	PublishedAt *time.Time      `db:"published_at" bson:"published_at"`
+}
@@ -0,0 +1,15 @@
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Add(ctx context.Context, data Entity) (id int64, err error)
+	// ListPending returns up to limit unpublished entries in the order they
+	// were added, within a unit of work they stay locked until it ends
+	ListPending(ctx context.Context, limit int) (dest []Entity, err error)
+	MarkPublished(ctx context.Context, ids []int64, at time.Time) (err error)
+	Purge(ctx context.Context, before time.Time) (count int64, err error)
+}
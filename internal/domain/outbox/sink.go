@@ -0,0 +1,12 @@
+package outbox
+
+import (
+	"context"
+	"reservation-system/internal/domain/event"
+)
+
+// Sink receives the events relayed from the outbox, an error leaves the event
+// in the outbox to be relayed again, so sinks must tolerate duplicates
+type Sink interface {
+	Send(ctx context.Context, data event.Event) error
+}
@@ -2,13 +2,31 @@ package recruiter
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxInterviewTypes = 20
+	maxAbsences       = 50
 )
 
 type Request struct {
-	FullName string `json:"fullname"`
-	Email    string `json:"email"`
-	Phone    int    `json:"phone"`
+	FullName       string   `json:"fullname"`
+	Email          string   `json:"email"`
+	Phone          int      `json:"phone"`
+	Title          string   `json:"title"`
+	Team           string   `json:"team"`
+	InterviewTypes []string `json:"interviewTypes"`
+	// WorkingHours override the working hours of the service when given
+	WorkingHours *Schedule `json:"workingHours"`
+	// MaxInterviewsPerDay and MaxInterviewsPerWeek cap the scheduled interviews, 0 is no cap
+	MaxInterviewsPerDay  int       `json:"maxInterviewsPerDay"`
+	MaxInterviewsPerWeek int       `json:"maxInterviewsPerWeek"`
+	OutOfOffice          []Absence `json:"outOfOffice"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
@@ -17,29 +35,112 @@ func (s *Request) Bind(r *http.Request) error {
 	}
 
 	if s.Email == "" {
-		return errors.New("pseudonym: cannot be blank")
+		return errors.New("email: cannot be blank")
 	}
 
 	if s.Phone == 0 {
 		return errors.New("phone: cannot be blank")
 	}
 
+	s.Title = strings.TrimSpace(s.Title)
+	s.Team = strings.TrimSpace(s.Team)
+
+	types := make([]string, 0, len(s.InterviewTypes))
+	for _, item := range s.InterviewTypes {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" {
+			return errors.New("interviewTypes: type cannot be blank")
+		}
+		if !Strings(types).Has(item) {
+			types = append(types, item)
+		}
+	}
+	if len(types) > maxInterviewTypes {
+		return fmt.Errorf("interviewTypes: at most %d types", maxInterviewTypes)
+	}
+	s.InterviewTypes = types
+
+	if s.WorkingHours != nil {
+		if s.WorkingHours.IsZero() {
+			s.WorkingHours = nil
+		} else if _, err := s.WorkingHours.Hours(); err != nil {
+			return fmt.Errorf("workingHours: %w", err)
+		}
+	}
+
+	if s.MaxInterviewsPerDay < 0 {
+		return errors.New("maxInterviewsPerDay: cannot be negative")
+	}
+	if s.MaxInterviewsPerWeek < 0 {
+		return errors.New("maxInterviewsPerWeek: cannot be negative")
+	}
+	if s.MaxInterviewsPerDay > 0 && s.MaxInterviewsPerWeek > 0 && s.MaxInterviewsPerWeek < s.MaxInterviewsPerDay {
+		return errors.New("maxInterviewsPerWeek: cannot be below maxInterviewsPerDay")
+	}
+
+	if len(s.OutOfOffice) > maxAbsences {
+		return fmt.Errorf("outOfOffice: at most %d periods", maxAbsences)
+	}
+	for _, absence := range s.OutOfOffice {
+		if absence.StartsAt.IsZero() || !absence.EndsAt.After(absence.StartsAt) {
+			return errors.New("outOfOffice: a period must end after it starts")
+		}
+	}
+	sort.SliceStable(s.OutOfOffice, func(i, j int) bool {
+		return s.OutOfOffice[i].StartsAt.Before(s.OutOfOffice[j].StartsAt)
+	})
+
 	return nil
 }
 
 type Response struct {
-	ID       string `json:"id"`
-	FullName string `json:"fullName"`
-	Email    string `json:"email"`
-	Phone    int    `json:"phone"`
+	ID                   string     `json:"id"`
+	FullName             string     `json:"fullName"`
+	Email                string     `json:"email"`
+	Phone                int        `json:"phone"`
+	Title                string     `json:"title,omitempty"`
+	Team                 string     `json:"team,omitempty"`
+	InterviewTypes       []string   `json:"interviewTypes"`
+	WorkingHours         *Schedule  `json:"workingHours,omitempty"`
+	MaxInterviewsPerDay  int        `json:"maxInterviewsPerDay,omitempty"`
+	MaxInterviewsPerWeek int        `json:"maxInterviewsPerWeek,omitempty"`
+	OutOfOffice          []Absence  `json:"outOfOffice"`
+	DeletedAt            *time.Time `json:"deletedAt,omitempty"`
+	Version              int        `json:"version"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
 	res = Response{
-		ID:       data.ID,
-		FullName: *data.FullName,
-		Email:    *data.Email,
-		Phone:    *data.Phone,
+		ID:             data.ID,
+		FullName:       *data.FullName,
+		Email:          *data.Email,
+		Phone:          *data.Phone,
+		InterviewTypes: []string{},
+		OutOfOffice:    []Absence{},
+		DeletedAt:      data.DeletedAt,
+		Version:        data.Version,
+	}
+	if data.Title != nil {
+		res.Title = *data.Title
+	}
+	if data.Team != nil {
+		res.Team = *data.Team
+	}
+	if data.InterviewTypes != nil {
+		res.InterviewTypes = append(res.InterviewTypes, *data.InterviewTypes...)
+	}
+	if data.WorkingHours != nil && !data.WorkingHours.IsZero() {
+		hours := *data.WorkingHours
+		res.WorkingHours = &hours
+	}
+	if data.MaxPerDay != nil {
+		res.MaxInterviewsPerDay = *data.MaxPerDay
+	}
+	if data.MaxPerWeek != nil {
+		res.MaxInterviewsPerWeek = *data.MaxPerWeek
+	}
+	if data.OutOfOffice != nil {
+		res.OutOfOffice = append(res.OutOfOffice, *data.OutOfOffice...)
 	}
 	return
 }
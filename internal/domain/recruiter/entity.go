@@ -1,8 +1,177 @@
 package recruiter
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reservation-system/internal/domain/availability"
+	"strings"
+	"time"
+)
+
+// Entity is a recruiter along with the profile the scheduling respects,
+// WorkingHours left empty fall back to the working hours of the service
+// and a zero MaxPerDay or MaxPerWeek is no cap
 type Entity struct {
-	ID       string  `db:"id" bson:"_id"`
-	FullName *string `db:"full_name" bson:"full_name"`
-	Email    *string `db:"email" bson:"email"`
-	Phone    *int    `db:"phone" bson:"phone"`
+	ID             string     `db:"id" bson:"_id"`
+	TenantID       string     `db:"tenant_id" bson:"tenant_id"`
+	FullName       *string    `db:"full_name" bson:"full_name"`
+	Email          *string    `db:"email" bson:"email"`
+	Phone          *int       `db:"phone" bson:"phone"`
+	Title          *string    `db:"title" bson:"title"`
+	Team           *string    `db:"team" bson:"team"`
+	InterviewTypes *Strings   `db:"interview_types" bson:"interview_types"`
+	WorkingHours   *Schedule  `db:"working_hours" bson:"working_hours"`
+	MaxPerDay      *int       `db:"max_interviews_per_day" bson:"max_interviews_per_day"`
+	MaxPerWeek     *int       `db:"max_interviews_per_week" bson:"max_interviews_per_week"`
+	OutOfOffice    *Absences  `db:"out_of_office" bson:"out_of_office"`
+	DeletedAt      *time.Time `db:"deleted_at" bson:"deleted_at"`
+	Version        int        `db:"version" bson:"version"`
+}
+
+// Filter narrows the entities returned by Repository.List, InterviewType
+// selects the recruiters able to conduct it
+type Filter struct {
+	IncludeDeleted bool
+	Team           string
+	InterviewType  string
+}
+
+// Match applies the profile fields of the filter, deleted recruiters are
+// left to the repository
+func (f Filter) Match(e Entity) bool {
+	if f.Team != "" && (e.Team == nil || !strings.EqualFold(*e.Team, f.Team)) {
+		return false
+	}
+	if f.InterviewType != "" && (e.InterviewTypes == nil || !e.InterviewTypes.Has(f.InterviewType)) {
+		return false
+	}
+	return true
+}
+
+// Absences returns the out-of-office periods as windows
+func (e Entity) Absences() []availability.Window {
+	if e.OutOfOffice == nil {
+		return nil
+	}
+	return e.OutOfOffice.Windows()
+}
+
+// Strings are stored as a JSON array
+type Strings []string
+
+// Has reports whether the value is among the strings, ignoring case
+func (s Strings) Has(value string) bool {
+	for _, item := range s {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Strings) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(s)
+	return string(src), err
+}
+
+func (s *Strings) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, s)
+	case string:
+		return json.Unmarshal([]byte(value), s)
+	}
+	return errors.New("recruiter: unsupported strings value")
+}
+
+// Schedule are the weekly working hours of a recruiter in the form
+// availability.ParseWorkingHours reads, stored as a JSON object
+type Schedule struct {
+	Days     []string `json:"days"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	TimeZone string   `json:"timeZone"`
+}
+
+// IsZero reports whether the schedule is left empty
+func (s Schedule) IsZero() bool {
+	return len(s.Days) == 0 && s.Start == "" && s.End == "" && s.TimeZone == ""
+}
+
+// Hours parses the schedule, the time zone defaults to UTC
+func (s Schedule) Hours() (availability.WorkingHours, error) {
+	zone := s.TimeZone
+	if zone == "" {
+		zone = "UTC"
+	}
+	return availability.ParseWorkingHours(s.Start, s.End, s.Days, zone)
+}
+
+// Value stores an empty schedule as NULL
+func (s Schedule) Value() (driver.Value, error) {
+	if s.IsZero() {
+		return nil, nil
+	}
+	src, err := json.Marshal(s)
+	return string(src), err
+}
+
+func (s *Schedule) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s = Schedule{}
+		return nil
+	case []byte:
+		return json.Unmarshal(value, s)
+	case string:
+		return json.Unmarshal([]byte(value), s)
+	}
+	return errors.New("recruiter: unsupported schedule value")
+}
+
+// Absence is an out-of-office period [StartsAt, EndsAt) of a recruiter
+type Absence struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// Absences are stored as a JSON array
+type Absences []Absence
+
+// Windows returns the absences as windows
+func (a Absences) Windows() (dest []availability.Window) {
+	dest = make([]availability.Window, 0, len(a))
+	for _, absence := range a {
+		dest = append(dest, availability.Window{StartsAt: absence.StartsAt, EndsAt: absence.EndsAt})
+	}
+	return
+}
+
+func (a Absences) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(a)
+	return string(src), err
+}
+
+func (a *Absences) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, a)
+	case string:
+		return json.Unmarshal([]byte(value), a)
+	}
+	return errors.New("recruiter: unsupported absences value")
 }
@@ -0,0 +1,97 @@
+package recruiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterMatch(t *testing.T) {
+	team := "Platform"
+	types := Strings{"technical", "System Design"}
+	data := Entity{Team: &team, InterviewTypes: &types}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		data   Entity
+		want   bool
+	}{
+		{name: "empty filter", filter: Filter{}, data: Entity{}, want: true},
+		{name: "team ignoring case", filter: Filter{Team: "platform"}, data: data, want: true},
+		{name: "other team", filter: Filter{Team: "payments"}, data: data, want: false},
+		{name: "no team", filter: Filter{Team: "platform"}, data: Entity{}, want: false},
+		{name: "interview type ignoring case", filter: Filter{InterviewType: "system design"}, data: data, want: true},
+		{name: "other interview type", filter: Filter{InterviewType: "behavioral"}, data: data, want: false},
+		{name: "no interview types", filter: Filter{InterviewType: "technical"}, data: Entity{}, want: false},
+		{name: "both", filter: Filter{Team: "Platform", InterviewType: "technical"}, data: data, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.data); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleHours(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		wantZone string
+		wantErr  bool
+	}{
+		{name: "defaults to UTC", schedule: Schedule{Days: []string{"MO"}, Start: "09:00", End: "17:00"}, wantZone: "UTC"},
+		{name: "time zone", schedule: Schedule{Days: []string{"MO"}, Start: "09:00", End: "17:00", TimeZone: "Europe/Berlin"}, wantZone: "Europe/Berlin"},
+		{name: "invalid", schedule: Schedule{Days: []string{"MO"}, Start: "17:00", End: "09:00"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours, err := tt.schedule.Hours()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Hours() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && hours.Location.String() != tt.wantZone {
+				t.Errorf("Hours() location = %v, want %s", hours.Location, tt.wantZone)
+			}
+		})
+	}
+}
+
+func TestScheduleValue(t *testing.T) {
+	value, err := Schedule{}.Value()
+	if err != nil || value != nil {
+		t.Errorf("Value() of an empty schedule = %v, %v, want NULL", value, err)
+	}
+
+	want := Schedule{Days: []string{"MO", "FR"}, Start: "08:00", End: "12:00", TimeZone: "Europe/Berlin"}
+	if value, err = want.Value(); err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	var got Schedule
+	if err = got.Scan(value); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(got.Days) != 2 || got.Start != want.Start || got.End != want.End || got.TimeZone != want.TimeZone {
+		t.Errorf("Scan() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAbsencesScan(t *testing.T) {
+	var got Absences
+	if err := got.Scan([]byte(`[{"startsAt":"2027-01-04T00:00:00Z","endsAt":"2027-01-08T00:00:00Z","reason":"vacation"}]`)); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	windows := got.Windows()
+	if len(windows) != 1 || !windows[0].StartsAt.Equal(time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)) || windows[0].Duration() != 4*24*time.Hour {
+		t.Errorf("Windows() = %v, want the four days of vacation", windows)
+	}
+
+	if err := got.Scan(42); err == nil {
+		t.Error("Scan() error = nil, want an error for an unsupported value")
+	}
+}
@@ -0,0 +1,29 @@
+package reminder
+
+import "time"
+
+const (
+	StatusPending = "pending"
+	StatusSent    = "sent"
+	// StatusSkipped is set on reminders of interviews that were cancelled,
+	// rescheduled or superseded by a closer reminder before they were sent
+	StatusSkipped = "skipped"
+)
+
+// Entity is a reminder of an interview planned Offset before its start, a
+// reminder is unique per interview, offset and start so that it is planned
+// once and planned again only when the interview is rescheduled
+type Entity struct {
+	ID            string     `db:"id" bson:"_id"`
+	InterviewID   string     `db:"interview_id" bson:"interview_id"`
+	OffsetSeconds int64      `db:"offset_seconds" bson:"offset_seconds"`
+	StartsAt      time.Time  `db:"starts_at" bson:"starts_at"`
+	DueAt         time.Time  `db:"due_at" bson:"due_at"`
+	Status        string     `db:"status" bson:"status"`
+	SentAt        *time.Time `db:"sent_at" bson:"sent_at"`
+	CreatedAt     time.Time  `db:"created_at" bson:"created_at"`
+}
+
+func (e Entity) Offset() time.Duration {
+	return time.Duration(e.OffsetSeconds) * time.Second
+}
@@ -0,0 +1,15 @@
+package reminder
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// Add plans the reminder, it is a no-op for a reminder planned already
+	Add(ctx context.Context, data Entity) (err error)
+	// ClaimDue returns up to limit pending reminders due at now, within a unit
+	// of work they stay locked until it ends so that no other replica sends them
+	ClaimDue(ctx context.Context, now time.Time, limit int) (dest []Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+}
@@ -0,0 +1,85 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type Request struct {
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	Capacity  int      `json:"capacity"`
+	Location  string   `json:"location"`
+	Equipment []string `json:"equipment"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	if s.Kind == "" {
+		s.Kind = KindRoom
+	}
+	if s.Kind != KindRoom && s.Kind != KindEquipment {
+		return fmt.Errorf("kind: must be %s or %s", KindRoom, KindEquipment)
+	}
+
+	if s.Capacity < 0 {
+		return errors.New("capacity: cannot be negative")
+	}
+
+	tags := make([]string, 0, len(s.Equipment))
+	for _, tag := range s.Equipment {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return errors.New("equipment: tag cannot be blank")
+		}
+		if !Tags(tags).Has(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	s.Equipment = tags
+
+	return nil
+}
+
+type Response struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	Capacity  int      `json:"capacity,omitempty"`
+	Location  string   `json:"location,omitempty"`
+	Equipment []string `json:"equipment"`
+	Version   int      `json:"version"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		Name:      *data.Name,
+		Kind:      *data.Kind,
+		Equipment: []string{},
+		Version:   data.Version,
+	}
+	if data.Capacity != nil {
+		res.Capacity = *data.Capacity
+	}
+	if data.Location != nil {
+		res.Location = *data.Location
+	}
+	if data.Equipment != nil {
+		res.Equipment = append(res.Equipment, *data.Equipment...)
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	KindRoom      = "room"
+	KindEquipment = "equipment"
+)
+
+// Entity is a room or a piece of equipment that interviews reserve, a
+// resource takes part in one interview at a time like a person does
+type Entity struct {
+	ID       string  `db:"id" bson:"_id"`
+	TenantID string  `db:"tenant_id" bson:"tenant_id"`
+	Name     *string `db:"name" bson:"name"`
+	Kind     *string `db:"kind" bson:"kind"`
+	// Capacity is the number of people a room seats, 0 is not limited
+	Capacity  *int    `db:"capacity" bson:"capacity"`
+	Location  *string `db:"location" bson:"location"`
+	Equipment *Tags   `db:"equipment" bson:"equipment"`
+	Version   int     `db:"version" bson:"version"`
+}
+
+// Filter narrows the entities returned by Repository.List, empty fields are
+// not applied. Equipment selects the resources having all the tags.
+type Filter struct {
+	Kind        string
+	Location    string
+	Equipment   []string
+	MinCapacity int
+}
+
+func (f Filter) Match(e Entity) bool {
+	if f.Kind != "" && *e.Kind != f.Kind {
+		return false
+	}
+	if f.Location != "" && !strings.EqualFold(*e.Location, f.Location) {
+		return false
+	}
+	if f.MinCapacity > 0 && (*e.Capacity != 0 && *e.Capacity < f.MinCapacity) {
+		return false
+	}
+	for _, tag := range f.Equipment {
+		if e.Equipment == nil || !e.Equipment.Has(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// Tags are stored as a JSON array
+type Tags []string
+
+// Has reports whether the tag is among the tags, ignoring case
+func (t Tags) Has(tag string) bool {
+	for _, item := range t {
+		if strings.EqualFold(item, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(t)
+	return string(src), err
+}
+
+func (t *Tags) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, t)
+	case string:
+		return json.Unmarshal([]byte(value), t)
+	}
+	return errors.New("resource: unsupported tags value")
+}
@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFilterMatch(t *testing.T) {
+	room, equipment := KindRoom, KindEquipment
+	berlin := "Berlin"
+	seats, unlimited := 4, 0
+	tags := Tags{"Whiteboard", "Video"}
+	data := Entity{Kind: &room, Location: &berlin, Capacity: &seats, Equipment: &tags}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		data   Entity
+		want   bool
+	}{
+		{name: "empty filter", filter: Filter{}, data: data, want: true},
+		{name: "kind", filter: Filter{Kind: KindRoom}, data: data, want: true},
+		{name: "other kind", filter: Filter{Kind: KindRoom}, data: Entity{Kind: &equipment}, want: false},
+		{name: "location ignoring case", filter: Filter{Location: "berlin"}, data: data, want: true},
+		{name: "seats enough", filter: Filter{MinCapacity: 4}, data: data, want: true},
+		{name: "seats too few", filter: Filter{MinCapacity: 5}, data: data, want: false},
+		{name: "seats without limit", filter: Filter{MinCapacity: 50}, data: Entity{Capacity: &unlimited}, want: true},
+		{name: "all the equipment ignoring case", filter: Filter{Equipment: []string{"video", "whiteboard"}}, data: data, want: true},
+		{name: "missing equipment", filter: Filter{Equipment: []string{"video", "projector"}}, data: data, want: false},
+		{name: "no equipment", filter: Filter{Equipment: []string{"video"}}, data: Entity{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(tt.data); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestBind(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           Request
+		wantErr       bool
+		wantKind      string
+		wantEquipment []string
+	}{
+		{name: "room by default", req: Request{Name: "Room 1"}, wantKind: KindRoom, wantEquipment: []string{}},
+		{name: "equipment is trimmed and deduplicated", req: Request{Name: "Beamer", Kind: KindEquipment, Equipment: []string{" Video ", "video", "HDMI"}}, wantKind: KindEquipment, wantEquipment: []string{"Video", "HDMI"}},
+		{name: "no name", req: Request{Kind: KindRoom}, wantErr: true},
+		{name: "unknown kind", req: Request{Name: "Car", Kind: "vehicle"}, wantErr: true},
+		{name: "negative capacity", req: Request{Name: "Room 1", Capacity: -1}, wantErr: true},
+		{name: "blank equipment", req: Request{Name: "Room 1", Equipment: []string{"Video", " "}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Bind(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.req.Kind != tt.wantKind || fmt.Sprint(tt.req.Equipment) != fmt.Sprint(tt.wantEquipment) {
+				t.Errorf("Bind() kind %s and equipment %q, want %s and %q", tt.req.Kind, tt.req.Equipment, tt.wantKind, tt.wantEquipment)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package resource
+
+import "context"
+
+// Repository stores entities under optimistic concurrency control,
+// Update and Delete fail with store.ErrorConflict when the version of the
+// stored entity differs from the expected one, version 0 skips the check.
+type Repository interface {
+	List(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string, version int) (err error)
+}
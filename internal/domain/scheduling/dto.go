@@ -0,0 +1,143 @@
+package scheduling
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reservation-system/internal/domain/interview"
+	"time"
+)
+
+const (
+	defaultStepMinutes = 15
+
+	maxCandidates = 200
+	maxRecruiters = 50
+)
+
+// Request asks for a conflict-free assignment of the candidates to the
+// recruiters within [From, To), interviews are created unless DryRun is set,
+// as video meetings when Remote is set
+type Request struct {
+	Candidates []CandidateRequest `json:"candidates"`
+	Recruiters []RecruiterRequest `json:"recruiters"`
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Title      string             `json:"title"`
+	Location   string             `json:"location"`
+	Remote     bool               `json:"remote"`
+	// DurationMinutes is the length of every interview
+	DurationMinutes int `json:"durationMinutes"`
+	// BreakMinutes is the least time a recruiter has between two interviews
+	BreakMinutes int `json:"breakMinutes"`
+	// MaxPerRecruiterPerDay caps the interviews of a recruiter on a day, existing ones included, 0 is no cap
+	MaxPerRecruiterPerDay int `json:"maxPerRecruiterPerDay"`
+	// StepMinutes is the granularity of the start times tried
+	StepMinutes int  `json:"stepMinutes"`
+	DryRun      bool `json:"dryRun"`
+}
+
+// CandidateRequest is a candidate to interview, by a recruiter having all the
+// Skills, within one of the PreferredWindows when given
+type CandidateRequest struct {
+	ID               string   `json:"id"`
+	Skills           []string `json:"skills"`
+	PreferredWindows []Window `json:"preferredWindows"`
+}
+
+type RecruiterRequest struct {
+	ID     string   `json:"id"`
+	Skills []string `json:"skills"`
+}
+
+type Window struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if len(s.Candidates) == 0 {
+		return errors.New("candidates: cannot be blank")
+	}
+	if len(s.Candidates) > maxCandidates {
+		return fmt.Errorf("candidates: at most %d candidates", maxCandidates)
+	}
+
+	if len(s.Recruiters) == 0 {
+		return errors.New("recruiters: cannot be blank")
+	}
+	if len(s.Recruiters) > maxRecruiters {
+		return fmt.Errorf("recruiters: at most %d recruiters", maxRecruiters)
+	}
+
+	seen := make(map[string]bool)
+	for _, candidate := range s.Candidates {
+		if candidate.ID == "" {
+			return errors.New("candidates: id cannot be blank")
+		}
+		if seen[candidate.ID] {
+			return fmt.Errorf("candidates: %s is given twice", candidate.ID)
+		}
+		seen[candidate.ID] = true
+
+		for _, window := range candidate.PreferredWindows {
+			if !window.EndsAt.After(window.StartsAt) {
+				return fmt.Errorf("candidates: preferred window of %s must end after it starts", candidate.ID)
+			}
+		}
+	}
+
+	seen = make(map[string]bool)
+	for _, recruiter := range s.Recruiters {
+		if recruiter.ID == "" {
+			return errors.New("recruiters: id cannot be blank")
+		}
+		if seen[recruiter.ID] {
+			return fmt.Errorf("recruiters: %s is given twice", recruiter.ID)
+		}
+		seen[recruiter.ID] = true
+	}
+
+	if s.From.IsZero() || !s.To.After(s.From) {
+		return errors.New("to: must be after from")
+	}
+
+	if s.DurationMinutes <= 0 {
+		return errors.New("durationMinutes: must be positive")
+	}
+	if s.BreakMinutes < 0 {
+		return errors.New("breakMinutes: cannot be negative")
+	}
+	if s.MaxPerRecruiterPerDay < 0 {
+		return errors.New("maxPerRecruiterPerDay: cannot be negative")
+	}
+
+	if s.StepMinutes == 0 {
+		s.StepMinutes = defaultStepMinutes
+	}
+	if s.StepMinutes < 0 {
+		return errors.New("stepMinutes: must be positive")
+	}
+
+	return nil
+}
+
+type Response struct {
+	DryRun      bool         `json:"dryRun"`
+	Assignments []Assignment `json:"assignments"`
+	Unassigned  []Unassigned `json:"unassigned"`
+	// Interviews are the interviews scheduled from the assignments, empty on a dry run
+	Interviews []interview.Response `json:"interviews"`
+}
+
+type Assignment struct {
+	CandidateID string    `json:"candidateId"`
+	RecruiterID string    `json:"recruiterId"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+}
+
+type Unassigned struct {
+	CandidateID string `json:"candidateId"`
+	Reason      string `json:"reason"`
+}
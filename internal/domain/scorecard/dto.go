@@ -0,0 +1,254 @@
+package scorecard
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxCriteria is the number of criteria a template can have
+	maxCriteria = 30
+
+	defaultScale = 5
+	maxScale     = 10
+)
+
+type TemplateRequest struct {
+	Name     string      `json:"name"`
+	Criteria []Criterion `json:"criteria"`
+}
+
+func (s *TemplateRequest) Bind(r *http.Request) error {
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	if len(s.Criteria) == 0 {
+		return errors.New("criteria: cannot be empty")
+	}
+	if len(s.Criteria) > maxCriteria {
+		return fmt.Errorf("criteria: cannot have more than %d criteria", maxCriteria)
+	}
+	for i := range s.Criteria {
+		s.Criteria[i].Name = strings.TrimSpace(s.Criteria[i].Name)
+		if s.Criteria[i].Name == "" {
+			return errors.New("criteria: name cannot be blank")
+		}
+		if _, ok := Criteria(s.Criteria[:i]).Find(s.Criteria[i].Name); ok {
+			return fmt.Errorf("criteria: %s is repeated", s.Criteria[i].Name)
+		}
+		if s.Criteria[i].Scale == 0 {
+			s.Criteria[i].Scale = defaultScale
+		}
+		if s.Criteria[i].Scale < 2 || s.Criteria[i].Scale > maxScale {
+			return fmt.Errorf("criteria: scale of %s must be between 2 and %d", s.Criteria[i].Name, maxScale)
+		}
+	}
+
+	return nil
+}
+
+type TemplateResponse struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	Criteria []Criterion `json:"criteria"`
+	Version  int         `json:"version"`
+}
+
+func ParseFromTemplate(data Template) (res TemplateResponse) {
+	res = TemplateResponse{
+		ID:       data.ID,
+		Name:     *data.Name,
+		Criteria: []Criterion{},
+		Version:  data.Version,
+	}
+	if data.Criteria != nil {
+		res.Criteria = append(res.Criteria, *data.Criteria...)
+	}
+	return
+}
+
+func ParseFromTemplates(data []Template) (res []TemplateResponse) {
+	res = make([]TemplateResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromTemplate(object))
+	}
+	return
+}
+
+// Request is the scorecard of the recruiter the key is issued to, RecruiterID
+// is only given by the admin key submitting on behalf of a recruiter
+type Request struct {
+	RecruiterID    string          `json:"recruiterId"`
+	TemplateID     string          `json:"templateId"`
+	Ratings        []RatingRequest `json:"ratings"`
+	Recommendation string          `json:"recommendation"`
+	Comment        string          `json:"comment"`
+}
+
+type RatingRequest struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	Comment   string `json:"comment"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.TemplateID == "" {
+		return errors.New("templateId: cannot be blank")
+	}
+
+	switch s.Recommendation {
+	case RecommendationStrongNoHire, RecommendationNoHire, RecommendationHire, RecommendationStrongHire:
+	default:
+		return fmt.Errorf("recommendation: must be %s, %s, %s or %s",
+			RecommendationStrongNoHire, RecommendationNoHire, RecommendationHire, RecommendationStrongHire)
+	}
+
+	for i := range s.Ratings {
+		s.Ratings[i].Criterion = strings.TrimSpace(s.Ratings[i].Criterion)
+		for _, previous := range s.Ratings[:i] {
+			if strings.EqualFold(previous.Criterion, s.Ratings[i].Criterion) {
+				return fmt.Errorf("ratings: %s is repeated", s.Ratings[i].Criterion)
+			}
+		}
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID             string    `json:"id"`
+	InterviewID    string    `json:"interviewId"`
+	RecruiterID    string    `json:"recruiterId"`
+	TemplateID     string    `json:"templateId"`
+	Ratings        []Rating  `json:"ratings"`
+	Recommendation string    `json:"recommendation"`
+	Comment        string    `json:"comment,omitempty"`
+	SubmittedAt    time.Time `json:"submittedAt"`
+	Version        int       `json:"version"`
+}
+
+func ParseFromEntity(data Submission) (res Response) {
+	res = Response{
+		ID:             data.ID,
+		InterviewID:    *data.InterviewID,
+		RecruiterID:    *data.RecruiterID,
+		TemplateID:     *data.TemplateID,
+		Ratings:        []Rating{},
+		Recommendation: *data.Recommendation,
+		Version:        data.Version,
+	}
+	if data.Ratings != nil {
+		res.Ratings = append(res.Ratings, *data.Ratings...)
+	}
+	if data.Comment != nil {
+		res.Comment = *data.Comment
+	}
+	if data.SubmittedAt != nil {
+		res.SubmittedAt = *data.SubmittedAt
+	}
+	return
+}
+
+func ParseFromEntities(data []Submission) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// SummaryResponse aggregates the scorecards submitted on the interviews of a
+// candidate. Average is the mean of all the scores as a share of their
+// scales, from 0 to 1, and Recommendation is hire or no_hire by majority.
+type SummaryResponse struct {
+	CandidateID     string             `json:"candidateId"`
+	Scorecards      int                `json:"scorecards"`
+	Hidden          int                `json:"hidden"`
+	Average         float64            `json:"average"`
+	Criteria        []CriterionSummary `json:"criteria"`
+	Recommendations map[string]int     `json:"recommendations"`
+	Recommendation  string             `json:"recommendation,omitempty"`
+}
+
+type CriterionSummary struct {
+	Criterion string  `json:"criterion"`
+	Scale     int     `json:"scale"`
+	Average   float64 `json:"average"`
+	Count     int     `json:"count"`
+}
+
+// Summarize aggregates the scorecards, hidden is the number of scorecards
+// left out of the summary
+func Summarize(candidateID string, data []Submission, hidden int) (res SummaryResponse) {
+	res = SummaryResponse{
+		CandidateID:     candidateID,
+		Scorecards:      len(data),
+		Hidden:          hidden,
+		Criteria:        []CriterionSummary{},
+		Recommendations: map[string]int{},
+	}
+
+	type key struct {
+		criterion string
+		scale     int
+	}
+	var (
+		keys   []key
+		totals = map[key]int{}
+		counts = map[key]int{}
+		share  float64
+		scores int
+		hires  int
+	)
+	for _, submission := range data {
+		res.Recommendations[*submission.Recommendation]++
+		if IsHire(*submission.Recommendation) {
+			hires++
+		}
+		if submission.Ratings == nil {
+			continue
+		}
+		for _, rating := range *submission.Ratings {
+			k := key{criterion: strings.ToLower(rating.Criterion), scale: rating.Scale}
+			if counts[k] == 0 {
+				keys = append(keys, k)
+				res.Criteria = append(res.Criteria, CriterionSummary{Criterion: rating.Criterion, Scale: rating.Scale})
+			}
+			totals[k] += rating.Score
+			counts[k]++
+			share += float64(rating.Score) / float64(rating.Scale)
+			scores++
+		}
+	}
+
+	for i, k := range keys {
+		res.Criteria[i].Count = counts[k]
+		res.Criteria[i].Average = round(float64(totals[k]) / float64(counts[k]))
+	}
+	sort.SliceStable(res.Criteria, func(i, j int) bool {
+		return res.Criteria[i].Criterion < res.Criteria[j].Criterion
+	})
+	if scores > 0 {
+		res.Average = round(share / float64(scores))
+	}
+
+	switch {
+	case hires*2 > len(data):
+		res.Recommendation = RecommendationHire
+	case hires*2 < len(data):
+		res.Recommendation = RecommendationNoHire
+	}
+
+	return
+}
+
+// round rounds to two decimals
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,150 @@
+package scorecard
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Recommendations of the recruiters, from the strongest rejection to the strongest hire
+const (
+	RecommendationStrongNoHire = "strong_no_hire"
+	RecommendationNoHire       = "no_hire"
+	RecommendationHire         = "hire"
+	RecommendationStrongHire   = "strong_hire"
+)
+
+// Template is the set of criteria recruiters rate candidates on
+type Template struct {
+	ID       string    `db:"id" bson:"_id"`
+	TenantID string    `db:"tenant_id" bson:"tenant_id"`
+	Name     *string   `db:"name" bson:"name"`
+	Criteria *Criteria `db:"criteria" bson:"criteria"`
+	Version  int       `db:"version" bson:"version"`
+}
+
+// Criterion is rated from 1 to Scale
+type Criterion struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Scale       int    `json:"scale"`
+}
+
+// Criteria are stored as a JSON array
+type Criteria []Criterion
+
+// Find returns the criterion with the name, ignoring case
+func (c Criteria) Find(name string) (dest Criterion, ok bool) {
+	for _, item := range c {
+		if strings.EqualFold(item.Name, name) {
+			return item, true
+		}
+	}
+	return
+}
+
+func (c Criteria) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(c)
+	return string(src), err
+}
+
+func (c *Criteria) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, c)
+	case string:
+		return json.Unmarshal([]byte(value), c)
+	}
+	return errors.New("scorecard: unsupported criteria value")
+}
+
+// Submission is the feedback of a recruiter of the panel on an interview,
+// a recruiter submits one scorecard per interview
+type Submission struct {
+	ID             string     `db:"id" bson:"_id"`
+	TenantID       string     `db:"tenant_id" bson:"tenant_id"`
+	InterviewID    *string    `db:"interview_id" bson:"interview_id"`
+	RecruiterID    *string    `db:"recruiter_id" bson:"recruiter_id"`
+	TemplateID     *string    `db:"template_id" bson:"template_id"`
+	Ratings        *Ratings   `db:"ratings" bson:"ratings"`
+	Recommendation *string    `db:"recommendation" bson:"recommendation"`
+	Comment        *string    `db:"comment" bson:"comment"`
+	SubmittedAt    *time.Time `db:"submitted_at" bson:"submitted_at"`
+	Version        int        `db:"version" bson:"version"`
+}
+
+// Rating is the score of a criterion, the scale of the criterion is kept so
+// that changes of the template do not alter submitted scorecards
+type Rating struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	Scale     int    `json:"scale"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// Ratings are stored as a JSON array
+type Ratings []Rating
+
+func (r Ratings) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(r)
+	return string(src), err
+}
+
+func (r *Ratings) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, r)
+	case string:
+		return json.Unmarshal([]byte(value), r)
+	}
+	return errors.New("scorecard: unsupported ratings value")
+}
+
+// Filter narrows the submissions returned by Repository.List, empty fields
+// are not applied
+type Filter struct {
+	InterviewIDs []string
+	RecruiterID  string
+	TemplateID   string
+}
+
+func (f Filter) Match(s Submission) bool {
+	if f.InterviewIDs != nil {
+		found := false
+		for _, id := range f.InterviewIDs {
+			if *s.InterviewID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.RecruiterID != "" && *s.RecruiterID != f.RecruiterID {
+		return false
+	}
+	if f.TemplateID != "" && *s.TemplateID != f.TemplateID {
+		return false
+	}
+	return true
+}
+
+// IsHire reports whether the recommendation is in favour of hiring
+func IsHire(recommendation string) bool {
+	return recommendation == RecommendationHire || recommendation == RecommendationStrongHire
+}
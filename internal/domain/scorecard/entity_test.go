@@ -0,0 +1,169 @@
+package scorecard
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCriteriaFind(t *testing.T) {
+	criteria := Criteria{{Name: "Communication", Scale: 5}, {Name: "Design", Scale: 10}}
+
+	if got, ok := criteria.Find("design"); !ok || got.Scale != 10 {
+		t.Errorf("Find(design) = %+v, %v, want the design criterion", got, ok)
+	}
+	if _, ok := criteria.Find("coding"); ok {
+		t.Error("Find(coding) found a criterion, want none")
+	}
+}
+
+func TestTemplateRequestBind(t *testing.T) {
+	valid := func() TemplateRequest {
+		return TemplateRequest{Name: "Backend", Criteria: []Criterion{{Name: " Design "}, {Name: "Coding", Scale: 10}}}
+	}
+
+	tests := []struct {
+		name    string
+		change  func(req *TemplateRequest)
+		wantErr bool
+	}{
+		{name: "valid", change: func(req *TemplateRequest) {}},
+		{name: "no name", change: func(req *TemplateRequest) { req.Name = "" }, wantErr: true},
+		{name: "no criteria", change: func(req *TemplateRequest) { req.Criteria = nil }, wantErr: true},
+		{name: "too many criteria", change: func(req *TemplateRequest) {
+			req.Criteria = nil
+			for i := 0; i <= maxCriteria; i++ {
+				req.Criteria = append(req.Criteria, Criterion{Name: fmt.Sprintf("criterion %d", i)})
+			}
+		}, wantErr: true},
+		{name: "blank criterion", change: func(req *TemplateRequest) { req.Criteria[1].Name = " " }, wantErr: true},
+		{name: "repeated criterion", change: func(req *TemplateRequest) { req.Criteria[1].Name = "design" }, wantErr: true},
+		{name: "scale too small", change: func(req *TemplateRequest) { req.Criteria[1].Scale = 1 }, wantErr: true},
+		{name: "scale too large", change: func(req *TemplateRequest) { req.Criteria[1].Scale = maxScale + 1 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.change(&req)
+
+			err := req.Bind(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := []Criterion{{Name: "Design", Scale: defaultScale}, {Name: "Coding", Scale: 10}}
+			if !reflect.DeepEqual(req.Criteria, want) {
+				t.Errorf("Bind() criteria = %+v, want %+v", req.Criteria, want)
+			}
+		})
+	}
+}
+
+func TestRequestBind(t *testing.T) {
+	valid := func() Request {
+		return Request{TemplateID: "template", Recommendation: RecommendationHire, Ratings: []RatingRequest{{Criterion: " Design ", Score: 4}, {Criterion: "Coding", Score: 3}}}
+	}
+
+	tests := []struct {
+		name    string
+		change  func(req *Request)
+		wantErr bool
+	}{
+		{name: "valid", change: func(req *Request) {}},
+		{name: "strong recommendation", change: func(req *Request) { req.Recommendation = RecommendationStrongNoHire }},
+		{name: "no template", change: func(req *Request) { req.TemplateID = "" }, wantErr: true},
+		{name: "no recommendation", change: func(req *Request) { req.Recommendation = "" }, wantErr: true},
+		{name: "unknown recommendation", change: func(req *Request) { req.Recommendation = "maybe" }, wantErr: true},
+		{name: "repeated rating", change: func(req *Request) { req.Ratings[1].Criterion = "DESIGN" }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.change(&req)
+
+			err := req.Bind(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && req.Ratings[0].Criterion != "Design" {
+				t.Errorf("Bind() criterion = %q, want it trimmed", req.Ratings[0].Criterion)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	submission := func(recommendation string, ratings ...Rating) Submission {
+		data := Ratings(ratings)
+		return Submission{Recommendation: &recommendation, Ratings: &data}
+	}
+
+	tests := []struct {
+		name               string
+		data               []Submission
+		wantAverage        float64
+		wantCriteria       []CriterionSummary
+		wantRecommendation string
+	}{
+		{name: "no scorecards", wantCriteria: []CriterionSummary{}},
+		{
+			name: "majority to hire",
+			data: []Submission{
+				submission(RecommendationStrongHire, Rating{Criterion: "Design", Score: 5, Scale: 5}, Rating{Criterion: "Coding", Score: 4, Scale: 10}),
+				submission(RecommendationHire, Rating{Criterion: "design", Score: 4, Scale: 5}),
+				submission(RecommendationNoHire, Rating{Criterion: "Design", Score: 3, Scale: 5}),
+			},
+			wantAverage: 0.7,
+			wantCriteria: []CriterionSummary{
+				{Criterion: "Coding", Scale: 10, Average: 4, Count: 1},
+				{Criterion: "Design", Scale: 5, Average: 4, Count: 3},
+			},
+			wantRecommendation: RecommendationHire,
+		},
+		{
+			name: "majority not to hire",
+			data: []Submission{
+				submission(RecommendationNoHire, Rating{Criterion: "Design", Score: 1, Scale: 5}),
+				submission(RecommendationStrongNoHire, Rating{Criterion: "Design", Score: 2, Scale: 5}),
+				submission(RecommendationHire, Rating{Criterion: "Design", Score: 4, Scale: 5}),
+			},
+			wantAverage:        0.47,
+			wantCriteria:       []CriterionSummary{{Criterion: "Design", Scale: 5, Average: 2.33, Count: 3}},
+			wantRecommendation: RecommendationNoHire,
+		},
+		{
+			name: "tie",
+			data: []Submission{
+				submission(RecommendationHire, Rating{Criterion: "Design", Score: 4, Scale: 5}),
+				submission(RecommendationNoHire, Rating{Criterion: "Design", Score: 4, Scale: 10}),
+			},
+			wantAverage: 0.6,
+			wantCriteria: []CriterionSummary{
+				{Criterion: "Design", Scale: 5, Average: 4, Count: 1},
+				{Criterion: "Design", Scale: 10, Average: 4, Count: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize("candidate", tt.data, 1)
+			if got.Scorecards != len(tt.data) || got.Hidden != 1 {
+				t.Errorf("Summarize() counts %d scorecards and %d hidden, want %d and 1", got.Scorecards, got.Hidden, len(tt.data))
+			}
+			if got.Average != tt.wantAverage {
+				t.Errorf("Summarize() average = %v, want %v", got.Average, tt.wantAverage)
+			}
+			if !reflect.DeepEqual(got.Criteria, tt.wantCriteria) {
+				t.Errorf("Summarize() criteria = %+v, want %+v", got.Criteria, tt.wantCriteria)
+			}
+			if got.Recommendation != tt.wantRecommendation {
+				t.Errorf("Summarize() recommendation = %q, want %q", got.Recommendation, tt.wantRecommendation)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package scorecard
+
+import "context"
+
+// TemplateRepository stores templates under optimistic concurrency control,
+// Update and Delete fail with store.ErrorConflict when the version of the
+// stored template differs from the expected one, version 0 skips the check.
+type TemplateRepository interface {
+	List(ctx context.Context) (dest []Template, err error)
+	Add(ctx context.Context, data Template) (id string, err error)
+	Get(ctx context.Context, id string) (dest Template, err error)
+	Update(ctx context.Context, id string, data Template) (err error)
+	Delete(ctx context.Context, id string, version int) (err error)
+}
+
+// Repository stores the submitted scorecards under optimistic concurrency
+// control like TemplateRepository does
+type Repository interface {
+	List(ctx context.Context, filter Filter) (dest []Submission, err error)
+	Add(ctx context.Context, data Submission) (id string, err error)
+	Get(ctx context.Context, id string) (dest Submission, err error)
+	Update(ctx context.Context, id string, data Submission) (err error)
+}
@@ -0,0 +1,136 @@
+package team
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxResources is the number of rooms and equipment a booking can reserve
+const maxResources = 10
+
+type Request struct {
+	Name     string `json:"name"`
+	Strategy string `json:"strategy"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" {
+		return errors.New("name: cannot be blank")
+	}
+
+	if s.Strategy == "" {
+		s.Strategy = StrategyRoundRobin
+	}
+	if s.Strategy != StrategyRoundRobin && s.Strategy != StrategyLeastLoaded {
+		return fmt.Errorf("strategy: must be %s or %s", StrategyRoundRobin, StrategyLeastLoaded)
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Strategy string `json:"strategy"`
+	Version  int    `json:"version"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:       data.ID,
+		Name:     *data.Name,
+		Strategy: StrategyRoundRobin,
+		Version:  data.Version,
+	}
+	if data.Strategy != nil {
+		res.Strategy = *data.Strategy
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+type MemberResponse struct {
+	TeamID      string    `json:"teamId"`
+	RecruiterID string    `json:"recruiterId"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+func ParseFromMember(data Member) MemberResponse {
+	return MemberResponse{
+		TeamID:      data.TeamID,
+		RecruiterID: data.RecruiterID,
+		JoinedAt:    data.JoinedAt,
+	}
+}
+
+func ParseFromMembers(data []Member) (res []MemberResponse) {
+	res = make([]MemberResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromMember(object))
+	}
+	return
+}
+
+// BookingRequest is an interview of the candidate with any available member
+// of the team, InterviewType narrows the members to the ones conducting it
+// and Strategy overrides the one of the team for this booking
+type BookingRequest struct {
+	CandidateID   string    `json:"candidateId"`
+	InterviewType string    `json:"interviewType"`
+	Strategy      string    `json:"strategy"`
+	ResourceIDs   []string  `json:"resourceIds"`
+	Title         string    `json:"title"`
+	Location      string    `json:"location"`
+	StartsAt      time.Time `json:"startsAt"`
+	EndsAt        time.Time `json:"endsAt"`
+	Remote        bool      `json:"remote"`
+}
+
+func (s *BookingRequest) Bind(r *http.Request) error {
+	if s.CandidateID == "" {
+		return errors.New("candidateId: cannot be blank")
+	}
+
+	if s.Strategy != "" && s.Strategy != StrategyRoundRobin && s.Strategy != StrategyLeastLoaded {
+		return fmt.Errorf("strategy: must be %s or %s", StrategyRoundRobin, StrategyLeastLoaded)
+	}
+
+	resources := make([]string, 0, len(s.ResourceIDs))
+	seen := make(map[string]bool, len(s.ResourceIDs))
+	for _, id := range s.ResourceIDs {
+		if id == "" {
+			return errors.New("resourceIds: id cannot be blank")
+		}
+		if !seen[id] {
+			seen[id] = true
+			resources = append(resources, id)
+		}
+	}
+	if len(resources) > maxResources {
+		return fmt.Errorf("resourceIds: at most %d resources", maxResources)
+	}
+	sort.Strings(resources)
+	s.ResourceIDs = resources
+
+	if s.StartsAt.IsZero() {
+		return errors.New("startsAt: cannot be blank")
+	}
+
+	if !s.EndsAt.After(s.StartsAt) {
+		return errors.New("endsAt: must be after startsAt")
+	}
+
+	return nil
+}
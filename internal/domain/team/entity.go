@@ -0,0 +1,49 @@
+package team
+
+import "time"
+
+const (
+	// StrategyRoundRobin assigns the members in turns, in the order they joined
+	StrategyRoundRobin = "round_robin"
+	// StrategyLeastLoaded assigns the member with the fewest interviews in the
+	// week of the booking, the turns of the round robin break the ties
+	StrategyLeastLoaded = "least_loaded"
+)
+
+// Entity is a hiring team, the candidates booked against the team get an
+// interview with one of its available members picked by the Strategy.
+// LastAssignedID is the member the round robin continues after.
+type Entity struct {
+	ID             string  `db:"id" bson:"_id"`
+	TenantID       string  `db:"tenant_id" bson:"tenant_id"`
+	Name           *string `db:"name" bson:"name"`
+	Strategy       *string `db:"strategy" bson:"strategy"`
+	LastAssignedID *string `db:"last_assigned_id" bson:"last_assigned_id"`
+	Version        int     `db:"version" bson:"version"`
+}
+
+// Member is a recruiter of a team, a recruiter can be a member of several teams
+type Member struct {
+	TeamID      string    `db:"team_id" bson:"team_id"`
+	TenantID    string    `db:"tenant_id" bson:"tenant_id"`
+	RecruiterID string    `db:"recruiter_id" bson:"recruiter_id"`
+	JoinedAt    time.Time `db:"joined_at" bson:"joined_at"`
+}
+
+// Turns returns the recruiters of the members in the order of the round
+// robin, starting after the last assigned one
+func Turns(members []Member, lastAssignedID string) (dest []string) {
+	start := 0
+	for i, member := range members {
+		if member.RecruiterID == lastAssignedID {
+			start = i + 1
+			break
+		}
+	}
+
+	dest = make([]string, 0, len(members))
+	for i := range members {
+		dest = append(dest, members[(start+i)%len(members)].RecruiterID)
+	}
+	return
+}
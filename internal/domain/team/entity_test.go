@@ -0,0 +1,31 @@
+package team
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTurns(t *testing.T) {
+	members := []Member{{RecruiterID: "a"}, {RecruiterID: "b"}, {RecruiterID: "c"}}
+
+	tests := []struct {
+		name           string
+		members        []Member
+		lastAssignedID string
+		want           []string
+	}{
+		{name: "nobody assigned yet", members: members, want: []string{"a", "b", "c"}},
+		{name: "after the first", members: members, lastAssignedID: "a", want: []string{"b", "c", "a"}},
+		{name: "after the last", members: members, lastAssignedID: "c", want: []string{"a", "b", "c"}},
+		{name: "last assigned left the team", members: members, lastAssignedID: "d", want: []string{"a", "b", "c"}},
+		{name: "no members", lastAssignedID: "a", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Turns(tt.members, tt.lastAssignedID); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Turns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package team
+
+import "context"
+
+// Repository stores entities under optimistic concurrency control,
+// Update and Delete fail with store.ErrorConflict when the version of the
+// stored entity differs from the expected one, version 0 skips the check.
+type Repository interface {
+	List(ctx context.Context) (dest []Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string, version int) (err error)
+	// Advance moves the round robin of the team past the recruiter, it leaves
+	// the version of the team as it is
+	Advance(ctx context.Context, id, recruiterID string) (err error)
+}
+
+// MemberRepository stores the members of the teams in the order they joined
+type MemberRepository interface {
+	List(ctx context.Context, teamID string) (dest []Member, err error)
+	// Add fails with store.ErrorConflict when the recruiter is already a member
+	Add(ctx context.Context, data Member) (err error)
+	Delete(ctx context.Context, teamID, recruiterID string) (err error)
+}
@@ -0,0 +1,95 @@
+package vacancy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxStages is the number of stages a pipeline can have
+const maxStages = 20
+
+type Request struct {
+	Title      string   `json:"title"`
+	Department string   `json:"department"`
+	OwnerID    string   `json:"ownerId"`
+	Status     string   `json:"status"`
+	Stages     []string `json:"stages"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Title == "" {
+		return errors.New("title: cannot be blank")
+	}
+
+	if s.OwnerID == "" {
+		return errors.New("ownerId: cannot be blank")
+	}
+
+	if s.Status == "" {
+		s.Status = StatusOpen
+	}
+	if s.Status != StatusOpen && s.Status != StatusOnHold && s.Status != StatusClosed {
+		return fmt.Errorf("status: must be %s, %s or %s", StatusOpen, StatusOnHold, StatusClosed)
+	}
+
+	if len(s.Stages) == 0 {
+		s.Stages = DefaultStages
+	}
+	stages := make([]string, 0, len(s.Stages))
+	for _, stage := range s.Stages {
+		stage = strings.ToLower(strings.TrimSpace(stage))
+		if stage == "" {
+			return errors.New("stages: stage cannot be blank")
+		}
+		if Stages(stages).Index(stage) >= 0 {
+			return fmt.Errorf("stages: %s is repeated", stage)
+		}
+		stages = append(stages, stage)
+	}
+	if len(stages) > maxStages {
+		return fmt.Errorf("stages: cannot have more than %d stages", maxStages)
+	}
+	s.Stages = stages
+
+	return nil
+}
+
+type Response struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Department string   `json:"department,omitempty"`
+	OwnerID    string   `json:"ownerId,omitempty"`
+	Status     string   `json:"status"`
+	Stages     []string `json:"stages"`
+	Version    int      `json:"version"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:      data.ID,
+		Title:   *data.Title,
+		Status:  *data.Status,
+		Stages:  []string{},
+		Version: data.Version,
+	}
+	if data.Department != nil {
+		res.Department = *data.Department
+	}
+	if data.OwnerID != nil {
+		res.OwnerID = *data.OwnerID
+	}
+	if data.Stages != nil {
+		res.Stages = append(res.Stages, *data.Stages...)
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package vacancy
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	StatusOpen   = "open"
+	StatusOnHold = "on_hold"
+	StatusClosed = "closed"
+)
+
+// DefaultStages is the pipeline of the vacancies created without one
+var DefaultStages = []string{"screening", "technical", "onsite", "offer"}
+
+// Entity is a job vacancy owned by a recruiter, the applications to it go
+// through its pipeline of Stages in order
+type Entity struct {
+	ID         string  `db:"id" bson:"_id"`
+	TenantID   string  `db:"tenant_id" bson:"tenant_id"`
+	Title      *string `db:"title" bson:"title"`
+	Department *string `db:"department" bson:"department"`
+	OwnerID    *string `db:"owner_id" bson:"owner_id"`
+	Status     *string `db:"status" bson:"status"`
+	Stages     *Stages `db:"stages" bson:"stages"`
+	Version    int     `db:"version" bson:"version"`
+}
+
+// Filter narrows the entities returned by Repository.List, empty fields are
+// not applied
+type Filter struct {
+	Status     string
+	Department string
+	OwnerID    string
+}
+
+func (f Filter) Match(e Entity) bool {
+	if f.Status != "" && *e.Status != f.Status {
+		return false
+	}
+	if f.Department != "" && (e.Department == nil || *e.Department != f.Department) {
+		return false
+	}
+	if f.OwnerID != "" && (e.OwnerID == nil || *e.OwnerID != f.OwnerID) {
+		return false
+	}
+	return true
+}
+
+// Stages are the names of the pipeline stages in order, stored as a JSON array
+type Stages []string
+
+// Index returns the position of the stage in the pipeline, -1 when it is not part of it
+func (s Stages) Index(stage string) int {
+	for i, item := range s {
+		if item == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// First returns the stage new applications start at
+func (s Stages) First() string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[0]
+}
+
+// Last returns the stage applications are hired from
+func (s Stages) Last() string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[len(s)-1]
+}
+
+func (s Stages) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(s)
+	return string(src), err
+}
+
+func (s *Stages) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, s)
+	case string:
+		return json.Unmarshal([]byte(value), s)
+	}
+	return errors.New("vacancy: unsupported stages value")
+}
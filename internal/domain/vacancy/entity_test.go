@@ -0,0 +1,83 @@
+package vacancy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStages(t *testing.T) {
+	tests := []struct {
+		name      string
+		stages    Stages
+		stage     string
+		wantIndex int
+		wantFirst string
+		wantLast  string
+	}{
+		{name: "first stage", stages: Stages{"screening", "onsite", "offer"}, stage: "screening", wantIndex: 0, wantFirst: "screening", wantLast: "offer"},
+		{name: "last stage", stages: Stages{"screening", "onsite", "offer"}, stage: "offer", wantIndex: 2, wantFirst: "screening", wantLast: "offer"},
+		{name: "other stage", stages: Stages{"screening", "onsite", "offer"}, stage: "technical", wantIndex: -1, wantFirst: "screening", wantLast: "offer"},
+		{name: "no stages", stage: "screening", wantIndex: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stages.Index(tt.stage); got != tt.wantIndex {
+				t.Errorf("Index() = %d, want %d", got, tt.wantIndex)
+			}
+			if got := tt.stages.First(); got != tt.wantFirst {
+				t.Errorf("First() = %q, want %q", got, tt.wantFirst)
+			}
+			if got := tt.stages.Last(); got != tt.wantLast {
+				t.Errorf("Last() = %q, want %q", got, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestRequestBind(t *testing.T) {
+	valid := func() Request {
+		return Request{Title: "Backend engineer", OwnerID: "owner"}
+	}
+
+	tests := []struct {
+		name       string
+		change     func(req *Request)
+		wantErr    bool
+		wantStatus string
+		wantStages []string
+	}{
+		{name: "defaults", change: func(req *Request) {}, wantStatus: StatusOpen, wantStages: DefaultStages},
+		{name: "stages are normalized", change: func(req *Request) { req.Status, req.Stages = StatusOnHold, []string{" Screening", "OFFER "} }, wantStatus: StatusOnHold, wantStages: []string{"screening", "offer"}},
+		{name: "no title", change: func(req *Request) { req.Title = "" }, wantErr: true},
+		{name: "no owner", change: func(req *Request) { req.OwnerID = "" }, wantErr: true},
+		{name: "unknown status", change: func(req *Request) { req.Status = "draft" }, wantErr: true},
+		{name: "blank stage", change: func(req *Request) { req.Stages = []string{"screening", " "} }, wantErr: true},
+		{name: "repeated stage", change: func(req *Request) { req.Stages = []string{"screening", "Screening"} }, wantErr: true},
+		{name: "too many stages", change: func(req *Request) {
+			req.Stages = nil
+			for i := 0; i <= maxStages; i++ {
+				req.Stages = append(req.Stages, fmt.Sprintf("stage %d", i))
+			}
+		}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.change(&req)
+
+			err := req.Bind(nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.Status != tt.wantStatus || strings.Join(req.Stages, ",") != strings.Join(tt.wantStages, ",") {
+				t.Errorf("Bind() status %s and stages %v, want %s and %v", req.Status, req.Stages, tt.wantStatus, tt.wantStages)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type Request struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Secret     string   `json:"secret"`
+	Active     *bool    `json:"active"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.URL == "" {
+		return errors.New("url: cannot be blank")
+	}
+
+	target, err := url.Parse(s.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return errors.New("url: must be an absolute http(s) url")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	Secret     string    `json:"secret,omitempty"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ParseFromEntity hides the secret, it is only shown once on creation
+func ParseFromEntity(data Subscription) (res Response) {
+	res = Response{
+		ID:         data.ID,
+		URL:        *data.URL,
+		EventTypes: data.EventTypes,
+		Active:     *data.Active,
+		CreatedAt:  data.CreatedAt,
+	}
+	if res.EventTypes == nil {
+		res.EventTypes = make([]string, 0)
+	}
+	return
+}
+
+func ParseFromEntities(data []Subscription) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+type DeliveryResponse struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscriptionId"`
+	EventID        string     `json:"eventId"`
+	EventType      string     `json:"eventType"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseCode   int        `json:"responseCode,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+func ParseFromDelivery(data Delivery) (res DeliveryResponse) {
+	res = DeliveryResponse{
+		ID:             data.ID,
+		SubscriptionID: data.SubscriptionID,
+		EventID:        data.EventID,
+		EventType:      data.EventType,
+		Status:         data.Status,
+		Attempts:       data.Attempts,
+		ResponseCode:   data.ResponseCode,
+		LastError:      data.LastError,
+		DeliveredAt:    data.DeliveredAt,
+		CreatedAt:      data.CreatedAt,
+	}
+	if data.Status == StatusPending {
+		res.NextAttemptAt = &data.NextAttemptAt
+	}
+	return
+}
+
+func ParseFromDeliveries(data []Delivery) (res []DeliveryResponse) {
+	res = make([]DeliveryResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromDelivery(object))
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+// Subscription is an endpoint notified about the events of EventTypes,
+// an empty list subscribes to every event
+type Subscription struct {
+	ID         string     `db:"id" bson:"_id"`
+	TenantID   string     `db:"tenant_id" bson:"tenant_id"`
+	URL        *string    `db:"url" bson:"url"`
+	EventTypes EventTypes `db:"event_types" bson:"event_types"`
+	Secret     *string    `db:"secret" bson:"secret"`
+	Active     *bool      `db:"active" bson:"active"`
+	CreatedAt  time.Time  `db:"created_at" bson:"created_at"`
+}
+
+func (s Subscription) Match(eventType string) bool {
+	if s.Active != nil && !*s.Active {
+		return false
+	}
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, item := range s.EventTypes {
+		if item == eventType || item == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivery is a queued event for a subscription together with the log of its attempts
+type Delivery struct {
+	ID             string     `db:"id" bson:"_id"`
+	SubscriptionID string     `db:"subscription_id" bson:"subscription_id"`
+	EventID        string     `db:"event_id" bson:"event_id"`
+	EventType      string     `db:"event_type" bson:"event_type"`
+	Payload        []byte     `db:"payload" bson:"payload"`
+	Status         string     `db:"status" bson:"status"`
+	Attempts       int        `db:"attempts" bson:"attempts"`
+	ResponseCode   int        `db:"response_code" bson:"response_code"`
+	LastError      string     `db:"last_error" bson:"last_error"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at" bson:"next_attempt_at"`
+	DeliveredAt    *time.Time `db:"delivered_at" bson:"delivered_at"`
+	CreatedAt      time.Time  `db:"created_at" bson:"created_at"`
+}
+
+// EventTypes is stored as a JSON array
+type EventTypes []string
+
+func (e EventTypes) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	src, err := json.Marshal(e)
+	return string(src), err
+}
+
+func (e *EventTypes) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, e)
+	case string:
+		return json.Unmarshal([]byte(value), e)
+	}
+	return errors.New("webhook: unsupported event types value")
+}
@@ -0,0 +1,24 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+type SubscriptionRepository interface {
+	List(ctx context.Context) (dest []Subscription, err error)
+	Add(ctx context.Context, data Subscription) (id string, err error)
+	Get(ctx context.Context, id string) (dest Subscription, err error)
+	Update(ctx context.Context, id string, data Subscription) (err error)
+	Delete(ctx context.Context, id string) (err error)
+}
+
+type DeliveryRepository interface {
+	List(ctx context.Context, subscriptionID string) (dest []Delivery, err error)
+	Add(ctx context.Context, data Delivery) (id string, err error)
+	Get(ctx context.Context, id string) (dest Delivery, err error)
+	Update(ctx context.Context, id string, data Delivery) (err error)
+	// Claim leases up to limit pending deliveries due at now until now+lease,
+	// so that concurrent dispatchers do not pick the same delivery
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (dest []Delivery, err error)
+}
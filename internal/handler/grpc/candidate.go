@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	reservationv1 "reservation-system/api/reservation/v1"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type CandidateServer struct {
+	reservationv1.UnimplementedCandidateServiceServer
+
+	reservationService *reservation.Service
+}
+
+func NewCandidateServer(s *reservation.Service) *CandidateServer {
+	return &CandidateServer{reservationService: s}
+}
+
+func (h *CandidateServer) ListCandidates(ctx context.Context, req *reservationv1.ListCandidatesRequest) (*reservationv1.ListCandidatesResponse, error) {
+	filter := candidate.Filter{
+		IncludeDeleted: req.GetIncludeDeleted(),
+		Skills:         req.GetSkills(),
+		Location:       req.GetLocation(),
+	}
+
+	res, err := h.reservationService.ListCandidates(ctx, filter)
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	dest := &reservationv1.ListCandidatesResponse{}
+	for _, object := range res {
+		dest.Candidates = append(dest.Candidates, candidateToProto(object))
+	}
+
+	return dest, nil
+}
+
+func (h *CandidateServer) AddCandidate(ctx context.Context, req *reservationv1.AddCandidateRequest) (*reservationv1.Candidate, error) {
+	data := candidate.Request{
+		FullName:        req.GetFullName(),
+		Email:           req.GetEmail(),
+		Phone:           int(req.GetPhone()),
+		Skills:          req.GetSkills(),
+		ExperienceYears: int(req.GetExperienceYears()),
+		Location:        req.GetLocation(),
+		Links:           req.GetLinks(),
+	}
+	if err := data.Bind(nil); err != nil {
+		return nil, response.Status(apperror.From(apperror.KindValidation, err))
+	}
+
+	res, err := h.reservationService.AddCandidate(ctx, data)
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return candidateToProto(res), nil
+}
+
+func (h *CandidateServer) GetCandidate(ctx context.Context, req *reservationv1.GetCandidateRequest) (*reservationv1.Candidate, error) {
+	res, err := h.reservationService.GetCandidate(ctx, req.GetId())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return candidateToProto(res), nil
+}
+
+func (h *CandidateServer) UpdateCandidate(ctx context.Context, req *reservationv1.UpdateCandidateRequest) (*reservationv1.Candidate, error) {
+	expected, err := version(req.GetVersion())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	data := candidate.Request{
+		FullName:        req.GetFullName(),
+		Email:           req.GetEmail(),
+		Phone:           int(req.GetPhone()),
+		Skills:          req.GetSkills(),
+		ExperienceYears: int(req.GetExperienceYears()),
+		Location:        req.GetLocation(),
+		Links:           req.GetLinks(),
+	}
+	if err = data.Bind(nil); err != nil {
+		return nil, response.Status(apperror.From(apperror.KindValidation, err))
+	}
+
+	res, err := h.reservationService.UpdateCandidate(ctx, req.GetId(), expected, data)
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return candidateToProto(res), nil
+}
+
+func (h *CandidateServer) DeleteCandidate(ctx context.Context, req *reservationv1.DeleteCandidateRequest) (*emptypb.Empty, error) {
+	expected, err := version(req.GetVersion())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	if err = h.reservationService.DeleteCandidate(ctx, req.GetId(), expected); err != nil {
+		return nil, response.Status(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CandidateServer) RestoreCandidate(ctx context.Context, req *reservationv1.RestoreCandidateRequest) (*reservationv1.Candidate, error) {
+	res, err := h.reservationService.RestoreCandidate(ctx, req.GetId())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return candidateToProto(res), nil
+}
+
+func candidateToProto(data candidate.Response) (res *reservationv1.Candidate) {
+	res = &reservationv1.Candidate{
+		Id:              data.ID,
+		FullName:        data.FullName,
+		Email:           data.Email,
+		Phone:           int64(data.Phone),
+		Version:         int64(data.Version),
+		Skills:          data.Skills,
+		ExperienceYears: int64(data.ExperienceYears),
+		Location:        data.Location,
+		Links:           data.Links,
+	}
+	if data.DeletedAt != nil {
+		res.DeletedAt = timestamppb.New(*data.DeletedAt)
+	}
+
+	return
+}
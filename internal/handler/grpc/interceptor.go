@@ -0,0 +1,142 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/organization"
+	organizationService "reservation-system/internal/service/organization"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/server/response"
+	"strings"
+	"time"
+)
+
+const (
+	// ActorMetadata is the metadata key holding the name of the user performing
+	// the call, it is advisory and recorded alongside the key of the call
+	ActorMetadata = "x-actor"
+
+	// RequestIDMetadata is the metadata key holding the id of the call
+	RequestIDMetadata = "x-request-id"
+
+	// AuthorizationMetadata is the metadata key holding the Bearer key of the principal
+	AuthorizationMetadata = "authorization"
+
+	// OrganizationMetadata is the metadata key naming the organization the
+	// admin key acts on behalf of
+	OrganizationMetadata = "x-organization-id"
+)
+
+// Context puts the actor and the request id of the call into its context
+// the same way the HTTP middlewares do
+func Context(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := first(md, RequestIDMetadata)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, requestID)
+	ctx = audit.ContextWithActor(ctx, first(md, ActorMetadata))
+
+	return handler(ctx, req)
+}
+
+// publicServices are the prefixes of the methods served without a key, the
+// probes of the health service and the reflection of the API
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// Tenant scopes the call to the organization of its Bearer key the same way
+// the HTTP middleware does, the public services are left unscoped
+func Tenant(s *organizationService.Service, required bool, adminKey string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		for _, prefix := range publicServices {
+			if strings.HasPrefix(info.FullMethod, prefix) {
+				return handler(ctx, req)
+			}
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		secret := first(md, AuthorizationMetadata)
+		if len(secret) < len("Bearer ") || !strings.EqualFold(secret[:len("Bearer ")], "Bearer ") {
+			if required {
+				return nil, response.Status(apperror.Unauthorized("%s: Bearer organization key is required", AuthorizationMetadata))
+			}
+			return handler(organization.ContextWithTenant(ctx, organization.DefaultID), req)
+		}
+
+		secret = strings.TrimSpace(secret[len("Bearer "):])
+		if adminKey != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(adminKey)) == 1 {
+			id := first(md, OrganizationMetadata)
+			if id == "" {
+				id = organization.DefaultID
+			}
+			if _, err := s.GetOrganization(ctx, id); err != nil {
+				return nil, response.Status(err)
+			}
+			ctx = organization.ContextWithTenant(ctx, id)
+			ctx = audit.ContextWithPrincipal(ctx, audit.PrincipalAdmin)
+
+			return handler(ctx, req)
+		}
+
+		key, err := s.Authenticate(ctx, secret)
+		if err != nil {
+			return nil, response.Status(err)
+		}
+		ctx = organization.ContextWithTenant(ctx, key.OrganizationID)
+		ctx = audit.ContextWithPrincipal(ctx, key.Principal())
+		if key.RecruiterID != nil {
+			ctx = audit.ContextWithRecruiter(ctx, *key.RecruiterID)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// Logger logs every call with its status code and duration
+func Logger(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	res, err := handler(ctx, req)
+
+	log.LoggerFromContext(ctx).Info("grpc call",
+		zap.String("method", info.FullMethod),
+		zap.String("code", status.Code(err).String()),
+		zap.String("request_id", middleware.GetReqID(ctx)),
+		zap.Duration("duration", time.Since(start)))
+
+	return res, err
+}
+
+// Recoverer turns a panic of the handler into an internal error
+func Recoverer(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+	defer func() {
+		if rvr := recover(); rvr != nil {
+			log.LoggerFromContext(ctx).Error("grpc panic", zap.String("method", info.FullMethod), zap.Any("panic", rvr))
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
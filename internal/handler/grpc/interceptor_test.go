@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	reservationv1 "reservation-system/api/reservation/v1"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/internal/service/reservation"
+	"testing"
+)
+
+func TestTenantPublicServices(t *testing.T) {
+	s, err := reservation.New(
+		reservation.WithCandidateRepository(memory.NewCandidateRepository()),
+		reservation.WithUnitOfWork(memory.NewUnitOfWork()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a key is required, the organization service is never reached without one
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(Tenant(nil, true, "")))
+	reservationv1.RegisterCandidateServiceServer(server, NewCandidateServer(s))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context) error
+		wantCode codes.Code
+	}{
+		{name: "health probe", wantCode: codes.OK, call: func(ctx context.Context) error {
+			res, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if err == nil && res.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+				t.Errorf("health status = %s, want SERVING", res.GetStatus())
+			}
+			return err
+		}},
+		{name: "api call", wantCode: codes.Unauthenticated, call: func(ctx context.Context) error {
+			_, err := reservationv1.NewCandidateServiceClient(conn).ListCandidates(ctx, &reservationv1.ListCandidatesRequest{})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call(context.Background())); got != tt.wantCode {
+				t.Errorf("code = %s, want %s", got, tt.wantCode)
+			}
+		})
+	}
+}
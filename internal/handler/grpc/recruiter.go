@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	reservationv1 "reservation-system/api/reservation/v1"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type RecruiterServer struct {
+	reservationv1.UnimplementedRecruiterServiceServer
+
+	reservationService *reservation.Service
+}
+
+func NewRecruiterServer(s *reservation.Service) *RecruiterServer {
+	return &RecruiterServer{reservationService: s}
+}
+
+func (h *RecruiterServer) ListRecruiters(ctx context.Context, req *reservationv1.ListRecruitersRequest) (*reservationv1.ListRecruitersResponse, error) {
+	filter := recruiter.Filter{
+		IncludeDeleted: req.GetIncludeDeleted(),
+		Team:           req.GetTeam(),
+		InterviewType:  req.GetInterviewType(),
+	}
+
+	res, err := h.reservationService.ListRecruiters(ctx, filter)
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	dest := &reservationv1.ListRecruitersResponse{}
+	for _, object := range res {
+		dest.Recruiters = append(dest.Recruiters, recruiterToProto(object))
+	}
+
+	return dest, nil
+}
+
+func (h *RecruiterServer) AddRecruiter(ctx context.Context, req *reservationv1.AddRecruiterRequest) (*reservationv1.Recruiter, error) {
+	data := recruiter.Request{
+		FullName:             req.GetFullName(),
+		Email:                req.GetEmail(),
+		Phone:                int(req.GetPhone()),
+		Title:                req.GetTitle(),
+		Team:                 req.GetTeam(),
+		InterviewTypes:       req.GetInterviewTypes(),
+		WorkingHours:         scheduleFromProto(req.GetWorkingHours()),
+		MaxInterviewsPerDay:  int(req.GetMaxInterviewsPerDay()),
+		MaxInterviewsPerWeek: int(req.GetMaxInterviewsPerWeek()),
+		OutOfOffice:          absencesFromProto(req.GetOutOfOffice()),
+	}
+	if err := data.Bind(nil); err != nil {
+		return nil, response.Status(apperror.From(apperror.KindValidation, err))
+	}
+
+	res, err := h.reservationService.AddRecruiter(ctx, data)
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return recruiterToProto(res), nil
+}
+
+func (h *RecruiterServer) GetRecruiter(ctx context.Context, req *reservationv1.GetRecruiterRequest) (*reservationv1.Recruiter, error) {
+	res, err := h.reservationService.GetRecruiter(ctx, req.GetId())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return recruiterToProto(res), nil
+}
+
+func (h *RecruiterServer) UpdateRecruiter(ctx context.Context, req *reservationv1.UpdateRecruiterRequest) (*reservationv1.Recruiter, error) {
+	expected, err := version(req.GetVersion())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	data := recruiter.Request{
+		FullName:             req.GetFullName(),
+		Email:                req.GetEmail(),
+		Phone:                int(req.GetPhone()),
+		Title:                req.GetTitle(),
+		Team:                 req.GetTeam(),
+		InterviewTypes:       req.GetInterviewTypes(),
+		WorkingHours:         scheduleFromProto(req.GetWorkingHours()),
+		MaxInterviewsPerDay:  int(req.GetMaxInterviewsPerDay()),
+		MaxInterviewsPerWeek: int(req.GetMaxInterviewsPerWeek()),
+		OutOfOffice:          absencesFromProto(req.GetOutOfOffice()),
+	}
+	if err = data.Bind(nil); err != nil {
+		return nil, response.Status(apperror.From(apperror.KindValidation, err))
+	}
+
+	res, err := h.reservationService.UpdateRecruiter(ctx, req.GetId(), expected, data)
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return recruiterToProto(res), nil
+}
+
+func (h *RecruiterServer) DeleteRecruiter(ctx context.Context, req *reservationv1.DeleteRecruiterRequest) (*emptypb.Empty, error) {
+	expected, err := version(req.GetVersion())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	if err = h.reservationService.DeleteRecruiter(ctx, req.GetId(), expected); err != nil {
+		return nil, response.Status(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *RecruiterServer) RestoreRecruiter(ctx context.Context, req *reservationv1.RestoreRecruiterRequest) (*reservationv1.Recruiter, error) {
+	res, err := h.reservationService.RestoreRecruiter(ctx, req.GetId())
+	if err != nil {
+		return nil, response.Status(err)
+	}
+
+	return recruiterToProto(res), nil
+}
+
+func recruiterToProto(data recruiter.Response) (res *reservationv1.Recruiter) {
+	res = &reservationv1.Recruiter{
+		Id:                   data.ID,
+		FullName:             data.FullName,
+		Email:                data.Email,
+		Phone:                int64(data.Phone),
+		Version:              int64(data.Version),
+		Title:                data.Title,
+		Team:                 data.Team,
+		InterviewTypes:       data.InterviewTypes,
+		MaxInterviewsPerDay:  int64(data.MaxInterviewsPerDay),
+		MaxInterviewsPerWeek: int64(data.MaxInterviewsPerWeek),
+	}
+	if data.DeletedAt != nil {
+		res.DeletedAt = timestamppb.New(*data.DeletedAt)
+	}
+	if data.WorkingHours != nil {
+		res.WorkingHours = &reservationv1.WorkingHours{
+			Days:     data.WorkingHours.Days,
+			Start:    data.WorkingHours.Start,
+			End:      data.WorkingHours.End,
+			TimeZone: data.WorkingHours.TimeZone,
+		}
+	}
+	for _, absence := range data.OutOfOffice {
+		res.OutOfOffice = append(res.OutOfOffice, &reservationv1.Absence{
+			StartsAt: timestamppb.New(absence.StartsAt),
+			EndsAt:   timestamppb.New(absence.EndsAt),
+			Reason:   absence.Reason,
+		})
+	}
+
+	return
+}
+
+// scheduleFromProto returns nil for missing working hours so that the
+// recruiter keeps the working hours of the service
+func scheduleFromProto(data *reservationv1.WorkingHours) *recruiter.Schedule {
+	if data == nil {
+		return nil
+	}
+
+	return &recruiter.Schedule{
+		Days:     data.GetDays(),
+		Start:    data.GetStart(),
+		End:      data.GetEnd(),
+		TimeZone: data.GetTimeZone(),
+	}
+}
+
+func absencesFromProto(data []*reservationv1.Absence) (dest []recruiter.Absence) {
+	for _, absence := range data {
+		dest = append(dest, recruiter.Absence{
+			StartsAt: absence.GetStartsAt().AsTime(),
+			EndsAt:   absence.GetEndsAt().AsTime(),
+			Reason:   absence.GetReason(),
+		})
+	}
+
+	return
+}
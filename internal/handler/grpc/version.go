@@ -0,0 +1,17 @@
+package grpc
+
+import "reservation-system/pkg/apperror"
+
+// version returns the entity version expected by the call. Unlike the
+// repositories, 0 does not skip the check: the calls require a version the
+// same way the HTTP handlers require If-Match.
+func version(value int64) (int, error) {
+	switch {
+	case value == 0:
+		return 0, apperror.PreconditionRequired("version: is required")
+	case value < 0:
+		return 0, apperror.Validation("version: must be positive")
+	}
+
+	return int(value), nil
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	reservationv1 "reservation-system/api/reservation/v1"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/internal/service/reservation"
+	"testing"
+)
+
+func TestVersionRequired(t *testing.T) {
+	s, err := reservation.New(
+		reservation.WithCandidateRepository(memory.NewCandidateRepository()),
+		reservation.WithRecruiterRepository(memory.NewRecruiterRepository()),
+		reservation.WithUnitOfWork(memory.NewUnitOfWork()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := organization.ContextWithTenant(context.Background(), organization.DefaultID)
+	candidates, recruiters := NewCandidateServer(s), NewRecruiterServer(s)
+
+	candidate, err := candidates.AddCandidate(ctx, &reservationv1.AddCandidateRequest{FullName: "Carl", Email: "carl@example.com", Phone: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recruiter, err := recruiters.AddRecruiter(ctx, &reservationv1.AddRecruiterRequest{FullName: "Rita", Email: "rita@example.com", Phone: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		call     func(version int64) error
+		wantCode codes.Code
+		version  int64
+	}{
+		{name: "update candidate without version", version: 0, wantCode: codes.FailedPrecondition, call: func(version int64) error {
+			_, err := candidates.UpdateCandidate(ctx, &reservationv1.UpdateCandidateRequest{Id: candidate.Id, Version: version, FullName: "Carl", Email: "carl@example.com", Phone: 1})
+			return err
+		}},
+		{name: "update candidate of a stale version", version: 2, wantCode: codes.Aborted, call: func(version int64) error {
+			_, err := candidates.UpdateCandidate(ctx, &reservationv1.UpdateCandidateRequest{Id: candidate.Id, Version: version, FullName: "Carl", Email: "carl@example.com", Phone: 1})
+			return err
+		}},
+		{name: "delete candidate without version", version: 0, wantCode: codes.FailedPrecondition, call: func(version int64) error {
+			_, err := candidates.DeleteCandidate(ctx, &reservationv1.DeleteCandidateRequest{Id: candidate.Id, Version: version})
+			return err
+		}},
+		{name: "delete candidate", version: 1, wantCode: codes.OK, call: func(version int64) error {
+			_, err := candidates.DeleteCandidate(ctx, &reservationv1.DeleteCandidateRequest{Id: candidate.Id, Version: version})
+			return err
+		}},
+		{name: "update recruiter without version", version: 0, wantCode: codes.FailedPrecondition, call: func(version int64) error {
+			_, err := recruiters.UpdateRecruiter(ctx, &reservationv1.UpdateRecruiterRequest{Id: recruiter.Id, Version: version, FullName: "Rita", Email: "rita@example.com", Phone: 1})
+			return err
+		}},
+		{name: "update recruiter with a negative version", version: -1, wantCode: codes.InvalidArgument, call: func(version int64) error {
+			_, err := recruiters.UpdateRecruiter(ctx, &reservationv1.UpdateRecruiterRequest{Id: recruiter.Id, Version: version, FullName: "Rita", Email: "rita@example.com", Phone: 1})
+			return err
+		}},
+		{name: "delete recruiter without version", version: 0, wantCode: codes.FailedPrecondition, call: func(version int64) error {
+			_, err := recruiters.DeleteRecruiter(ctx, &reservationv1.DeleteRecruiterRequest{Id: recruiter.Id, Version: version})
+			return err
+		}},
+		{name: "delete recruiter", version: 1, wantCode: codes.OK, call: func(version int64) error {
+			_, err := recruiters.DeleteRecruiter(ctx, &reservationv1.DeleteRecruiterRequest{Id: recruiter.Id, Version: version})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call(tt.version)); got != tt.wantCode {
+				t.Errorf("code = %s, want %s", got, tt.wantCode)
+			}
+		})
+	}
+}
@@ -4,16 +4,32 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	reservationv1 "reservation-system/api/reservation/v1"
 	"reservation-system/docs"
 	"reservation-system/internal/config"
+	grpcHandler "reservation-system/internal/handler/grpc"
 	"reservation-system/internal/handler/http"
+	"reservation-system/internal/service/event"
+	"reservation-system/internal/service/idempotency"
+	"reservation-system/internal/service/notification"
+	"reservation-system/internal/service/organization"
 	"reservation-system/internal/service/reservation"
+	"reservation-system/internal/service/webhook"
 	"reservation-system/pkg/server/router"
 )
 
 type Dependencies struct {
-	Configs            config.Configs
-	ReservationService *reservation.Service
+	Configs             config.Configs
+	ReservationService  *reservation.Service
+	IdempotencyService  *idempotency.Service
+	EventBus            *event.Bus
+	WebhookService      *webhook.Service
+	NotificationService *notification.Service
+	OrganizationService *organization.Service
 }
 
 // Configuration is an alias for a function that will take in a pointer to a Handler and modify it
@@ -24,6 +40,7 @@ type Handler struct {
 	dependencies Dependencies
 
 	HTTP *chi.Mux
+	GRPC *grpc.Server
 }
 
 // New takes a variable amount of Configuration functions and returns a new Handler
@@ -50,7 +67,7 @@ func WithHTTPHandler() Configuration {
 		// Create the http handler, if we needed parameters, such as connection strings they could be inputted here
 		h.HTTP = router.New()
 
-		h.HTTP.Use(middleware.Timeout(h.dependencies.Configs.APP.Timeout))
+		h.HTTP.Use(http.Actor)
 
 		// Init swagger handler
 		docs.SwaggerInfo.BasePath = h.dependencies.Configs.APP.Path
@@ -60,14 +77,91 @@ func WithHTTPHandler() Configuration {
 		// Init service handlers
 		recruiterHandler := http.NewRecruiterHandler(h.dependencies.ReservationService)
 		candidateHandler := http.NewCandidateHandler(h.dependencies.ReservationService)
+		interviewHandler := http.NewInterviewHandler(h.dependencies.ReservationService)
+		resourceHandler := http.NewResourceHandler(h.dependencies.ReservationService)
+		vacancyHandler := http.NewVacancyHandler(h.dependencies.ReservationService)
+		teamHandler := http.NewTeamHandler(h.dependencies.ReservationService)
+		applicationHandler := http.NewApplicationHandler(h.dependencies.ReservationService)
+		scorecardTemplateHandler := http.NewScorecardTemplateHandler(h.dependencies.ReservationService)
+		availabilityHandler := http.NewAvailabilityHandler(h.dependencies.ReservationService)
+		auditHandler := http.NewAuditHandler(h.dependencies.ReservationService)
+		eventHandler := http.NewEventHandler(h.dependencies.EventBus)
+		webhookHandler := http.NewWebhookHandler(h.dependencies.WebhookService)
+		notificationHandler := http.NewNotificationHandler(h.dependencies.NotificationService)
+		organizationHandler := http.NewOrganizationHandler(h.dependencies.OrganizationService)
 
 		h.HTTP.Route("/", func(r chi.Router) {
-			// use the Bearer Authentication middleware
+			// the tenant admin endpoints are authenticated with the admin key
+			r.Group(func(r chi.Router) {
+				r.Use(http.Admin(h.dependencies.Configs.AUTH.AdminKey))
+				r.Use(http.Idempotency(h.dependencies.IdempotencyService))
+				r.Use(middleware.Timeout(h.dependencies.Configs.APP.Timeout))
 
-			r.Mount("/recruiters", recruiterHandler.Routes())
-			r.Mount("/candidates", candidateHandler.Routes())
+				r.Mount("/organizations", organizationHandler.Routes())
+			})
+
+			// the calendar feeds authenticate with their own token, calendar
+			// clients fetch them without credentials
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.Timeout(h.dependencies.Configs.APP.Timeout))
+
+				r.Get("/candidates/{id}/calendar.ics", candidateHandler.Calendar)
+				r.Get("/recruiters/{id}/calendar.ics", recruiterHandler.Calendar)
+			})
+
+			// the other endpoints are scoped to the organization of the Bearer key
+			r.Group(func(r chi.Router) {
+				r.Use(http.Tenant(h.dependencies.OrganizationService, h.dependencies.Configs.AUTH.Required, h.dependencies.Configs.AUTH.AdminKey))
+				r.Use(http.Idempotency(h.dependencies.IdempotencyService))
+
+				r.Group(func(r chi.Router) {
+					r.Use(middleware.Timeout(h.dependencies.Configs.APP.Timeout))
+
+					r.Mount("/recruiters", recruiterHandler.Routes())
+					r.Mount("/candidates", candidateHandler.Routes())
+					r.Mount("/interviews", interviewHandler.Routes())
+					r.Mount("/resources", resourceHandler.Routes())
+					r.Mount("/teams", teamHandler.Routes())
+					r.Mount("/vacancies", vacancyHandler.Routes())
+					r.Mount("/applications", applicationHandler.Routes())
+					r.Mount("/scorecard-templates", scorecardTemplateHandler.Routes())
+					r.Mount("/availability", availabilityHandler.Routes())
+					r.Mount("/audit", auditHandler.Routes())
+					r.Mount("/webhooks", webhookHandler.Routes())
+					r.Mount("/notifications", notificationHandler.Routes())
+				})
+
+				// event streams are long-lived and not limited by the request timeout
+				r.Mount("/events", eventHandler.Routes())
+			})
 		})
 
 		return
 	}
 }
+
+func WithGRPCHandler() Configuration {
+	return func(h *Handler) (err error) {
+		// Create the grpc handler with the interceptors mirroring the http middlewares
+		h.GRPC = grpc.NewServer(
+			grpc.ChainUnaryInterceptor(
+				grpcHandler.Recoverer,
+				grpcHandler.Context,
+				grpcHandler.Logger,
+				grpcHandler.Tenant(h.dependencies.OrganizationService, h.dependencies.Configs.AUTH.Required, h.dependencies.Configs.AUTH.AdminKey)))
+
+		// Init service handlers
+		reservationv1.RegisterCandidateServiceServer(h.GRPC, grpcHandler.NewCandidateServer(h.dependencies.ReservationService))
+		reservationv1.RegisterRecruiterServiceServer(h.GRPC, grpcHandler.NewRecruiterServer(h.dependencies.ReservationService))
+
+		// Init health and reflection services
+		healthServer := health.NewServer()
+		for name := range h.GRPC.GetServiceInfo() {
+			healthServer.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_SERVING)
+		}
+		grpc_health_v1.RegisterHealthServer(h.GRPC, healthServer)
+		reflection.Register(h.GRPC)
+
+		return
+	}
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/application"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type ApplicationHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewApplicationHandler(s *reservation.Service) *ApplicationHandler {
+	return &ApplicationHandler{reservationService: s}
+}
+
+func (h *ApplicationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Post("/move", h.move)
+	})
+
+	return r
+}
+
+// @Summary	list of applications from the repository
+// @Tags		applications
+// @Accept		json
+// @Produce	json
+// @Param		candidate	query		string	false	"candidate id"
+// @Param		vacancy		query		string	false	"vacancy id"
+// @Param		stage		query		string	false	"stage of the pipeline"
+// @Param		status		query		string	false	"active, hired, rejected or withdrawn"
+// @Success	200			{array}		application.Response
+// @Failure	500			{object}	response.Problem
+// @Router		/applications 	[get]
+func (h *ApplicationHandler) list(w http.ResponseWriter, r *http.Request) {
+	filter := application.Filter{
+		CandidateID: r.URL.Query().Get("candidate"),
+		VacancyID:   r.URL.Query().Get("vacancy"),
+		Stage:       r.URL.Query().Get("stage"),
+		Status:      r.URL.Query().Get("status"),
+	}
+
+	res, err := h.reservationService.ListApplications(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	apply the candidate to the vacancy
+// @Tags		applications
+// @Accept		json
+// @Produce	json
+// @Param		request	body		application.Request	true	"body param"
+// @Success	201		{object}	application.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	409		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/applications [post]
+func (h *ApplicationHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := application.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.AddApplication(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	get the application with its stage history from the repository
+// @Tags		applications
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	application.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/applications/{id} [get]
+func (h *ApplicationHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.GetApplication(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	move the application to another stage or hire, reject or withdraw it
+// @Tags		applications
+// @Accept		json
+// @Produce	json
+// @Param		id			path		string					true	"path param"
+// @Param		If-Match	header		string					true	"entity tag of the application"
+// @Param		request		body		application.MoveRequest	true	"body param"
+// @Success	200			{object}	application.Response
+// @Failure	400			{object}	response.Problem
+// @Failure	404			{object}	response.Problem
+// @Failure	409			{object}	response.Problem
+// @Failure	412			{object}	response.Problem
+// @Failure	422			{object}	response.Problem
+// @Failure	428			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
+// @Router		/applications/{id}/move [post]
+func (h *ApplicationHandler) move(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := application.MoveRequest{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.MoveApplication(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
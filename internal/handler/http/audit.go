@@ -0,0 +1,49 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/server/response"
+)
+
+type AuditHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewAuditHandler(s *reservation.Service) *AuditHandler {
+	return &AuditHandler{reservationService: s}
+}
+
+func (h *AuditHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+
+	return r
+}
+
+// @Summary	list of audit entries for the entity
+// @Tags		audit
+// @Accept		json
+// @Produce	json
+// @Param		entity	query		string	false	"entity type, e.g. candidate"
+// @Param		id		query		string	false	"entity id"
+// @Success	200		{array}		audit.Response
+// @Failure	500		{object}	response.Problem
+// @Router		/audit 	[get]
+func (h *AuditHandler) list(w http.ResponseWriter, r *http.Request) {
+	filter := audit.Filter{
+		EntityType: r.URL.Query().Get("entity"),
+		EntityID:   r.URL.Query().Get("id"),
+	}
+
+	res, err := h.reservationService.ListAudit(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type AvailabilityHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewAvailabilityHandler(s *reservation.Service) *AvailabilityHandler {
+	return &AvailabilityHandler{reservationService: s}
+}
+
+func (h *AvailabilityHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.find)
+
+	return r
+}
+
+// @Summary	windows in which all the recruiters are free, earliest first
+// @Tags		availability
+// @Accept		json
+// @Produce	json
+// @Param		recruiters	query		string	true	"comma separated recruiter ids"
+// @Param		from		query		string	false	"RFC 3339 time, defaults to now"
+// @Param		to			query		string	false	"RFC 3339 time, defaults to a week after from"
+// @Param		duration	query		string	true	"minimal length of a window, e.g. 45m or 1h30m"
+// @Param		limit		query		int		false	"maximal number of windows"
+// @Success	200			{array}		availability.Response
+// @Failure	400			{object}	response.Problem
+// @Failure	422			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
+// @Router		/availability 	[get]
+func (h *AvailabilityHandler) find(w http.ResponseWriter, r *http.Request) {
+	req := availability.Request{}
+	if recruiters := r.URL.Query().Get("recruiters"); recruiters != "" {
+		req.RecruiterIDs = strings.Split(recruiters, ",")
+	}
+
+	var err error
+	if req.From, err = parseTime(r, "from"); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if req.To, err = parseTime(r, "to"); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if duration := r.URL.Query().Get("duration"); duration != "" {
+		if req.Duration, err = time.ParseDuration(duration); err != nil {
+			response.Error(w, r, apperror.Validation("duration: must be a duration such as 45m or 1h30m"))
+			return
+		}
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if req.Limit, err = strconv.Atoi(limit); err != nil {
+			response.Error(w, r, apperror.Validation("limit: must be a number"))
+			return
+		}
+	}
+
+	res, err := h.reservationService.FindAvailability(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"io"
+	"mime"
+	"net/http"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+// @Summary	list of the busy times of the recruiter imported from external calendars
+// @Tags		recruiters
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string	true	"path param"
+// @Param		from	query		string	false	"RFC 3339 time, busy times ending after it"
+// @Param		to		query		string	false	"RFC 3339 time, busy times starting before it"
+// @Success	200		{array}		busy.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/recruiters/{id}/busy [get]
+func (h *RecruiterHandler) listBusy(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	from, err := parseTime(r, "from")
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	to, err := parseTime(r, "to")
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	res, err := h.reservationService.ListBusy(r.Context(), id, from, to)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	import the busy times of the recruiter from an iCalendar file, replacing the previous upload
+// @Tags		recruiters
+// @Accept		multipart/form-data
+// @Accept		text/calendar
+// @Produce	json
+// @Param		id		path		string	true	"path param"
+// @Param		file	formData	file	false	"iCalendar file"
+// @Success	200		{object}	busy.ImportResponse
+// @Failure	400		{object}	response.Problem
+// @Failure	404		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/recruiters/{id}/busy [post]
+func (h *RecruiterHandler) importBusy(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	r.Body = http.MaxBytesReader(w, r.Body, reservation.MaxCalendarSize)
+
+	var src io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			response.Error(w, r, apperror.Validation("file: cannot be blank"))
+			return
+		}
+		defer file.Close()
+		src = file
+	}
+
+	res, err := h.reservationService.ImportBusy(r.Context(), id, src)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	get the external calendar feed of the recruiter
+// @Tags		recruiters
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	busy.FeedResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/recruiters/{id}/busy/feed [get]
+func (h *RecruiterHandler) getBusyFeed(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.GetBusyFeed(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	subscribe to the external calendar feed of the recruiter, it is imported right away
+// @Tags		recruiters
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		busy.FeedRequest	true	"body param"
+// @Success	200		{object}	busy.FeedResponse
+// @Failure	400		{object}	response.Problem
+// @Failure	404		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/recruiters/{id}/busy/feed [put]
+func (h *RecruiterHandler) setBusyFeed(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := busy.FeedRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.SetBusyFeed(r.Context(), id, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	unsubscribe from the external calendar feed of the recruiter and drop its busy times
+// @Tags		recruiters
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	204
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/recruiters/{id}/busy/feed [delete]
+func (h *RecruiterHandler) deleteBusyFeed(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.reservationService.DeleteBusyFeed(r.Context(), id); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
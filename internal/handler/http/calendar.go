@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+	"reservation-system/pkg/ical"
+)
+
+// writeCalendar writes the calendar as an iCalendar file, attached under the
+// filename when given so that it is offered as a download
+func writeCalendar(w http.ResponseWriter, cal ical.Calendar, filename string) {
+	body := cal.Bytes()
+
+	contentType := "text/calendar; charset=utf-8"
+	if cal.Method != "" {
+		contentType += "; method=" + cal.Method
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	if filename != "" {
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// baseURL returns the scheme and host the request was addressed to
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
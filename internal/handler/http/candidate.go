@@ -1,14 +1,18 @@
 package http
 
 import (
-	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"io"
+	"mime"
 	"net/http"
+	"reservation-system/internal/domain/calendar"
 	"reservation-system/internal/domain/candidate"
 	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
 	"reservation-system/pkg/server/response"
-	"reservation-system/pkg/store"
+	"strconv"
+	"strings"
 )
 
 type CandidateHandler struct {
@@ -29,6 +33,18 @@ func (h *CandidateHandler) Routes() chi.Router {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
+		r.Post("/restore", h.restore)
+		r.Post("/calendar-token", h.issueCalendarToken)
+		r.Get("/scorecards", h.scorecards)
+
+		r.Get("/notes", h.listNotes)
+		r.Post("/notes", h.addNote)
+		r.Delete("/notes/{noteId}", h.deleteNote)
+
+		r.Get("/attachments", h.listAttachments)
+		r.Post("/attachments", h.uploadAttachment)
+		r.Get("/attachments/{attachmentId}", h.downloadAttachment)
+		r.Delete("/attachments/{attachmentId}", h.deleteAttachment)
 	})
 
 	return r
@@ -38,13 +54,32 @@ func (h *CandidateHandler) Routes() chi.Router {
 // @Tags		candidates
 // @Accept		json
 // @Produce	json
+// @Param		include_deleted	query		bool	false	"include deleted candidates, admin key only"
+// @Param		skills			query		string	false	"comma separated skills the candidates all have"
+// @Param		location		query		string	false	"location of the candidates"
 // @Success	200			{array}		candidate.Response
-// @Failure	500			{object}	response.Object
+// @Failure	400			{object}	response.Problem
+// @Failure	403			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
 // @Router		/candidates 	[get]
 func (h *CandidateHandler) list(w http.ResponseWriter, r *http.Request) {
-	res, err := h.reservationService.ListCandidates(r.Context())
+	filter := candidate.Filter{}
+	if value := r.URL.Query().Get("include_deleted"); value != "" {
+		includeDeleted, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Error(w, r, apperror.Validation("include_deleted: must be a boolean"))
+			return
+		}
+		filter.IncludeDeleted = includeDeleted
+	}
+	if skills := r.URL.Query().Get("skills"); skills != "" {
+		filter.Skills = strings.Split(skills, ",")
+	}
+	filter.Location = r.URL.Query().Get("location")
+
+	res, err := h.reservationService.ListCandidates(r.Context(), filter)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		response.Error(w, r, err)
 		return
 	}
 
@@ -56,24 +91,25 @@ func (h *CandidateHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Accept		json
 // @Produce	json
 // @Param		request	body		candidate.Request	true	"body param"
-// @Success	200		{object}	candidate.Response
-// @Failure	400		{object}	response.Object
-// @Failure	500		{object}	response.Object
+// @Success	201		{object}	candidate.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
 // @Router		/candidates [post]
 func (h *CandidateHandler) add(w http.ResponseWriter, r *http.Request) {
 	req := candidate.Request{}
 	if err := render.Bind(r, &req); err != nil {
-		response.BadRequest(w, r, err, req)
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
 		return
 	}
 
 	res, err := h.reservationService.AddCandidate(r.Context(), req)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		response.Error(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", etag(res.Version))
 
-	response.OK(w, r, res)
+	response.Created(w, r, res)
 }
 
 // @Summary	get the candidate from the repository
@@ -82,22 +118,18 @@ func (h *CandidateHandler) add(w http.ResponseWriter, r *http.Request) {
 // @Produce	json
 // @Param		id	path		int	true	"path param"
 // @Success	200	{object}	candidate.Response
-// @Failure	404	{object}	response.Object
-// @Failure	500	{object}	response.Object
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
 // @Router		/candidates/{id} [get]
 func (h *CandidateHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	res, err := h.reservationService.GetCandidate(r.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, store.ErrorNotFound):
-			response.NotFound(w, r, err)
-		default:
-			response.InternalServerError(w, r, err)
-		}
+		response.Error(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", etag(res.Version))
 
 	response.OK(w, r, res)
 }
@@ -106,52 +138,347 @@ func (h *CandidateHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Tags		candidates
 // @Accept		json
 // @Produce	json
-// @Param		id		path	int				true	"path param"
-// @Param		request	body	candidate.Request	true	"body param"
-// @Success	200
-// @Failure	400	{object}	response.Object
-// @Failure	404	{object}	response.Object
-// @Failure	500	{object}	response.Object
+// @Param		id			path	int				true	"path param"
+// @Param		If-Match	header	string			true	"entity tag of the candidate"
+// @Param		request		body	candidate.Request	true	"body param"
+// @Success	200	{object}	candidate.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
 // @Router		/candidates/{id} [put]
 func (h *CandidateHandler) update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
 	req := candidate.Request{}
-	if err := render.Bind(r, &req); err != nil {
-		response.BadRequest(w, r, err, req)
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
 		return
 	}
 
-	if err := h.reservationService.UpdateCandidate(r.Context(), id, req); err != nil {
-		switch {
-		case errors.Is(err, store.ErrorNotFound):
-			response.NotFound(w, r, err)
-		default:
-			response.InternalServerError(w, r, err)
-		}
+	res, err := h.reservationService.UpdateCandidate(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
 }
 
 // @Summary	delete the candidate from the repository
 // @Tags		candidates
 // @Accept		json
 // @Produce	json
-// @Param		id	path	int	true	"path param"
-// @Success	200
-// @Failure	404	{object}	response.Object
-// @Failure	500	{object}	response.Object
+// @Param		id			path	int		true	"path param"
+// @Param		If-Match	header	string	true	"entity tag of the candidate"
+// @Success	204
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
 // @Router		/candidates/{id} [delete]
 func (h *CandidateHandler) delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	if err := h.reservationService.DeleteCandidate(r.Context(), id); err != nil {
-		switch {
-		case errors.Is(err, store.ErrorNotFound):
-			response.NotFound(w, r, err)
-		default:
-			response.InternalServerError(w, r, err)
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err = h.reservationService.DeleteCandidate(r.Context(), id, version); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
+
+// @Summary	restore the deleted candidate in the repository
+// @Tags		candidates
+// @Accept		json
+// @Produce	json
+// @Param		id	path		int	true	"path param"
+// @Success	200	{object}	candidate.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/candidates/{id}/restore [post]
+func (h *CandidateHandler) restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.RestoreCandidate(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	calendar feed of the interviews of the candidate
+// @Tags		candidates
+// @Produce	text/calendar
+// @Param		id		path		string	true	"path param"
+// @Param		token	query		string	true	"secret feed token"
+// @Success	200		{string}	string
+// @Failure	403		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/candidates/{id}/calendar.ics [get]
+func (h *CandidateHandler) Calendar(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.CalendarFeed(r.Context(), calendar.OwnerCandidate, id, r.URL.Query().Get("token"))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	writeCalendar(w, res, "")
+}
+
+// @Summary	issue a new calendar feed token for the candidate, the previous one stops working
+// @Tags		candidates
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	201	{object}	calendar.FeedTokenResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/candidates/{id}/calendar-token [post]
+func (h *CandidateHandler) issueCalendarToken(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.IssueFeedToken(r.Context(), calendar.OwnerCandidate, id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	res.URL = baseURL(r) + res.URL
+
+	response.Created(w, r, res)
+}
+
+// @Summary	summary of the scorecards submitted on the interviews of the candidate
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	scorecard.SummaryResponse
+// @Failure	403	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/candidates/{id}/scorecards [get]
+func (h *CandidateHandler) scorecards(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.SummarizeScorecards(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	notes of the recruiters on the candidate, newest first
+// @Tags		candidates
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		candidate.NoteResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/candidates/{id}/notes [get]
+func (h *CandidateHandler) listNotes(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.ListNotes(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a note of a recruiter on the candidate
+// @Tags		candidates
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string					true	"path param"
+// @Param		request	body		candidate.NoteRequest	true	"body param"
+// @Success	201		{object}	candidate.NoteResponse
+// @Failure	400		{object}	response.Problem
+// @Failure	404		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/candidates/{id}/notes [post]
+func (h *CandidateHandler) addNote(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := candidate.NoteRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.AddNote(r.Context(), id, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.Created(w, r, res)
+}
+
+// @Summary	delete the note on the candidate
+// @Tags		candidates
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string	true	"path param"
+// @Param		noteId	path	string	true	"path param"
+// @Success	204
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/candidates/{id}/notes/{noteId} [delete]
+func (h *CandidateHandler) deleteNote(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	noteID := chi.URLParam(r, "noteId")
+
+	if err := h.reservationService.DeleteNote(r.Context(), id, noteID); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
+
+// @Summary	files attached to the candidate, newest first
+// @Tags		candidates
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		candidate.AttachmentResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/candidates/{id}/attachments [get]
+func (h *CandidateHandler) listAttachments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.ListAttachments(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	attach a file such as a CV to the candidate
+// @Tags		candidates
+// @Accept		multipart/form-data
+// @Produce	json
+// @Param		id		path		string	true	"path param"
+// @Param		file	formData	file	true	"pdf, doc, docx, odt, rtf or txt document"
+// @Success	201		{object}	candidate.AttachmentResponse
+// @Failure	400		{object}	response.Problem
+// @Failure	404		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/candidates/{id}/attachments [post]
+func (h *CandidateHandler) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	// the parts are streamed rather than parsed so that the file is never
+	// held in memory or spooled to a temporary file
+	reader, err := r.MultipartReader()
+	if err != nil {
+		response.Error(w, r, apperror.Validation("request must be multipart/form-data"))
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			response.Error(w, r, apperror.Validation("file: cannot be blank"))
+			return
+		}
+		if err != nil {
+			response.Error(w, r, apperror.From(apperror.KindValidation, err))
+			return
 		}
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		res, err := h.reservationService.UploadAttachment(r.Context(), id, part.FileName(), part)
+		part.Close()
+		if err != nil {
+			response.Error(w, r, err)
+			return
+		}
+
+		response.Created(w, r, res)
 		return
 	}
 }
+
+// @Summary	download the file attached to the candidate
+// @Tags		candidates
+// @Produce	octet-stream
+// @Param		id				path		string	true	"path param"
+// @Param		attachmentId	path		string	true	"path param"
+// @Success	200				{file}		file
+// @Failure	404				{object}	response.Problem
+// @Failure	500				{object}	response.Problem
+// @Router		/candidates/{id}/attachments/{attachmentId} [get]
+func (h *CandidateHandler) downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	res, content, err := h.reservationService.DownloadAttachment(r.Context(), id, attachmentID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", res.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": res.FileName}))
+	w.Header().Set("Content-Length", strconv.FormatInt(res.Size, 10))
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, content)
+}
+
+// @Summary	delete the file attached to the candidate
+// @Tags		candidates
+// @Accept		json
+// @Produce	json
+// @Param		id				path	string	true	"path param"
+// @Param		attachmentId	path	string	true	"path param"
+// @Success	204
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/candidates/{id}/attachments/{attachmentId} [delete]
+func (h *CandidateHandler) deleteAttachment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	if err := h.reservationService.DeleteAttachment(r.Context(), id, attachmentID); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
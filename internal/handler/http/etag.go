@@ -0,0 +1,40 @@
+package http
+
+import (
+	"net/http"
+	"reservation-system/pkg/apperror"
+	"strconv"
+	"strings"
+)
+
+// etag formats the version of an entity as a strong entity tag
+func etag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatch returns the entity version expected by the If-Match header,
+// "*" matches any version and is returned as 0. If-Match compares strongly,
+// a weak entity tag never matches.
+func ifMatch(r *http.Request) (version int, err error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch value {
+	case "":
+		return 0, apperror.PreconditionRequired("If-Match: header is required")
+	case "*":
+		return 0, nil
+	}
+
+	if strings.HasPrefix(value, "W/") {
+		return 0, apperror.PreconditionFailed("If-Match: weak entity tags do not match")
+	}
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, apperror.Validation("If-Match: invalid entity tag")
+	}
+
+	version, err = strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version <= 0 {
+		return 0, apperror.Validation("If-Match: invalid entity tag")
+	}
+
+	return
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"net/http/httptest"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"strings"
+	"testing"
+)
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		value    string
+		want     int
+		wantKind apperror.Kind
+	}{
+		{value: `"3"`, want: 3},
+		{value: ` "3" `, want: 3},
+		{value: "*", want: 0},
+		{value: "", wantKind: apperror.KindPreconditionRequired},
+		{value: `W/"3"`, wantKind: apperror.KindPreconditionFailed},
+		{value: "3", wantKind: apperror.KindValidation},
+		{value: `"0"`, wantKind: apperror.KindValidation},
+		{value: `"three"`, wantKind: apperror.KindValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/", nil)
+			r.Header.Set("If-Match", tt.value)
+
+			got, err := ifMatch(r)
+			if tt.wantKind != apperror.KindInternal {
+				if apperror.KindOf(err) != tt.wantKind {
+					t.Fatalf("ifMatch() error = %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ifMatch() = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntityTags(t *testing.T) {
+	s, err := reservation.New(
+		reservation.WithCandidateRepository(memory.NewCandidateRepository()),
+		reservation.WithRecruiterRepository(memory.NewRecruiterRepository()),
+		reservation.WithUnitOfWork(memory.NewUnitOfWork()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := organization.ContextWithTenant(r.Context(), organization.DefaultID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+	r.Mount("/candidates", NewCandidateHandler(s).Routes())
+	r.Mount("/recruiters", NewRecruiterHandler(s).Routes())
+
+	body := `{"fullname":"Jane Doe","email":"jane@example.com","phone":4915112345678}`
+
+	for _, path := range []string{"/candidates", "/recruiters"} {
+		t.Run(path, func(t *testing.T) {
+			do := func(method, target, ifMatch string) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(method, target, strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				if ifMatch != "" {
+					req.Header.Set("If-Match", ifMatch)
+				}
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+				return w
+			}
+
+			w := do(http.MethodPost, path, "")
+			if w.Code != http.StatusCreated || w.Header().Get("ETag") != `"1"` {
+				t.Fatalf("POST = %d with ETag %s, want 201 with \"1\"", w.Code, w.Header().Get("ETag"))
+			}
+			created := struct {
+				Data struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}{}
+			if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+				t.Fatal(err)
+			}
+			target := path + "/" + created.Data.ID
+
+			tests := []struct {
+				name     string
+				method   string
+				ifMatch  string
+				wantCode int
+				wantETag string
+			}{
+				{name: "get", method: http.MethodGet, wantCode: http.StatusOK, wantETag: `"1"`},
+				{name: "update without If-Match", method: http.MethodPut, wantCode: http.StatusPreconditionRequired},
+				{name: "update of a newer version", method: http.MethodPut, ifMatch: `"2"`, wantCode: http.StatusPreconditionFailed},
+				{name: "update with a weak tag", method: http.MethodPut, ifMatch: `W/"1"`, wantCode: http.StatusPreconditionFailed},
+				{name: "update", method: http.MethodPut, ifMatch: `"1"`, wantCode: http.StatusOK, wantETag: `"2"`},
+				{name: "get after update", method: http.MethodGet, wantCode: http.StatusOK, wantETag: `"2"`},
+				{name: "update of a stale version", method: http.MethodPut, ifMatch: `"1"`, wantCode: http.StatusPreconditionFailed},
+				{name: "delete without If-Match", method: http.MethodDelete, wantCode: http.StatusPreconditionRequired},
+				{name: "delete of a stale version", method: http.MethodDelete, ifMatch: `"1"`, wantCode: http.StatusPreconditionFailed},
+				{name: "delete", method: http.MethodDelete, ifMatch: `"2"`, wantCode: http.StatusNoContent},
+				{name: "get after delete", method: http.MethodGet, wantCode: http.StatusNotFound},
+			}
+
+			for _, tt := range tests {
+				w := do(tt.method, target, tt.ifMatch)
+				if w.Code != tt.wantCode {
+					t.Fatalf("%s = %d, want %d: %s", tt.name, w.Code, tt.wantCode, w.Body.String())
+				}
+				if got := w.Header().Get("ETag"); got != tt.wantETag {
+					t.Errorf("%s ETag = %s, want %s", tt.name, got, tt.wantETag)
+				}
+			}
+		})
+	}
+}
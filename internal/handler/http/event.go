@@ -0,0 +1,118 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/organization"
+	eventService "reservation-system/internal/service/event"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+	"strconv"
+	"time"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+type EventHandler struct {
+	eventBus *eventService.Bus
+}
+
+func NewEventHandler(b *eventService.Bus) *EventHandler {
+	return &EventHandler{eventBus: b}
+}
+
+func (h *EventHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.stream)
+
+	return r
+}
+
+// @Summary	stream of entity changes as server-sent events
+// @Tags		events
+// @Produce	text/event-stream
+// @Param		entity			query		string	false	"comma separated entity types, e.g. candidate,recruiter"
+// @Param		type			query		string	false	"comma separated event types, e.g. candidate.create"
+// @Param		Last-Event-ID	header		string	false	"id of the last received event to resume from"
+// @Success	200				{object}	event.Event
+// @Failure	400				{object}	response.Problem
+// @Failure	500				{object}	response.Problem
+// @Router		/events [get]
+func (h *EventHandler) stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		response.Error(w, r, fmt.Errorf("streaming is not supported by %T", w))
+		return
+	}
+
+	filter := event.Filter{
+		TenantID:    organization.TenantFromContext(r.Context()),
+		EntityTypes: event.ParseList(r.URL.Query().Get("entity")),
+		Types:       event.ParseList(r.URL.Query().Get("type")),
+	}
+
+	var lastID uint64
+	if value := r.Header.Get("Last-Event-ID"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			response.Error(w, r, apperror.Validation("Last-Event-ID: must be an event id"))
+			return
+		}
+		lastID = id
+	}
+
+	subscription, replay := h.eventBus.Subscribe(filter, lastID)
+	defer h.eventBus.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, data := range replay {
+		if err := writeEvent(w, data); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.eventBus.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case data, ok := <-subscription.C:
+			// the subscriber fell behind, the client reconnects with Last-Event-ID
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, data event.Event) (err error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", data.ID, data.Type, body)
+
+	return
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/organization"
+	eventService "reservation-system/internal/service/event"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const otherTenantID = "00000000-0000-0000-0000-000000000002"
+
+// newEventServer serves the stream of the bus to the organization named by
+// the X-Organization-ID header of the request
+func newEventServer(t *testing.T, bus *eventService.Bus) *httptest.Server {
+	t.Helper()
+
+	routes := NewEventHandler(bus).Routes()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := organization.ContextWithTenant(r.Context(), r.Header.Get(OrganizationHeader))
+		routes.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// stream opens the stream and returns a function reading the id of the next event
+func stream(t *testing.T, server *httptest.Server, tenantID, query, lastID string) (int, func() uint64) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/?"+query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(OrganizationHeader, tenantID)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+
+	scanner := bufio.NewScanner(res.Body)
+	next := func() uint64 {
+		t.Helper()
+
+		for scanner.Scan() {
+			if value, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+				id, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return id
+			}
+		}
+		t.Fatalf("stream ended: %v", scanner.Err())
+		return 0
+	}
+
+	return res.StatusCode, next
+}
+
+func TestEventStreamReplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+		query    string
+		lastID   string
+		want     []uint64
+	}{
+		{name: "after the last event of the organization", tenantID: organization.DefaultID, lastID: "1", want: []uint64{3, 5}},
+		{name: "other organization", tenantID: otherTenantID, lastID: "1", want: []uint64{2, 4}},
+		{name: "entity type", tenantID: organization.DefaultID, query: "entity=candidate", lastID: "1", want: []uint64{5}},
+		{name: "event type", tenantID: otherTenantID, query: "type=candidate.create", lastID: "1", want: []uint64{2}},
+		{name: "unknown id replays the buffer", tenantID: organization.DefaultID, lastID: "99", want: []uint64{1, 3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus, err := eventService.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(bus.Close)
+
+			for _, data := range []event.Event{
+				{TenantID: organization.DefaultID, Type: "candidate.create", EntityType: "candidate"},
+				{TenantID: otherTenantID, Type: "candidate.create", EntityType: "candidate"},
+				{TenantID: organization.DefaultID, Type: "recruiter.create", EntityType: "recruiter"},
+				{TenantID: otherTenantID, Type: "candidate.update", EntityType: "candidate"},
+				{TenantID: organization.DefaultID, Type: "candidate.update", EntityType: "candidate"},
+			} {
+				bus.Publish(context.Background(), data)
+			}
+
+			status, next := stream(t, newEventServer(t, bus), tt.tenantID, tt.query, tt.lastID)
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, want 200", status)
+			}
+
+			// a live event matching every filter marks the end of the replay
+			live := bus.Publish(context.Background(), event.Event{TenantID: tt.tenantID, Type: "candidate.create", EntityType: "candidate"})
+
+			var got []uint64
+			for id := next(); id != live.ID; id = next() {
+				got = append(got, id)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("replayed %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("replayed %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestEventStreamLive(t *testing.T) {
+	bus, err := eventService.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bus.Close)
+	server := newEventServer(t, bus)
+
+	_, next := stream(t, server, organization.DefaultID, "", "")
+
+	// the subscription is registered before the response starts
+	bus.Publish(context.Background(), event.Event{TenantID: otherTenantID, Type: "candidate.create", EntityType: "candidate"})
+	want := bus.Publish(context.Background(), event.Event{TenantID: organization.DefaultID, Type: "candidate.create", EntityType: "candidate"})
+
+	if got := next(); got != want.ID {
+		t.Errorf("received event %d, want %d of the organization", got, want.ID)
+	}
+}
+
+func TestEventStreamInvalidLastEventID(t *testing.T) {
+	bus, err := eventService.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bus.Close)
+
+	status, _ := stream(t, newEventServer(t, bus), organization.DefaultID, "", "latest")
+	if status != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", status)
+	}
+}
@@ -0,0 +1,417 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/scheduling"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+	"time"
+)
+
+type InterviewHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewInterviewHandler(s *reservation.Service) *InterviewHandler {
+	return &InterviewHandler{reservationService: s}
+}
+
+func (h *InterviewHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+	r.Post("/batch", h.plan)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Post("/cancel", h.cancel)
+		r.Post("/respond", h.respond)
+		r.Get("/invite.ics", h.invite)
+		r.Get("/scorecards", h.listScorecards)
+		r.Post("/scorecards", h.submitScorecard)
+		r.Put("/scorecards/{scorecardId}", h.updateScorecard)
+	})
+
+	return r
+}
+
+// @Summary	list of interviews from the repository
+// @Tags		interviews
+// @Accept		json
+// @Produce	json
+// @Param		candidate	query		string	false	"candidate id"
+// @Param		recruiter	query		string	false	"recruiter id"
+// @Param		resource	query		string	false	"resource id"
+// @Param		from		query		string	false	"RFC 3339 time, interviews ending after it"
+// @Param		to			query		string	false	"RFC 3339 time, interviews starting before it"
+// @Param		status		query		string	false	"scheduled or cancelled"
+// @Success	200			{array}		interview.Response
+// @Failure	400			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
+// @Router		/interviews 	[get]
+func (h *InterviewHandler) list(w http.ResponseWriter, r *http.Request) {
+	filter := interview.Filter{
+		CandidateID: r.URL.Query().Get("candidate"),
+		RecruiterID: r.URL.Query().Get("recruiter"),
+		ResourceID:  r.URL.Query().Get("resource"),
+		Status:      r.URL.Query().Get("status"),
+	}
+
+	var err error
+	if filter.From, err = parseTime(r, "from"); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if filter.To, err = parseTime(r, "to"); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	res, err := h.reservationService.ListInterviews(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	schedule a new interview
+// @Tags		interviews
+// @Accept		json
+// @Produce	json
+// @Param		request	body		interview.Request	true	"body param"
+// @Success	201		{object}	interview.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	409		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/interviews [post]
+func (h *InterviewHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := interview.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.ScheduleInterview(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	assign candidates to recruiters and schedule their interviews
+// @Tags		interviews
+// @Accept		json
+// @Produce	json
+// @Param		request	body		scheduling.Request	true	"body param, dryRun previews the assignment"
+// @Success	200		{object}	scheduling.Response	"dry run"
+// @Success	201		{object}	scheduling.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	409		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/interviews/batch [post]
+func (h *InterviewHandler) plan(w http.ResponseWriter, r *http.Request) {
+	req := scheduling.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.PlanInterviews(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if req.DryRun || len(res.Interviews) == 0 {
+		response.OK(w, r, res)
+		return
+	}
+	response.Created(w, r, res)
+}
+
+// @Summary	get the interview from the repository
+// @Tags		interviews
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	interview.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/interviews/{id} [get]
+func (h *InterviewHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.GetInterview(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	reschedule the interview
+// @Tags		interviews
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string				true	"path param"
+// @Param		If-Match	header	string				true	"entity tag of the interview"
+// @Param		request		body	interview.Request	true	"body param"
+// @Success	200	{object}	interview.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	422	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/interviews/{id} [put]
+func (h *InterviewHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := interview.Request{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.RescheduleInterview(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	cancel the interview
+// @Tags		interviews
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string	true	"path param"
+// @Param		If-Match	header	string	true	"entity tag of the interview"
+// @Success	200	{object}	interview.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/interviews/{id}/cancel [post]
+func (h *InterviewHandler) cancel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	res, err := h.reservationService.CancelInterview(r.Context(), id, version)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	respond to the interview as its candidate or a recruiter of its panel
+// @Tags		interviews
+// @Accept		json
+// @Produce	json
+// @Param		id			path		string						true	"path param"
+// @Param		If-Match	header		string						false	"entity tag of the interview"
+// @Param		request		body		interview.ResponseRequest	true	"body param"
+// @Success	200			{object}	interview.Response
+// @Failure	400			{object}	response.Problem
+// @Failure	404			{object}	response.Problem
+// @Failure	409			{object}	response.Problem
+// @Failure	412			{object}	response.Problem
+// @Failure	422			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
+// @Router		/interviews/{id}/respond [post]
+func (h *InterviewHandler) respond(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	// participants respond independently of each other, so the entity tag is optional
+	version := 0
+	if r.Header.Get("If-Match") != "" {
+		var err error
+		if version, err = ifMatch(r); err != nil {
+			response.Error(w, r, err)
+			return
+		}
+	}
+
+	req := interview.ResponseRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.RespondToInterview(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	invitation to the interview as an iCalendar attachment, its cancellation once cancelled
+// @Tags		interviews
+// @Produce	text/calendar
+// @Param		id	path		string	true	"path param"
+// @Success	200	{string}	string
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/interviews/{id}/invite.ics [get]
+func (h *InterviewHandler) invite(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.InterviewInvite(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	writeCalendar(w, res, "interview-"+id+".ics")
+}
+
+// @Summary	scorecards submitted on the interview, recruiters of the panel read the others once they submitted theirs
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		scorecard.Response
+// @Failure	403	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/interviews/{id}/scorecards [get]
+func (h *InterviewHandler) listScorecards(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.ListScorecards(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	submit the scorecard of a recruiter of the panel on the interview
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		scorecard.Request	true	"body param"
+// @Success	201		{object}	scorecard.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	403		{object}	response.Problem
+// @Failure	404		{object}	response.Problem
+// @Failure	409		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/interviews/{id}/scorecards [post]
+func (h *InterviewHandler) submitScorecard(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := scorecard.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.SubmitScorecard(r.Context(), id, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	update the scorecard submitted on the interview
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string				true	"path param"
+// @Param		scorecardId	path	string				true	"path param"
+// @Param		If-Match	header	string				true	"entity tag of the scorecard"
+// @Param		request		body	scorecard.Request	true	"body param"
+// @Success	200	{object}	scorecard.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	403	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	422	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/interviews/{id}/scorecards/{scorecardId} [put]
+func (h *InterviewHandler) updateScorecard(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	scorecardID := chi.URLParam(r, "scorecardId")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := scorecard.Request{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.UpdateScorecard(r.Context(), id, scorecardID, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// parseTime reads an optional RFC 3339 time from the query parameter
+func parseTime(r *http.Request, name string) (value time.Time, err error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return
+	}
+
+	value, err = time.Parse(time.RFC3339, raw)
+	if err != nil {
+		err = apperror.Validation("%s: must be an RFC 3339 time", name)
+	}
+
+	return
+}
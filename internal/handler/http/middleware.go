@@ -0,0 +1,199 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"github.com/go-chi/chi/v5/middleware"
+	"io"
+	"net/http"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/service/idempotency"
+	organizationService "reservation-system/internal/service/organization"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+	"strings"
+)
+
+const (
+	// ActorHeader is the request header holding the name of the user performing
+	// the request, it is advisory and recorded alongside the key of the request
+	ActorHeader = "X-Actor"
+
+	// IdempotencyKeyHeader is the request header holding the client generated key of a POST request
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// AuthorizationHeader is the request header holding the Bearer key of the principal
+	AuthorizationHeader = "Authorization"
+
+	// OrganizationHeader is the request header naming the organization the
+	// admin key acts on behalf of
+	OrganizationHeader = "X-Organization-ID"
+)
+
+// Actor is a middleware that puts the actor named by the client into the
+// context of the request, it is advisory and never authenticates the request
+func Actor(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := audit.ContextWithActor(r.Context(), r.Header.Get(ActorHeader))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+
+	return http.HandlerFunc(fn)
+}
+
+// Tenant is a middleware that scopes the request to the organization of its
+// Bearer key. Requests without a key belong to the default organization unless
+// a key is required.
+// The admin key acts on behalf of the organization named by OrganizationHeader.
+func Tenant(s *organizationService.Service, required bool, adminKey string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			secret := bearer(r)
+			if secret == "" {
+				switch {
+				case required:
+					response.Error(w, r, apperror.Unauthorized("%s: Bearer organization key is required", AuthorizationHeader))
+				default:
+					ctx := organization.ContextWithTenant(r.Context(), organization.DefaultID)
+					next.ServeHTTP(w, r.WithContext(ctx))
+				}
+				return
+			}
+
+			if adminKey != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(adminKey)) == 1 {
+				id := r.Header.Get(OrganizationHeader)
+				if id == "" {
+					id = organization.DefaultID
+				}
+				if _, err := s.GetOrganization(r.Context(), id); err != nil {
+					response.Error(w, r, err)
+					return
+				}
+
+				ctx := organization.ContextWithTenant(r.Context(), id)
+				ctx = audit.ContextWithPrincipal(ctx, audit.PrincipalAdmin)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			key, err := s.Authenticate(r.Context(), secret)
+			if err != nil {
+				response.Error(w, r, err)
+				return
+			}
+
+			ctx := organization.ContextWithTenant(r.Context(), key.OrganizationID)
+			ctx = audit.ContextWithPrincipal(ctx, key.Principal())
+			if key.RecruiterID != nil {
+				ctx = audit.ContextWithRecruiter(ctx, *key.RecruiterID)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// Admin is a middleware that only lets in the requests bearing the admin key,
+// the tenant admin endpoints are disabled when no admin key is configured
+func Admin(key string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if key == "" {
+				response.Error(w, r, apperror.Forbidden("tenant admin endpoints are disabled"))
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(bearer(r)), []byte(key)) != 1 {
+				response.Error(w, r, apperror.Unauthorized("%s: invalid admin key", AuthorizationHeader))
+				return
+			}
+
+			ctx := audit.ContextWithPrincipal(r.Context(), audit.PrincipalAdmin)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// bearer returns the credentials of the Bearer Authorization header
+func bearer(r *http.Request) string {
+	value := r.Header.Get(AuthorizationHeader)
+	if len(value) < len("Bearer ") || !strings.EqualFold(value[:len("Bearer ")], "Bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(value[len("Bearer "):])
+}
+
+// Idempotency is a middleware that replays the stored response of a POST request
+// retried with the same Idempotency-Key and rejects the key reused with another body.
+// Keys are scoped by the organization and the authenticated principal of the
+// request, never by the advisory actor.
+func Idempotency(s *idempotency.Service) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key = organization.TenantFromContext(r.Context()) + ":" + audit.PrincipalFromContext(r.Context()) + ":" + key
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				response.Error(w, r, apperror.From(apperror.KindValidation, err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := idempotency.Fingerprint([]byte(r.Method), []byte(r.URL.Path), body)
+
+			stored, replay, err := s.Begin(r.Context(), key, fingerprint)
+			if err != nil {
+				response.Error(w, r, err)
+				return
+			}
+
+			if replay {
+				w.Header().Set("Content-Type", stored.ContentType)
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.StatusCode)
+				w.Write(stored.Body)
+				return
+			}
+
+			// the key is settled even when the client has gone away, otherwise
+			// it would stay in progress until it expires
+			ctx := context.WithoutCancel(r.Context())
+
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					s.Release(ctx, key)
+					panic(rvr)
+				}
+			}()
+
+			buf := &bytes.Buffer{}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(buf)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			// server errors are not stored so that the request can be retried
+			if status >= http.StatusInternalServerError {
+				s.Release(ctx, key)
+				return
+			}
+			s.Complete(ctx, key, status, ww.Header().Get("Content-Type"), buf.Bytes())
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
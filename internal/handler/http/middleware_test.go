@@ -0,0 +1,223 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reservation-system/internal/domain/audit"
+	domainIdempotency "reservation-system/internal/domain/idempotency"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/internal/service/idempotency"
+	"strings"
+	"testing"
+)
+
+func TestIdempotency(t *testing.T) {
+	type request struct {
+		method    string
+		path      string
+		key       string
+		principal string
+		tenant    string
+		body      string
+	}
+	post := func(key, body string) request {
+		return request{method: http.MethodPost, path: "/interviews", key: key, body: body}
+	}
+
+	tests := []struct {
+		name         string
+		requests     []request
+		wantStatuses []int
+		wantReplayed bool
+		wantCalls    int
+	}{
+		{
+			name:         "retry is replayed",
+			requests:     []request{post("a", `{"id":1}`), post("a", `{"id":1}`)},
+			wantStatuses: []int{http.StatusCreated, http.StatusCreated},
+			wantReplayed: true,
+			wantCalls:    1,
+		},
+		{
+			name:         "key reused with another body",
+			requests:     []request{post("a", `{"id":1}`), post("a", `{"id":2}`)},
+			wantStatuses: []int{http.StatusCreated, http.StatusUnprocessableEntity},
+			wantCalls:    1,
+		},
+		{
+			name:         "key reused on another path",
+			requests:     []request{post("a", `{"id":1}`), {method: http.MethodPost, path: "/candidates", key: "a", body: `{"id":1}`}},
+			wantStatuses: []int{http.StatusCreated, http.StatusUnprocessableEntity},
+			wantCalls:    1,
+		},
+		{
+			name:         "requests without a key",
+			requests:     []request{post("", `{"id":1}`), post("", `{"id":1}`)},
+			wantStatuses: []int{http.StatusCreated, http.StatusCreated},
+			wantCalls:    2,
+		},
+		{
+			name: "other methods are left alone",
+			requests: []request{
+				{method: http.MethodPut, path: "/interviews", key: "a", body: `{"id":1}`},
+				{method: http.MethodPut, path: "/interviews", key: "a", body: `{"id":1}`},
+			},
+			wantStatuses: []int{http.StatusCreated, http.StatusCreated},
+			wantCalls:    2,
+		},
+		{
+			name:         "server errors can be retried",
+			requests:     []request{{method: http.MethodPost, path: "/fail", key: "a"}, {method: http.MethodPost, path: "/fail", key: "a"}},
+			wantStatuses: []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			wantCalls:    2,
+		},
+		{
+			name: "keys are scoped by the principal",
+			requests: []request{
+				{method: http.MethodPost, path: "/interviews", key: "a", principal: "first", body: `{"id":1}`},
+				{method: http.MethodPost, path: "/interviews", key: "a", principal: "second", body: `{"id":2}`},
+			},
+			wantStatuses: []int{http.StatusCreated, http.StatusCreated},
+			wantCalls:    2,
+		},
+		{
+			name: "keys are scoped by the organization",
+			requests: []request{
+				{method: http.MethodPost, path: "/interviews", key: "a", tenant: "first", body: `{"id":1}`},
+				{method: http.MethodPost, path: "/interviews", key: "a", tenant: "second", body: `{"id":2}`},
+			},
+			wantStatuses: []int{http.StatusCreated, http.StatusCreated},
+			wantCalls:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := idempotency.New(idempotency.WithIdempotencyRepository(memory.NewIdempotencyRepository()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			calls := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if r.URL.Path == "/fail" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(w, `{"call":%d}`, calls)
+			})
+			handler := Idempotency(s)(next)
+
+			var first string
+			for i, req := range tt.requests {
+				r := httptest.NewRequest(req.method, req.path, strings.NewReader(req.body))
+				if req.key != "" {
+					r.Header.Set(IdempotencyKeyHeader, req.key)
+				}
+				ctx := organization.ContextWithTenant(r.Context(), organization.DefaultID+req.tenant)
+				ctx = audit.ContextWithPrincipal(ctx, req.principal)
+				w := httptest.NewRecorder()
+
+				handler.ServeHTTP(w, r.WithContext(ctx))
+
+				if w.Code != tt.wantStatuses[i] {
+					t.Fatalf("request %d status = %d, want %d", i, w.Code, tt.wantStatuses[i])
+				}
+				if i == 0 {
+					first = w.Body.String()
+					continue
+				}
+
+				replayed := w.Header().Get("Idempotent-Replayed") == "true"
+				if replayed != tt.wantReplayed {
+					t.Errorf("request %d replayed = %v, want %v", i, replayed, tt.wantReplayed)
+				}
+				if replayed && (w.Body.String() != first || w.Header().Get("Content-Type") != "application/json") {
+					t.Errorf("request %d replayed %q, want %q", i, w.Body.String(), first)
+				}
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("handler called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// cancelRepository fails the writes under a cancelled context like the
+// database drivers do
+type cancelRepository struct {
+	*memory.IdempotencyRepository
+}
+
+func (r cancelRepository) Update(ctx context.Context, key string, data domainIdempotency.Entity) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.IdempotencyRepository.Update(ctx, key, data)
+}
+
+func (r cancelRepository) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.IdempotencyRepository.Delete(ctx, key)
+}
+
+func TestIdempotencyClientGone(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantStatus   int
+		wantReplayed bool
+	}{
+		{name: "completed request is replayed", status: http.StatusCreated, wantStatus: http.StatusCreated, wantReplayed: true},
+		{name: "failed request is released", status: http.StatusBadGateway, wantStatus: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := idempotency.New(idempotency.WithIdempotencyRepository(cancelRepository{memory.NewIdempotencyRepository()}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var cancel context.CancelFunc
+			handler := Idempotency(s)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// the client disconnects while the request is handled
+				if cancel != nil {
+					cancel()
+				}
+				w.WriteHeader(tt.status)
+			}))
+
+			serve := func(ctx context.Context) *httptest.ResponseRecorder {
+				r := httptest.NewRequest(http.MethodPost, "/interviews", strings.NewReader(`{"id":1}`))
+				r.Header.Set(IdempotencyKeyHeader, "a")
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, r.WithContext(ctx))
+				return w
+			}
+
+			ctx := organization.ContextWithTenant(context.Background(), organization.DefaultID)
+			cancelled, cancelFunc := context.WithCancel(ctx)
+			cancel = cancelFunc
+			serve(cancelled)
+			cancel = nil
+
+			w := serve(ctx)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("retry status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if replayed := w.Header().Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplayed {
+				t.Errorf("retry replayed = %v, want %v", replayed, tt.wantReplayed)
+			}
+		})
+	}
+}
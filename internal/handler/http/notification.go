@@ -0,0 +1,147 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/notification"
+	notificationService "reservation-system/internal/service/notification"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type NotificationHandler struct {
+	notificationService *notificationService.Service
+}
+
+func NewNotificationHandler(s *notificationService.Service) *NotificationHandler {
+	return &NotificationHandler{notificationService: s}
+}
+
+func (h *NotificationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/{id}/retry", h.retry)
+
+	r.Route("/opt-outs", func(r chi.Router) {
+		r.Get("/", h.listOptOuts)
+		r.Post("/", h.addOptOut)
+		r.Delete("/{channel}/{recipient}", h.deleteOptOut)
+	})
+
+	return r
+}
+
+// @Summary	send log of the notifications
+// @Tags		notifications
+// @Accept		json
+// @Produce	json
+// @Param		channel		query		string	false	"channel, e.g. email"
+// @Param		recipient	query		string	false	"recipient address"
+// @Param		status		query		string	false	"pending, sent or dead"
+// @Success	200			{array}		notification.Response
+// @Failure	500			{object}	response.Problem
+// @Router		/notifications 	[get]
+func (h *NotificationHandler) list(w http.ResponseWriter, r *http.Request) {
+	filter := notification.Filter{
+		Channel:   r.URL.Query().Get("channel"),
+		Recipient: r.URL.Query().Get("recipient"),
+		Status:    r.URL.Query().Get("status"),
+	}
+
+	res, err := h.notificationService.ListNotifications(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	queue the notification again, e.g. after it went dead
+// @Tags		notifications
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	notification.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/notifications/{id}/retry [post]
+func (h *NotificationHandler) retry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.notificationService.RetryNotification(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	list of recipients who opted out of notifications
+// @Tags		notifications
+// @Accept		json
+// @Produce	json
+// @Param		channel	query		string	false	"channel, e.g. sms"
+// @Success	200		{array}		notification.OptOutResponse
+// @Failure	500		{object}	response.Problem
+// @Router		/notifications/opt-outs [get]
+func (h *NotificationHandler) listOptOuts(w http.ResponseWriter, r *http.Request) {
+	res, err := h.notificationService.ListOptOuts(r.Context(), r.URL.Query().Get("channel"))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	opt the recipient out of the notifications of the channel
+// @Tags		notifications
+// @Accept		json
+// @Produce	json
+// @Param		request	body		notification.OptOutRequest	true	"body param"
+// @Success	201		{object}	notification.OptOutResponse
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/notifications/opt-outs [post]
+func (h *NotificationHandler) addOptOut(w http.ResponseWriter, r *http.Request) {
+	req := notification.OptOutRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.notificationService.AddOptOut(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.Created(w, r, res)
+}
+
+// @Summary	opt the recipient back in to the notifications of the channel
+// @Tags		notifications
+// @Accept		json
+// @Produce	json
+// @Param		channel		path	string	true	"path param"
+// @Param		recipient	path	string	true	"path param"
+// @Success	204
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/notifications/opt-outs/{channel}/{recipient} [delete]
+func (h *NotificationHandler) deleteOptOut(w http.ResponseWriter, r *http.Request) {
+	channel := chi.URLParam(r, "channel")
+	recipient := chi.URLParam(r, "recipient")
+
+	if err := h.notificationService.DeleteOptOut(r.Context(), channel, recipient); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
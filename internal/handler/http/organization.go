@@ -0,0 +1,219 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/organization"
+	organizationService "reservation-system/internal/service/organization"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type OrganizationHandler struct {
+	organizationService *organizationService.Service
+}
+
+func NewOrganizationHandler(s *organizationService.Service) *OrganizationHandler {
+	return &OrganizationHandler{organizationService: s}
+}
+
+func (h *OrganizationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Get("/keys", h.listKeys)
+		r.Post("/keys", h.issueKey)
+		r.Delete("/keys/{keyID}", h.revokeKey)
+	})
+
+	return r
+}
+
+// @Summary	list of organizations
+// @Tags		organizations
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}		organization.Response
+// @Failure	401	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/organizations 	[get]
+func (h *OrganizationHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.organizationService.ListOrganizations(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new organization
+// @Tags		organizations
+// @Accept		json
+// @Produce	json
+// @Param		request	body		organization.Request	true	"body param"
+// @Success	201		{object}	organization.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	401		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/organizations [post]
+func (h *OrganizationHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := organization.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.organizationService.AddOrganization(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	get the organization
+// @Tags		organizations
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	organization.Response
+// @Failure	401	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/organizations/{id} [get]
+func (h *OrganizationHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.organizationService.GetOrganization(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	rename or suspend the organization
+// @Tags		organizations
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string					true	"path param"
+// @Param		If-Match	header	string					true	"entity tag of the organization"
+// @Param		request		body	organization.Request	true	"body param"
+// @Success	200	{object}	organization.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	401	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/organizations/{id} [put]
+func (h *OrganizationHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := organization.Request{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.organizationService.UpdateOrganization(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	list of the keys of the organization, without their secrets
+// @Tags		organizations
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		organization.KeyResponse
+// @Failure	401	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/organizations/{id}/keys [get]
+func (h *OrganizationHandler) listKeys(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.organizationService.ListKeys(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	issue a new key of the organization, the secret is only returned once
+// @Tags		organizations
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string					true	"path param"
+// @Param		request	body		organization.KeyRequest	true	"body param"
+// @Success	201		{object}	organization.KeyResponse
+// @Failure	400		{object}	response.Problem
+// @Failure	401		{object}	response.Problem
+// @Failure	404		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/organizations/{id}/keys [post]
+func (h *OrganizationHandler) issueKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := organization.KeyRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.organizationService.IssueKey(r.Context(), id, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.Created(w, r, res)
+}
+
+// @Summary	revoke the key of the organization
+// @Tags		organizations
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string	true	"path param"
+// @Param		keyID	path	string	true	"path param"
+// @Success	204
+// @Failure	401	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/organizations/{id}/keys/{keyID} [delete]
+func (h *OrganizationHandler) revokeKey(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	keyID := chi.URLParam(r, "keyID")
+
+	if err := h.organizationService.RevokeKey(r.Context(), id, keyID); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
@@ -1,14 +1,15 @@
 package http
 
 import (
-	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"net/http"
+	"reservation-system/internal/domain/calendar"
 	"reservation-system/internal/domain/recruiter"
 	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
 	"reservation-system/pkg/server/response"
-	"reservation-system/pkg/store"
+	"strconv"
 )
 
 type RecruiterHandler struct {
@@ -29,6 +30,14 @@ func (h *RecruiterHandler) Routes() chi.Router {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
+		r.Post("/restore", h.restore)
+		r.Post("/calendar-token", h.issueCalendarToken)
+
+		r.Get("/busy", h.listBusy)
+		r.Post("/busy", h.importBusy)
+		r.Get("/busy/feed", h.getBusyFeed)
+		r.Put("/busy/feed", h.setBusyFeed)
+		r.Delete("/busy/feed", h.deleteBusyFeed)
 	})
 
 	return r
@@ -38,13 +47,30 @@ func (h *RecruiterHandler) Routes() chi.Router {
 // @Tags		recruiters
 // @Accept		json
 // @Produce	json
+// @Param		include_deleted	query		bool	false	"include deleted recruiters, admin key only"
+// @Param		team			query		string	false	"team of the recruiters"
+// @Param		interview_type	query		string	false	"interview type the recruiters conduct"
 // @Success	200			{array}		recruiter.Response
-// @Failure	500			{object}	response.Object
+// @Failure	400			{object}	response.Problem
+// @Failure	403			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
 // @Router		/recruiters 	[get]
 func (h *RecruiterHandler) list(w http.ResponseWriter, r *http.Request) {
-	res, err := h.reservationService.ListRecruiters(r.Context())
+	filter := recruiter.Filter{}
+	if value := r.URL.Query().Get("include_deleted"); value != "" {
+		includeDeleted, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Error(w, r, apperror.Validation("include_deleted: must be a boolean"))
+			return
+		}
+		filter.IncludeDeleted = includeDeleted
+	}
+	filter.Team = r.URL.Query().Get("team")
+	filter.InterviewType = r.URL.Query().Get("interview_type")
+
+	res, err := h.reservationService.ListRecruiters(r.Context(), filter)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		response.Error(w, r, err)
 		return
 	}
 
@@ -56,24 +82,25 @@ func (h *RecruiterHandler) list(w http.ResponseWriter, r *http.Request) {
 // @Accept		json
 // @Produce	json
 // @Param		request	body		recruiter.Request	true	"body param"
-// @Success	200		{object}	recruiter.Response
-// @Failure	400		{object}	response.Object
-// @Failure	500		{object}	response.Object
+// @Success	201		{object}	recruiter.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
 // @Router		/recruiters [post]
 func (h *RecruiterHandler) add(w http.ResponseWriter, r *http.Request) {
 	req := recruiter.Request{}
 	if err := render.Bind(r, &req); err != nil {
-		response.BadRequest(w, r, err, req)
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
 		return
 	}
 
 	res, err := h.reservationService.AddRecruiter(r.Context(), req)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		response.Error(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", etag(res.Version))
 
-	response.OK(w, r, res)
+	response.Created(w, r, res)
 }
 
 // @Summary	get the recruiter from the repository
@@ -82,22 +109,18 @@ func (h *RecruiterHandler) add(w http.ResponseWriter, r *http.Request) {
 // @Produce	json
 // @Param		id	path		int	true	"path param"
 // @Success	200	{object}	recruiter.Response
-// @Failure	404	{object}	response.Object
-// @Failure	500	{object}	response.Object
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
 // @Router		/recruiters/{id} [get]
 func (h *RecruiterHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
 	res, err := h.reservationService.GetRecruiter(r.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, store.ErrorNotFound):
-			response.NotFound(w, r, err)
-		default:
-			response.InternalServerError(w, r, err)
-		}
+		response.Error(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", etag(res.Version))
 
 	response.OK(w, r, res)
 }
@@ -106,52 +129,132 @@ func (h *RecruiterHandler) get(w http.ResponseWriter, r *http.Request) {
 // @Tags		recruiters
 // @Accept		json
 // @Produce	json
-// @Param		id		path	int				true	"path param"
-// @Param		request	body	recruiter.Request	true	"body param"
-// @Success	200
-// @Failure	400	{object}	response.Object
-// @Failure	404	{object}	response.Object
-// @Failure	500	{object}	response.Object
+// @Param		id			path	int				true	"path param"
+// @Param		If-Match	header	string			true	"entity tag of the recruiter"
+// @Param		request		body	recruiter.Request	true	"body param"
+// @Success	200	{object}	recruiter.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
 // @Router		/recruiters/{id} [put]
 func (h *RecruiterHandler) update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
 	req := recruiter.Request{}
-	if err := render.Bind(r, &req); err != nil {
-		response.BadRequest(w, r, err, req)
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
 		return
 	}
 
-	if err := h.reservationService.UpdateRecruiter(r.Context(), id, req); err != nil {
-		switch {
-		case errors.Is(err, store.ErrorNotFound):
-			response.NotFound(w, r, err)
-		default:
-			response.InternalServerError(w, r, err)
-		}
+	res, err := h.reservationService.UpdateRecruiter(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
 }
 
 // @Summary	delete the recruiter from the repository
 // @Tags		recruiters
 // @Accept		json
 // @Produce	json
-// @Param		id	path	int	true	"path param"
-// @Success	200
-// @Failure	404	{object}	response.Object
-// @Failure	500	{object}	response.Object
+// @Param		id			path	int		true	"path param"
+// @Param		If-Match	header	string	true	"entity tag of the recruiter"
+// @Success	204
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
 // @Router		/recruiters/{id} [delete]
 func (h *RecruiterHandler) delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	if err := h.reservationService.DeleteRecruiter(r.Context(), id); err != nil {
-		switch {
-		case errors.Is(err, store.ErrorNotFound):
-			response.NotFound(w, r, err)
-		default:
-			response.InternalServerError(w, r, err)
-		}
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err = h.reservationService.DeleteRecruiter(r.Context(), id, version); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
+
+// @Summary	restore the deleted recruiter in the repository
+// @Tags		recruiters
+// @Accept		json
+// @Produce	json
+// @Param		id	path		int	true	"path param"
+// @Success	200	{object}	recruiter.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/recruiters/{id}/restore [post]
+func (h *RecruiterHandler) restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.RestoreRecruiter(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	calendar feed of the interviews of the recruiter
+// @Tags		recruiters
+// @Produce	text/calendar
+// @Param		id		path		string	true	"path param"
+// @Param		token	query		string	true	"secret feed token"
+// @Success	200		{string}	string
+// @Failure	403		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/recruiters/{id}/calendar.ics [get]
+func (h *RecruiterHandler) Calendar(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.CalendarFeed(r.Context(), calendar.OwnerRecruiter, id, r.URL.Query().Get("token"))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	writeCalendar(w, res, "")
+}
+
+// @Summary	issue a new calendar feed token for the recruiter, the previous one stops working
+// @Tags		recruiters
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	201	{object}	calendar.FeedTokenResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/recruiters/{id}/calendar-token [post]
+func (h *RecruiterHandler) issueCalendarToken(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.IssueFeedToken(r.Context(), calendar.OwnerRecruiter, id)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
+	res.URL = baseURL(r) + res.URL
+
+	response.Created(w, r, res)
 }
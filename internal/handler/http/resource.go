@@ -0,0 +1,191 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+	"strconv"
+	"strings"
+)
+
+type ResourceHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewResourceHandler(s *reservation.Service) *ResourceHandler {
+	return &ResourceHandler{reservationService: s}
+}
+
+func (h *ResourceHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// @Summary	list of rooms and equipment from the repository
+// @Tags		resources
+// @Accept		json
+// @Produce	json
+// @Param		kind		query		string	false	"room or equipment"
+// @Param		location	query		string	false	"location of the resources"
+// @Param		equipment	query		string	false	"comma separated equipment tags the resources all have"
+// @Param		capacity	query		int		false	"least number of people a room seats"
+// @Success	200			{array}		resource.Response
+// @Failure	400			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
+// @Router		/resources 	[get]
+func (h *ResourceHandler) list(w http.ResponseWriter, r *http.Request) {
+	filter := resource.Filter{
+		Kind:     r.URL.Query().Get("kind"),
+		Location: r.URL.Query().Get("location"),
+	}
+	if equipment := r.URL.Query().Get("equipment"); equipment != "" {
+		filter.Equipment = strings.Split(equipment, ",")
+	}
+	if capacity := r.URL.Query().Get("capacity"); capacity != "" {
+		var err error
+		if filter.MinCapacity, err = strconv.Atoi(capacity); err != nil {
+			response.Error(w, r, apperror.Validation("capacity: must be a number"))
+			return
+		}
+	}
+
+	res, err := h.reservationService.ListResources(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new room or piece of equipment to the repository
+// @Tags		resources
+// @Accept		json
+// @Produce	json
+// @Param		request	body		resource.Request	true	"body param"
+// @Success	201		{object}	resource.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/resources [post]
+func (h *ResourceHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := resource.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.AddResource(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	get the resource from the repository
+// @Tags		resources
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	resource.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/resources/{id} [get]
+func (h *ResourceHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.GetResource(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the resource in the repository
+// @Tags		resources
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string				true	"path param"
+// @Param		If-Match	header	string				true	"entity tag of the resource"
+// @Param		request		body	resource.Request	true	"body param"
+// @Success	200	{object}	resource.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/resources/{id} [put]
+func (h *ResourceHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := resource.Request{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.UpdateResource(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	delete the resource from the repository
+// @Tags		resources
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string	true	"path param"
+// @Param		If-Match	header	string	true	"entity tag of the resource"
+// @Success	204
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/resources/{id} [delete]
+func (h *ResourceHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err = h.reservationService.DeleteResource(r.Context(), id, version); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
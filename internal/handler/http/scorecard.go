@@ -0,0 +1,169 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type ScorecardTemplateHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewScorecardTemplateHandler(s *reservation.Service) *ScorecardTemplateHandler {
+	return &ScorecardTemplateHandler{reservationService: s}
+}
+
+func (h *ScorecardTemplateHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// @Summary	list of scorecard templates from the repository
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}		scorecard.TemplateResponse
+// @Failure	500	{object}	response.Problem
+// @Router		/scorecard-templates [get]
+func (h *ScorecardTemplateHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.reservationService.ListScorecardTemplates(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new scorecard template to the repository
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		request	body		scorecard.TemplateRequest	true	"body param"
+// @Success	201		{object}	scorecard.TemplateResponse
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/scorecard-templates [post]
+func (h *ScorecardTemplateHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := scorecard.TemplateRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.AddScorecardTemplate(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	get the scorecard template from the repository
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	scorecard.TemplateResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/scorecard-templates/{id} [get]
+func (h *ScorecardTemplateHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.GetScorecardTemplate(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the scorecard template in the repository
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string						true	"path param"
+// @Param		If-Match	header	string						true	"entity tag of the scorecard template"
+// @Param		request		body	scorecard.TemplateRequest	true	"body param"
+// @Success	200	{object}	scorecard.TemplateResponse
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/scorecard-templates/{id} [put]
+func (h *ScorecardTemplateHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := scorecard.TemplateRequest{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.UpdateScorecardTemplate(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	delete the scorecard template from the repository
+// @Tags		scorecards
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string	true	"path param"
+// @Param		If-Match	header	string	true	"entity tag of the scorecard template"
+// @Success	204
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/scorecard-templates/{id} [delete]
+func (h *ScorecardTemplateHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err = h.reservationService.DeleteScorecardTemplate(r.Context(), id, version); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
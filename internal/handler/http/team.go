@@ -0,0 +1,274 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/team"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type TeamHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewTeamHandler(s *reservation.Service) *TeamHandler {
+	return &TeamHandler{reservationService: s}
+}
+
+func (h *TeamHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+
+		r.Get("/members", h.listMembers)
+		r.Put("/members/{recruiterID}", h.addMember)
+		r.Delete("/members/{recruiterID}", h.removeMember)
+
+		r.Post("/interviews", h.book)
+	})
+
+	return r
+}
+
+// @Summary	list of hiring teams from the repository
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}		team.Response
+// @Failure	500	{object}	response.Problem
+// @Router		/teams [get]
+func (h *TeamHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.reservationService.ListTeams(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new hiring team to the repository
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		request	body		team.Request	true	"body param, strategy is round_robin or least_loaded"
+// @Success	201		{object}	team.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/teams [post]
+func (h *TeamHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := team.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.AddTeam(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	get the hiring team from the repository
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	team.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/teams/{id} [get]
+func (h *TeamHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.GetTeam(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the hiring team in the repository
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string			true	"path param"
+// @Param		If-Match	header	string			true	"entity tag of the team"
+// @Param		request		body	team.Request	true	"body param"
+// @Success	200	{object}	team.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/teams/{id} [put]
+func (h *TeamHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := team.Request{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.UpdateTeam(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	delete the hiring team from the repository
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string	true	"path param"
+// @Param		If-Match	header	string	true	"entity tag of the team"
+// @Success	204
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/teams/{id} [delete]
+func (h *TeamHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err = h.reservationService.DeleteTeam(r.Context(), id, version); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
+
+// @Summary	list of the members of the hiring team in the order of their turns
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		team.MemberResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/teams/{id}/members [get]
+func (h *TeamHandler) listMembers(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.ListTeamMembers(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add the recruiter to the hiring team
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		id			path		string	true	"path param"
+// @Param		recruiterID	path		string	true	"path param"
+// @Success	200			{array}		team.MemberResponse
+// @Failure	404			{object}	response.Problem
+// @Failure	409			{object}	response.Problem
+// @Failure	422			{object}	response.Problem
+// @Failure	500			{object}	response.Problem
+// @Router		/teams/{id}/members/{recruiterID} [put]
+func (h *TeamHandler) addMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	recruiterID := chi.URLParam(r, "recruiterID")
+
+	res, err := h.reservationService.AddTeamMember(r.Context(), id, recruiterID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	remove the recruiter from the hiring team
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string	true	"path param"
+// @Param		recruiterID	path	string	true	"path param"
+// @Success	204
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/teams/{id}/members/{recruiterID} [delete]
+func (h *TeamHandler) removeMember(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	recruiterID := chi.URLParam(r, "recruiterID")
+
+	if err := h.reservationService.RemoveTeamMember(r.Context(), id, recruiterID); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
+
+// @Summary	schedule an interview with any available recruiter of the hiring team
+// @Tags		teams
+// @Accept		json
+// @Produce	json
+// @Param		id		path		string				true	"path param"
+// @Param		request	body		team.BookingRequest	true	"body param, strategy overrides the one of the team"
+// @Success	201		{object}	interview.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	404		{object}	response.Problem
+// @Failure	409		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/teams/{id}/interviews [post]
+func (h *TeamHandler) book(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := team.BookingRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.BookTeamInterview(r.Context(), id, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
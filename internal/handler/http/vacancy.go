@@ -0,0 +1,181 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/internal/service/reservation"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type VacancyHandler struct {
+	reservationService *reservation.Service
+}
+
+func NewVacancyHandler(s *reservation.Service) *VacancyHandler {
+	return &VacancyHandler{reservationService: s}
+}
+
+func (h *VacancyHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// @Summary	list of vacancies from the repository
+// @Tags		vacancies
+// @Accept		json
+// @Produce	json
+// @Param		status		query		string	false	"open, on_hold or closed"
+// @Param		department	query		string	false	"department of the vacancies"
+// @Param		owner		query		string	false	"recruiter id of the owner"
+// @Success	200			{array}		vacancy.Response
+// @Failure	500			{object}	response.Problem
+// @Router		/vacancies 	[get]
+func (h *VacancyHandler) list(w http.ResponseWriter, r *http.Request) {
+	filter := vacancy.Filter{
+		Status:     r.URL.Query().Get("status"),
+		Department: r.URL.Query().Get("department"),
+		OwnerID:    r.URL.Query().Get("owner"),
+	}
+
+	res, err := h.reservationService.ListVacancies(r.Context(), filter)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new vacancy to the repository
+// @Tags		vacancies
+// @Accept		json
+// @Produce	json
+// @Param		request	body		vacancy.Request	true	"body param"
+// @Success	201		{object}	vacancy.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	422		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/vacancies [post]
+func (h *VacancyHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := vacancy.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.AddVacancy(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.Created(w, r, res)
+}
+
+// @Summary	get the vacancy from the repository
+// @Tags		vacancies
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	vacancy.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/vacancies/{id} [get]
+func (h *VacancyHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.reservationService.GetVacancy(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the vacancy in the repository
+// @Tags		vacancies
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string			true	"path param"
+// @Param		If-Match	header	string			true	"entity tag of the vacancy"
+// @Param		request		body	vacancy.Request	true	"body param"
+// @Success	200	{object}	vacancy.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	422	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/vacancies/{id} [put]
+func (h *VacancyHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	req := vacancy.Request{}
+	if err = render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.reservationService.UpdateVacancy(r.Context(), id, version, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	w.Header().Set("ETag", etag(res.Version))
+
+	response.OK(w, r, res)
+}
+
+// @Summary	delete the vacancy from the repository
+// @Tags		vacancies
+// @Accept		json
+// @Produce	json
+// @Param		id			path	string	true	"path param"
+// @Param		If-Match	header	string	true	"entity tag of the vacancy"
+// @Success	204
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	412	{object}	response.Problem
+// @Failure	428	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/vacancies/{id} [delete]
+func (h *VacancyHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatch(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err = h.reservationService.DeleteVacancy(r.Context(), id, version); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
@@ -0,0 +1,193 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"reservation-system/internal/domain/webhook"
+	webhookService "reservation-system/internal/service/webhook"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/server/response"
+)
+
+type WebhookHandler struct {
+	webhookService *webhookService.Service
+}
+
+func NewWebhookHandler(s *webhookService.Service) *WebhookHandler {
+	return &WebhookHandler{webhookService: s}
+}
+
+func (h *WebhookHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+		r.Get("/deliveries", h.listDeliveries)
+		r.Post("/deliveries/{deliveryID}/retry", h.retryDelivery)
+	})
+
+	return r
+}
+
+// @Summary	list of webhook subscriptions
+// @Tags		webhooks
+// @Accept		json
+// @Produce	json
+// @Success	200	{array}		webhook.Response
+// @Failure	500	{object}	response.Problem
+// @Router		/webhooks 	[get]
+func (h *WebhookHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	add a new webhook subscription, the secret is only returned once
+// @Tags		webhooks
+// @Accept		json
+// @Produce	json
+// @Param		request	body		webhook.Request	true	"body param"
+// @Success	201		{object}	webhook.Response
+// @Failure	400		{object}	response.Problem
+// @Failure	500		{object}	response.Problem
+// @Router		/webhooks [post]
+func (h *WebhookHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := webhook.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.webhookService.AddSubscription(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.Created(w, r, res)
+}
+
+// @Summary	get the webhook subscription
+// @Tags		webhooks
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{object}	webhook.Response
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/webhooks/{id} [get]
+func (h *WebhookHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.webhookService.GetSubscription(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	update the webhook subscription
+// @Tags		webhooks
+// @Accept		json
+// @Produce	json
+// @Param		id		path	string			true	"path param"
+// @Param		request	body	webhook.Request	true	"body param"
+// @Success	200	{object}	webhook.Response
+// @Failure	400	{object}	response.Problem
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/webhooks/{id} [put]
+func (h *WebhookHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := webhook.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.Error(w, r, apperror.From(apperror.KindValidation, err))
+		return
+	}
+
+	res, err := h.webhookService.UpdateSubscription(r.Context(), id, req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	delete the webhook subscription with its deliveries
+// @Tags		webhooks
+// @Accept		json
+// @Produce	json
+// @Param		id	path	string	true	"path param"
+// @Success	204
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/webhooks/{id} [delete]
+func (h *WebhookHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), id); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
+
+// @Summary	delivery log of the webhook subscription
+// @Tags		webhooks
+// @Accept		json
+// @Produce	json
+// @Param		id	path		string	true	"path param"
+// @Success	200	{array}		webhook.DeliveryResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.webhookService.ListDeliveries(r.Context(), id)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// @Summary	queue the delivery again, e.g. after it went dead
+// @Tags		webhooks
+// @Accept		json
+// @Produce	json
+// @Param		id			path		string	true	"path param"
+// @Param		deliveryID	path		string	true	"path param"
+// @Success	200	{object}	webhook.DeliveryResponse
+// @Failure	404	{object}	response.Problem
+// @Failure	409	{object}	response.Problem
+// @Failure	500	{object}	response.Problem
+// @Router		/webhooks/{id}/deliveries/{deliveryID}/retry [post]
+func (h *WebhookHandler) retryDelivery(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	deliveryID := chi.URLParam(r, "deliveryID")
+
+	res, err := h.webhookService.RetryDelivery(r.Context(), id, deliveryID)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
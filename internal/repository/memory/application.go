@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/application"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+)
+
+type ApplicationRepository struct {
+	db map[string]application.Entity
+	sync.RWMutex
+}
+
+func NewApplicationRepository() *ApplicationRepository {
+	return &ApplicationRepository{
+		db: make(map[string]application.Entity),
+	}
+}
+
+func (r *ApplicationRepository) List(ctx context.Context, filter application.Filter) (dest []application.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]application.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && filter.Match(data) {
+			dest = append(dest, cloneApplication(data))
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		if !dest[i].AppliedAt.Equal(*dest[j].AppliedAt) {
+			return dest[i].AppliedAt.Before(*dest[j].AppliedAt)
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	return
+}
+
+func (r *ApplicationRepository) Add(ctx context.Context, data application.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	data = cloneApplication(data)
+	for i := range data.History {
+		data.History[i].ApplicationID = data.ID
+	}
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *ApplicationRepository) Get(ctx context.Context, id string) (dest application.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+	dest = cloneApplication(dest)
+
+	return
+}
+
+func (r *ApplicationRepository) Move(ctx context.Context, id string, version int, data application.Transition) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	if version != 0 && version != current.Version {
+		return store.ErrorConflict
+	}
+	current = cloneApplication(current)
+	data.ApplicationID = id
+	current.Stage = &data.Stage
+	current.Status = &data.Status
+	current.History = append(current.History, data)
+	current.Version++
+	r.db[id] = current
+
+	return
+}
+
+func cloneApplication(data application.Entity) application.Entity {
+	if data.History != nil {
+		data.History = append([]application.Transition{}, data.History...)
+	}
+	return data
+}
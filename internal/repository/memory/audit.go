@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/organization"
+	"sync"
+	"time"
+)
+
+type AuditRepository struct {
+	db []audit.Entity
+	sync.RWMutex
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		db: make([]audit.Entity, 0),
+	}
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter audit.Filter) (dest []audit.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]audit.Entity, 0)
+	for _, data := range r.db {
+		if !visible(ctx, data.TenantID) {
+			continue
+		}
+		if filter.EntityType != "" && data.EntityType != filter.EntityType {
+			continue
+		}
+		if filter.EntityID != "" && data.EntityID != filter.EntityID {
+			continue
+		}
+		dest = append(dest, data)
+	}
+
+	return
+}
+
+func (r *AuditRepository) Add(ctx context.Context, data audit.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now().UTC()
+	}
+	r.db = append(r.db, data)
+
+	return data.ID, nil
+}
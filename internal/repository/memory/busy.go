@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+	"time"
+)
+
+type BusyRepository struct {
+	db map[string]busy.Block
+	sync.RWMutex
+}
+
+func NewBusyRepository() *BusyRepository {
+	return &BusyRepository{
+		db: make(map[string]busy.Block),
+	}
+}
+
+func (r *BusyRepository) List(ctx context.Context, filter busy.Filter) (dest []busy.Block, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]busy.Block, 0, len(r.db))
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && filter.Match(data) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].StartsAt.Before(dest[j].StartsAt)
+	})
+
+	return
+}
+
+func (r *BusyRepository) Replace(ctx context.Context, recruiterID, source string, data []busy.Block) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for id, block := range r.db {
+		if visible(ctx, block.TenantID) && block.RecruiterID == recruiterID && block.Source == source {
+			delete(r.db, id)
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, block := range data {
+		block.ID = uuid.New().String()
+		block.TenantID = organization.TenantFromContext(ctx)
+		block.RecruiterID = recruiterID
+		block.Source = source
+		block.CreatedAt = now
+		r.db[block.ID] = block
+	}
+
+	return
+}
+
+type BusyFeedRepository struct {
+	db map[string]busy.Feed
+	sync.RWMutex
+}
+
+func NewBusyFeedRepository() *BusyFeedRepository {
+	return &BusyFeedRepository{
+		db: make(map[string]busy.Feed),
+	}
+}
+
+func (r *BusyFeedRepository) List(ctx context.Context) (dest []busy.Feed, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]busy.Feed, 0, len(r.db))
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *BusyFeedRepository) Get(ctx context.Context, recruiterID string) (dest busy.Feed, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[recruiterID]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *BusyFeedRepository) Save(ctx context.Context, data busy.Feed) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.RecruiterID] = data
+
+	return
+}
+
+func (r *BusyFeedRepository) Delete(ctx context.Context, recruiterID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if data, ok := r.db[recruiterID]; !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	delete(r.db, recruiterID)
+
+	return
+}
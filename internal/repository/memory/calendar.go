@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"reservation-system/internal/domain/calendar"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+	"sync"
+)
+
+type FeedTokenRepository struct {
+	db map[string]calendar.FeedToken
+	sync.RWMutex
+}
+
+func NewFeedTokenRepository() *FeedTokenRepository {
+	return &FeedTokenRepository{
+		db: make(map[string]calendar.FeedToken),
+	}
+}
+
+func (r *FeedTokenRepository) Get(ctx context.Context, ownerType, ownerID string) (dest calendar.FeedToken, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[ownerType+"/"+ownerID]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *FeedTokenRepository) Save(ctx context.Context, data calendar.FeedToken) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.OwnerType+"/"+data.OwnerID] = data
+
+	return
+}
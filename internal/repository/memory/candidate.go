@@ -2,10 +2,13 @@ package memory
 
 import (
 	"context"
-	"database/sql"
 	"github.com/google/uuid"
 	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+	"sort"
 	"sync"
+	"time"
 )
 
 type CandidateRepository struct {
@@ -19,12 +22,18 @@ func NewCandidateRepository() *CandidateRepository {
 	}
 }
 
-func (r *CandidateRepository) List(ctx context.Context) (dest []candidate.Entity, err error) {
+func (r *CandidateRepository) List(ctx context.Context, filter candidate.Filter) (dest []candidate.Entity, err error) {
 	r.RLock()
 	defer r.RUnlock()
 
 	dest = make([]candidate.Entity, 0, len(r.db))
 	for _, data := range r.db {
+		if !visible(ctx, data.TenantID) || (data.DeletedAt != nil && !filter.IncludeDeleted) {
+			continue
+		}
+		if !filter.Match(data) {
+			continue
+		}
 		dest = append(dest, data)
 	}
 
@@ -37,6 +46,7 @@ func (r *CandidateRepository) Add(ctx context.Context, data candidate.Entity) (d
 
 	id := r.generateID()
 	data.ID = id
+	data.TenantID = organization.TenantFromContext(ctx)
 	r.db[id] = data
 
 	return id, nil
@@ -47,8 +57,8 @@ func (r *CandidateRepository) Get(ctx context.Context, id string) (dest candidat
 	defer r.RUnlock()
 
 	dest, ok := r.db[id]
-	if !ok {
-		err = sql.ErrNoRows
+	if !ok || !visible(ctx, dest.TenantID) || dest.DeletedAt != nil {
+		err = store.ErrorNotFound
 		return
 	}
 
@@ -59,22 +69,65 @@ func (r *CandidateRepository) Update(ctx context.Context, id string, data candid
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) || current.DeletedAt != nil {
+		return store.ErrorNotFound
 	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+	data.ID = id
+	data.TenantID = current.TenantID
+	data.Version = current.Version + 1
 	r.db[id] = data
 
 	return
 }
 
-func (r *CandidateRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *CandidateRepository) Delete(ctx context.Context, id string, version int) (err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) || data.DeletedAt != nil {
+		return store.ErrorNotFound
+	}
+	if version != 0 && version != data.Version {
+		return store.ErrorConflict
+	}
+	now := time.Now().UTC()
+	data.DeletedAt = &now
+	data.Version++
+	r.db[id] = data
+
+	return
+}
+
+func (r *CandidateRepository) Restore(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) || data.DeletedAt == nil {
+		return store.ErrorNotFound
+	}
+	data.DeletedAt = nil
+	data.Version++
+	r.db[id] = data
+
+	return
+}
+
+func (r *CandidateRepository) Purge(ctx context.Context, before time.Time) (dest []candidate.Entity, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for id, data := range r.db {
+		if data.DeletedAt != nil && data.DeletedAt.Before(before) {
+			delete(r.db, id)
+			dest = append(dest, candidate.Entity{ID: id, TenantID: data.TenantID})
+		}
 	}
-	delete(r.db, id)
 
 	return
 }
@@ -82,3 +135,136 @@ func (r *CandidateRepository) Delete(ctx context.Context, id string) (err error)
 func (r *CandidateRepository) generateID() string {
 	return uuid.New().String()
 }
+
+type CandidateNoteRepository struct {
+	db map[string]candidate.Note
+	sync.RWMutex
+}
+
+func NewCandidateNoteRepository() *CandidateNoteRepository {
+	return &CandidateNoteRepository{
+		db: make(map[string]candidate.Note),
+	}
+}
+
+func (r *CandidateNoteRepository) List(ctx context.Context, candidateID string) (dest []candidate.Note, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]candidate.Note, 0)
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && data.CandidateID == candidateID {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		if !dest[i].CreatedAt.Equal(dest[j].CreatedAt) {
+			return dest[i].CreatedAt.After(dest[j].CreatedAt)
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	return
+}
+
+func (r *CandidateNoteRepository) Add(ctx context.Context, data candidate.Note) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *CandidateNoteRepository) Get(ctx context.Context, id string) (dest candidate.Note, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *CandidateNoteRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if data, ok := r.db[id]; !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+type CandidateAttachmentRepository struct {
+	db map[string]candidate.Attachment
+	sync.RWMutex
+}
+
+func NewCandidateAttachmentRepository() *CandidateAttachmentRepository {
+	return &CandidateAttachmentRepository{
+		db: make(map[string]candidate.Attachment),
+	}
+}
+
+func (r *CandidateAttachmentRepository) List(ctx context.Context, candidateID string) (dest []candidate.Attachment, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]candidate.Attachment, 0)
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && data.CandidateID == candidateID {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		if !dest[i].UploadedAt.Equal(dest[j].UploadedAt) {
+			return dest[i].UploadedAt.After(dest[j].UploadedAt)
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	return
+}
+
+func (r *CandidateAttachmentRepository) Add(ctx context.Context, data candidate.Attachment) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.ID] = data
+
+	return
+}
+
+func (r *CandidateAttachmentRepository) Get(ctx context.Context, id string) (dest candidate.Attachment, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *CandidateAttachmentRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if data, ok := r.db[id]; !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
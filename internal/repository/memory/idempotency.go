@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"reservation-system/internal/domain/idempotency"
+	"reservation-system/pkg/store"
+	"sync"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db map[string]idempotency.Entity
+	sync.RWMutex
+}
+
+func NewIdempotencyRepository() *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: make(map[string]idempotency.Entity),
+	}
+}
+
+func (r *IdempotencyRepository) Add(ctx context.Context, data idempotency.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if current, ok := r.db[data.Key]; ok && current.ExpiresAt.After(time.Now()) {
+		return store.ErrorConflict
+	}
+	r.db[data.Key] = data
+
+	return
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, key string) (dest idempotency.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[key]
+	if !ok || !dest.ExpiresAt.After(time.Now()) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *IdempotencyRepository) Update(ctx context.Context, key string, data idempotency.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[key]
+	if !ok {
+		return store.ErrorNotFound
+	}
+	current.StatusCode = data.StatusCode
+	current.ContentType = data.ContentType
+	current.Body = data.Body
+	r.db[key] = current
+
+	return
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, key string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[key]; !ok {
+		return store.ErrorNotFound
+	}
+	delete(r.db, key)
+
+	return
+}
+
+func (r *IdempotencyRepository) Purge(ctx context.Context, before time.Time) (count int64, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for key, data := range r.db {
+		if data.ExpiresAt.Before(before) {
+			delete(r.db, key)
+			count++
+		}
+	}
+
+	return
+}
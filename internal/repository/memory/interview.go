@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+)
+
+type InterviewRepository struct {
+	db map[string]interview.Entity
+	sync.RWMutex
+}
+
+func NewInterviewRepository() *InterviewRepository {
+	return &InterviewRepository{
+		db: make(map[string]interview.Entity),
+	}
+}
+
+func (r *InterviewRepository) List(ctx context.Context, filter interview.Filter) (dest []interview.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]interview.Entity, 0)
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && filter.Match(data) {
+			dest = append(dest, cloneInterview(data))
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].StartsAt.Before(*dest[j].StartsAt)
+	})
+
+	return
+}
+
+func (r *InterviewRepository) Add(ctx context.Context, data interview.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if data.ID == "" {
+		data.ID = uuid.New().String()
+	}
+	data.TenantID = organization.TenantFromContext(ctx)
+	data = cloneInterview(data)
+	for i := range data.Participants {
+		data.Participants[i].InterviewID = data.ID
+	}
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *InterviewRepository) Get(ctx context.Context, id string) (dest interview.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+	dest = cloneInterview(dest)
+
+	return
+}
+
+func (r *InterviewRepository) Update(ctx context.Context, id string, data interview.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+
+	if data.RecruiterID != nil {
+		current.RecruiterID = data.RecruiterID
+	}
+	if data.Title != nil {
+		current.Title = data.Title
+	}
+	if data.Location != nil {
+		current.Location = data.Location
+	}
+	if data.MeetingURL != nil {
+		current.MeetingURL = data.MeetingURL
+	}
+	if data.StartsAt != nil {
+		current.StartsAt = data.StartsAt
+	}
+	if data.EndsAt != nil {
+		current.EndsAt = data.EndsAt
+	}
+	if data.Status != nil {
+		current.Status = data.Status
+	}
+	if data.ResourceIDs != nil {
+		current.ResourceIDs = cloneInterview(data).ResourceIDs
+	}
+	if data.Participants != nil {
+		current.Participants = cloneInterview(data).Participants
+		for i := range current.Participants {
+			current.Participants[i].InterviewID = id
+		}
+	}
+	current.Version++
+	r.db[id] = current
+
+	return
+}
+
+func (r *InterviewRepository) Respond(ctx context.Context, id string, data interview.Participant) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	current = cloneInterview(current)
+
+	for i, participant := range current.Participants {
+		if participant.Type == data.Type && participant.ID == data.ID {
+			current.Participants[i].Status = data.Status
+			current.Participants[i].RespondedAt = data.RespondedAt
+			current.Version++
+			r.db[id] = current
+			return
+		}
+	}
+
+	return store.ErrorNotFound
+}
+
+// LockSchedules is a no-op, the units of work of the memory store already run one at a time
+func (r *InterviewRepository) LockSchedules(ctx context.Context, ids ...string) (err error) {
+	return
+}
+
+func (r *InterviewRepository) Purge(ctx context.Context, participantType, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for key, data := range r.db {
+		if !visible(ctx, data.TenantID) {
+			continue
+		}
+		if participantType == interview.ParticipantCandidate {
+			if data.CandidateID != nil && *data.CandidateID == id {
+				delete(r.db, key)
+			}
+			continue
+		}
+		if !data.HasRecruiter(id) {
+			continue
+		}
+
+		data = cloneInterview(data)
+		participants := data.Participants[:0]
+		for _, participant := range data.Participants {
+			if participant.Type != participantType || participant.ID != id {
+				participants = append(participants, participant)
+			}
+		}
+		data.Participants = participants
+		data.RecruiterID = nil
+		if recruiterIDs := data.RecruiterIDs(); len(recruiterIDs) > 0 {
+			data.RecruiterID = &recruiterIDs[0]
+		}
+		data.Version++
+		r.db[key] = data
+	}
+
+	return
+}
+
+// cloneInterview copies the participants and resources so that the stored
+// entity is not changed through the slices of a returned one
+func cloneInterview(data interview.Entity) interview.Entity {
+	if data.Participants != nil {
+		data.Participants = append([]interview.Participant{}, data.Participants...)
+	}
+	if data.ResourceIDs != nil {
+		data.ResourceIDs = append([]string{}, data.ResourceIDs...)
+	}
+	return data
+}
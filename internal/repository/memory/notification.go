@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+	"time"
+)
+
+type NotificationRepository struct {
+	db map[string]notification.Entity
+	sync.RWMutex
+}
+
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		db: make(map[string]notification.Entity),
+	}
+}
+
+func (r *NotificationRepository) List(ctx context.Context, filter notification.Filter) (dest []notification.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]notification.Entity, 0)
+	for _, data := range r.db {
+		if !visible(ctx, data.TenantID) {
+			continue
+		}
+		if filter.Channel != "" && data.Channel != filter.Channel {
+			continue
+		}
+		if filter.Recipient != "" && data.Recipient != filter.Recipient {
+			continue
+		}
+		if filter.Status != "" && data.Status != filter.Status {
+			continue
+		}
+		dest = append(dest, data)
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *NotificationRepository) Add(ctx context.Context, data notification.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now().UTC()
+	}
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *NotificationRepository) Get(ctx context.Context, id string) (dest notification.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *NotificationRepository) Update(ctx context.Context, id string, data notification.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	data.ID = id
+	data.TenantID = current.TenantID
+	r.db[id] = data
+
+	return
+}
+
+func (r *NotificationRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (dest []notification.Entity, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, data := range r.db {
+		if data.Status == notification.StatusPending && !data.NextAttemptAt.After(now) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].NextAttemptAt.Before(dest[j].NextAttemptAt)
+	})
+	if len(dest) > limit {
+		dest = dest[:limit]
+	}
+
+	for _, data := range dest {
+		data.NextAttemptAt = now.Add(lease)
+		r.db[data.ID] = data
+	}
+
+	return
+}
+
+type NotificationOptOutRepository struct {
+	db map[string]notification.OptOut
+	sync.RWMutex
+}
+
+func NewNotificationOptOutRepository() *NotificationOptOutRepository {
+	return &NotificationOptOutRepository{
+		db: make(map[string]notification.OptOut),
+	}
+}
+
+func (r *NotificationOptOutRepository) List(ctx context.Context, channel string) (dest []notification.OptOut, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]notification.OptOut, 0)
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && (channel == "" || data.Channel == channel) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *NotificationOptOutRepository) Add(ctx context.Context, data notification.OptOut) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.TenantID = organization.TenantFromContext(ctx)
+	key := data.TenantID + "/" + data.Channel + "/" + data.Recipient
+	if _, ok := r.db[key]; ok {
+		return
+	}
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now().UTC()
+	}
+	r.db[key] = data
+
+	return
+}
+
+func (r *NotificationOptOutRepository) Delete(ctx context.Context, channel, recipient string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for key, data := range r.db {
+		if visible(ctx, data.TenantID) && data.Channel == channel && data.Recipient == recipient {
+			delete(r.db, key)
+			return
+		}
+	}
+
+	return store.ErrorNotFound
+}
+
+func (r *NotificationOptOutRepository) Exists(ctx context.Context, channel, recipient string) (exists bool, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && data.Channel == channel && data.Recipient == recipient {
+			return true, nil
+		}
+	}
+
+	return
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+	"time"
+)
+
+type OrganizationRepository struct {
+	db map[string]organization.Entity
+	sync.RWMutex
+}
+
+// NewOrganizationRepository seeds the default organization like the migration does
+func NewOrganizationRepository() *OrganizationRepository {
+	name, status := "Default", organization.StatusActive
+	return &OrganizationRepository{
+		db: map[string]organization.Entity{
+			organization.DefaultID: {
+				ID:        organization.DefaultID,
+				Name:      &name,
+				Status:    &status,
+				CreatedAt: time.Now().UTC(),
+				Version:   1,
+			},
+		},
+	}
+}
+
+func (r *OrganizationRepository) List(ctx context.Context) (dest []organization.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]organization.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		dest = append(dest, data)
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *OrganizationRepository) Add(ctx context.Context, data organization.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id = uuid.New().String()
+	data.ID = id
+	data.Version = 1
+	r.db[id] = data
+
+	return
+}
+
+func (r *OrganizationRepository) Get(ctx context.Context, id string) (dest organization.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, id string, data organization.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok {
+		return store.ErrorNotFound
+	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+	data.ID = id
+	data.CreatedAt = current.CreatedAt
+	data.Version = current.Version + 1
+	r.db[id] = data
+
+	return
+}
+
+type OrganizationKeyRepository struct {
+	db map[string]organization.Key
+	sync.RWMutex
+}
+
+func NewOrganizationKeyRepository() *OrganizationKeyRepository {
+	return &OrganizationKeyRepository{
+		db: make(map[string]organization.Key),
+	}
+}
+
+func (r *OrganizationKeyRepository) List(ctx context.Context, organizationID string) (dest []organization.Key, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]organization.Key, 0)
+	for _, data := range r.db {
+		if data.OrganizationID == organizationID {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *OrganizationKeyRepository) Add(ctx context.Context, data organization.Key) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id = uuid.New().String()
+	data.ID = id
+	r.db[id] = data
+
+	return
+}
+
+func (r *OrganizationKeyRepository) GetBySecret(ctx context.Context, secretHash string) (dest organization.Key, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, data := range r.db {
+		if data.SecretHash == secretHash {
+			return data, nil
+		}
+	}
+
+	return dest, store.ErrorNotFound
+}
+
+func (r *OrganizationKeyRepository) Revoke(ctx context.Context, organizationID, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || data.OrganizationID != organizationID || data.RevokedAt != nil {
+		return store.ErrorNotFound
+	}
+	now := time.Now().UTC()
+	data.RevokedAt = &now
+	r.db[id] = data
+
+	return
+}
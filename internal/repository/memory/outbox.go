@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"reservation-system/internal/domain/outbox"
+	"sync"
+	"time"
+)
+
+type OutboxRepository struct {
+	db       []outbox.Entity
+	sequence int64
+	sync.RWMutex
+}
+
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{
+		db: make([]outbox.Entity, 0),
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, data outbox.Entity) (id int64, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.sequence++
+	data.ID = r.sequence
+	data.CreatedAt = time.Now().UTC()
+	r.db = append(r.db, data)
+
+	return data.ID, nil
+}
+
+func (r *OutboxRepository) ListPending(ctx context.Context, limit int) (dest []outbox.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, data := range r.db {
+		if len(dest) == limit {
+			break
+		}
+		if data.PublishedAt == nil {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, ids []int64, at time.Time) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	published := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		published[id] = true
+	}
+	for i := range r.db {
+		if published[r.db[i].ID] {
+			r.db[i].PublishedAt = &at
+		}
+	}
+
+	return
+}
+
+func (r *OutboxRepository) Purge(ctx context.Context, before time.Time) (count int64, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	rest := make([]outbox.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		if data.PublishedAt != nil && data.PublishedAt.Before(before) {
+			count++
+			continue
+		}
+		rest = append(rest, data)
+	}
+	r.db = rest
+
+	return
+}
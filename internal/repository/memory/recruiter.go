@@ -2,10 +2,12 @@ package memory
 
 import (
 	"context"
-	"database/sql"
 	"github.com/google/uuid"
+	"reservation-system/internal/domain/organization"
 	"reservation-system/internal/domain/recruiter"
+	"reservation-system/pkg/store"
 	"sync"
+	"time"
 )
 
 type RecruiterRepository struct {
@@ -19,12 +21,18 @@ func NewRecruiterRepository() *RecruiterRepository {
 	}
 }
 
-func (r *RecruiterRepository) List(ctx context.Context) (dest []recruiter.Entity, err error) {
+func (r *RecruiterRepository) List(ctx context.Context, filter recruiter.Filter) (dest []recruiter.Entity, err error) {
 	r.RLock()
 	defer r.RUnlock()
 
 	dest = make([]recruiter.Entity, 0, len(r.db))
 	for _, data := range r.db {
+		if !visible(ctx, data.TenantID) || (data.DeletedAt != nil && !filter.IncludeDeleted) {
+			continue
+		}
+		if !filter.Match(data) {
+			continue
+		}
 		dest = append(dest, data)
 	}
 
@@ -37,6 +45,7 @@ func (r *RecruiterRepository) Add(ctx context.Context, data recruiter.Entity) (d
 
 	id := r.generateID()
 	data.ID = id
+	data.TenantID = organization.TenantFromContext(ctx)
 	r.db[id] = data
 
 	return id, nil
@@ -47,8 +56,8 @@ func (r *RecruiterRepository) Get(ctx context.Context, id string) (dest recruite
 	defer r.RUnlock()
 
 	dest, ok := r.db[id]
-	if !ok {
-		err = sql.ErrNoRows
+	if !ok || !visible(ctx, dest.TenantID) || dest.DeletedAt != nil {
+		err = store.ErrorNotFound
 		return
 	}
 
@@ -59,22 +68,65 @@ func (r *RecruiterRepository) Update(ctx context.Context, id string, data recrui
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) || current.DeletedAt != nil {
+		return store.ErrorNotFound
 	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+	data.ID = id
+	data.TenantID = current.TenantID
+	data.Version = current.Version + 1
+	r.db[id] = data
+
+	return
+}
+
+func (r *RecruiterRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) || data.DeletedAt != nil {
+		return store.ErrorNotFound
+	}
+	if version != 0 && version != data.Version {
+		return store.ErrorConflict
+	}
+	now := time.Now().UTC()
+	data.DeletedAt = &now
+	data.Version++
+	r.db[id] = data
+
+	return
+}
+
+func (r *RecruiterRepository) Restore(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) || data.DeletedAt == nil {
+		return store.ErrorNotFound
+	}
+	data.DeletedAt = nil
+	data.Version++
 	r.db[id] = data
 
 	return
 }
 
-func (r *RecruiterRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *RecruiterRepository) Purge(ctx context.Context, before time.Time) (dest []recruiter.Entity, err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return sql.ErrNoRows
+	for id, data := range r.db {
+		if data.DeletedAt != nil && data.DeletedAt.Before(before) {
+			delete(r.db, id)
+			dest = append(dest, recruiter.Entity{ID: id, TenantID: data.TenantID})
+		}
 	}
-	delete(r.db, id)
 
 	return
 }
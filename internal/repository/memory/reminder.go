@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/reminder"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+	"time"
+)
+
+type ReminderRepository struct {
+	db   map[string]reminder.Entity
+	keys map[string]string
+	sync.RWMutex
+}
+
+func NewReminderRepository() *ReminderRepository {
+	return &ReminderRepository{
+		db:   make(map[string]reminder.Entity),
+		keys: make(map[string]string),
+	}
+}
+
+func (r *ReminderRepository) Add(ctx context.Context, data reminder.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	key := fmt.Sprintf("%s/%d/%d", data.InterviewID, data.OffsetSeconds, data.StartsAt.UnixNano())
+	if _, ok := r.keys[key]; ok {
+		return
+	}
+
+	data.ID = uuid.New().String()
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now().UTC()
+	}
+	r.db[data.ID] = data
+	r.keys[key] = data.ID
+
+	return
+}
+
+// ClaimDue does not lock, the units of work of the memory store already run one at a time
+func (r *ReminderRepository) ClaimDue(ctx context.Context, now time.Time, limit int) (dest []reminder.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for _, data := range r.db {
+		if data.Status == reminder.StatusPending && !data.DueAt.After(now) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].DueAt.Before(dest[j].DueAt)
+	})
+	if len(dest) > limit {
+		dest = dest[:limit]
+	}
+
+	return
+}
+
+func (r *ReminderRepository) Update(ctx context.Context, id string, data reminder.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	data.ID = id
+	r.db[id] = data
+
+	return
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+)
+
+type ResourceRepository struct {
+	db map[string]resource.Entity
+	sync.RWMutex
+}
+
+func NewResourceRepository() *ResourceRepository {
+	return &ResourceRepository{
+		db: make(map[string]resource.Entity),
+	}
+}
+
+func (r *ResourceRepository) List(ctx context.Context, filter resource.Filter) (dest []resource.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]resource.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && filter.Match(data) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Name < *dest[j].Name
+	})
+
+	return
+}
+
+func (r *ResourceRepository) Add(ctx context.Context, data resource.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *ResourceRepository) Get(ctx context.Context, id string) (dest resource.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ResourceRepository) Update(ctx context.Context, id string, data resource.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+	data.ID = id
+	data.TenantID = current.TenantID
+	data.Version = current.Version + 1
+	r.db[id] = data
+
+	return
+}
+
+func (r *ResourceRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	if version != 0 && version != data.Version {
+		return store.ErrorConflict
+	}
+	delete(r.db, id)
+
+	return
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+)
+
+type ScorecardTemplateRepository struct {
+	db map[string]scorecard.Template
+	sync.RWMutex
+}
+
+func NewScorecardTemplateRepository() *ScorecardTemplateRepository {
+	return &ScorecardTemplateRepository{
+		db: make(map[string]scorecard.Template),
+	}
+}
+
+func (r *ScorecardTemplateRepository) List(ctx context.Context) (dest []scorecard.Template, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]scorecard.Template, 0, len(r.db))
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Name < *dest[j].Name
+	})
+
+	return
+}
+
+func (r *ScorecardTemplateRepository) Add(ctx context.Context, data scorecard.Template) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *ScorecardTemplateRepository) Get(ctx context.Context, id string) (dest scorecard.Template, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ScorecardTemplateRepository) Update(ctx context.Context, id string, data scorecard.Template) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+	data.ID = id
+	data.TenantID = current.TenantID
+	data.Version = current.Version + 1
+	r.db[id] = data
+
+	return
+}
+
+func (r *ScorecardTemplateRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	if version != 0 && version != data.Version {
+		return store.ErrorConflict
+	}
+	delete(r.db, id)
+
+	return
+}
+
+type ScorecardRepository struct {
+	db map[string]scorecard.Submission
+	sync.RWMutex
+}
+
+func NewScorecardRepository() *ScorecardRepository {
+	return &ScorecardRepository{
+		db: make(map[string]scorecard.Submission),
+	}
+}
+
+func (r *ScorecardRepository) List(ctx context.Context, filter scorecard.Filter) (dest []scorecard.Submission, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]scorecard.Submission, 0)
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) && filter.Match(data) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		if !dest[i].SubmittedAt.Equal(*dest[j].SubmittedAt) {
+			return dest[i].SubmittedAt.Before(*dest[j].SubmittedAt)
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	return
+}
+
+func (r *ScorecardRepository) Add(ctx context.Context, data scorecard.Submission) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *ScorecardRepository) Get(ctx context.Context, id string) (dest scorecard.Submission, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ScorecardRepository) Update(ctx context.Context, id string, data scorecard.Submission) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+	data.ID = id
+	data.TenantID = current.TenantID
+	data.InterviewID = current.InterviewID
+	data.RecruiterID = current.RecruiterID
+	data.Version = current.Version + 1
+	r.db[id] = data
+
+	return
+}
@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/team"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+	"time"
+)
+
+type TeamRepository struct {
+	db map[string]team.Entity
+	sync.RWMutex
+}
+
+func NewTeamRepository() *TeamRepository {
+	return &TeamRepository{
+		db: make(map[string]team.Entity),
+	}
+}
+
+func (r *TeamRepository) List(ctx context.Context) (dest []team.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]team.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Name < *dest[j].Name
+	})
+
+	return
+}
+
+func (r *TeamRepository) Add(ctx context.Context, data team.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *TeamRepository) Get(ctx context.Context, id string) (dest team.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *TeamRepository) Update(ctx context.Context, id string, data team.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	if data.Version != 0 && data.Version != current.Version {
+		return store.ErrorConflict
+	}
+	data.ID = id
+	data.TenantID = current.TenantID
+	data.LastAssignedID = current.LastAssignedID
+	data.Version = current.Version + 1
+	r.db[id] = data
+
+	return
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	if version != 0 && version != data.Version {
+		return store.ErrorConflict
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *TeamRepository) Advance(ctx context.Context, id, recruiterID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	data.LastAssignedID = &recruiterID
+	r.db[id] = data
+
+	return
+}
+
+type TeamMemberRepository struct {
+	db map[string][]team.Member
+	sync.RWMutex
+}
+
+func NewTeamMemberRepository() *TeamMemberRepository {
+	return &TeamMemberRepository{
+		db: make(map[string][]team.Member),
+	}
+}
+
+func (r *TeamMemberRepository) List(ctx context.Context, teamID string) (dest []team.Member, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]team.Member, 0, len(r.db[teamID]))
+	for _, data := range r.db[teamID] {
+		if visible(ctx, data.TenantID) {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *TeamMemberRepository) Add(ctx context.Context, data team.Member) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, member := range r.db[data.TeamID] {
+		if member.RecruiterID == data.RecruiterID {
+			return store.ErrorConflict
+		}
+	}
+	data.TenantID = organization.TenantFromContext(ctx)
+	if data.JoinedAt.IsZero() {
+		data.JoinedAt = time.Now().UTC()
+	}
+	r.db[data.TeamID] = append(r.db[data.TeamID], data)
+
+	return
+}
+
+func (r *TeamMemberRepository) Delete(ctx context.Context, teamID, recruiterID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	members := r.db[teamID]
+	for i, member := range members {
+		if member.RecruiterID == recruiterID && visible(ctx, member.TenantID) {
+			r.db[teamID] = append(members[:i:i], members[i+1:]...)
+			return
+		}
+	}
+
+	return store.ErrorNotFound
+}
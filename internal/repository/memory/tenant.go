@@ -0,0 +1,17 @@
+package memory
+
+import (
+	"context"
+	"reservation-system/internal/domain/organization"
+)
+
+// visible reports whether the data of the organization is visible in ctx,
+// a system context sees the data of every organization and a context that
+// is not scoped sees none
+func visible(ctx context.Context, tenantID string) bool {
+	if organization.IsSystem(ctx) {
+		return true
+	}
+	tenant := organization.TenantFromContext(ctx)
+	return tenant != "" && tenant == tenantID
+}
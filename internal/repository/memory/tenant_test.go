@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/internal/domain/team"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/pkg/store"
+	"testing"
+)
+
+const otherTenantID = "00000000-0000-0000-0000-000000000002"
+
+// tenantRepository reaches the methods of a repository shared by the entities
+type tenantRepository struct {
+	add    func(ctx context.Context) (string, error)
+	get    func(ctx context.Context, id string) error
+	list   func(ctx context.Context) (int, error)
+	update func(ctx context.Context, id string) error
+	delete func(ctx context.Context, id string) error
+}
+
+func TestTenantIsolation(t *testing.T) {
+	candidates, recruiters, resources := NewCandidateRepository(), NewRecruiterRepository(), NewResourceRepository()
+	vacancies, teams, interviews := NewVacancyRepository(), NewTeamRepository(), NewInterviewRepository()
+
+	tests := map[string]tenantRepository{
+		"candidate": {
+			add: func(ctx context.Context) (string, error) { return candidates.Add(ctx, candidate.Entity{}) },
+			get: func(ctx context.Context, id string) (err error) { _, err = candidates.Get(ctx, id); return },
+			list: func(ctx context.Context) (int, error) {
+				dest, err := candidates.List(ctx, candidate.Filter{})
+				return len(dest), err
+			},
+			update: func(ctx context.Context, id string) error { return candidates.Update(ctx, id, candidate.Entity{}) },
+			delete: func(ctx context.Context, id string) error { return candidates.Delete(ctx, id, 0) },
+		},
+		"recruiter": {
+			add: func(ctx context.Context) (string, error) { return recruiters.Add(ctx, recruiter.Entity{}) },
+			get: func(ctx context.Context, id string) (err error) { _, err = recruiters.Get(ctx, id); return },
+			list: func(ctx context.Context) (int, error) {
+				dest, err := recruiters.List(ctx, recruiter.Filter{})
+				return len(dest), err
+			},
+			update: func(ctx context.Context, id string) error { return recruiters.Update(ctx, id, recruiter.Entity{}) },
+			delete: func(ctx context.Context, id string) error { return recruiters.Delete(ctx, id, 0) },
+		},
+		"resource": {
+			add: func(ctx context.Context) (string, error) { return resources.Add(ctx, resource.Entity{}) },
+			get: func(ctx context.Context, id string) (err error) { _, err = resources.Get(ctx, id); return },
+			list: func(ctx context.Context) (int, error) {
+				dest, err := resources.List(ctx, resource.Filter{})
+				return len(dest), err
+			},
+			update: func(ctx context.Context, id string) error { return resources.Update(ctx, id, resource.Entity{}) },
+			delete: func(ctx context.Context, id string) error { return resources.Delete(ctx, id, 0) },
+		},
+		"vacancy": {
+			add: func(ctx context.Context) (string, error) { return vacancies.Add(ctx, vacancy.Entity{}) },
+			get: func(ctx context.Context, id string) (err error) { _, err = vacancies.Get(ctx, id); return },
+			list: func(ctx context.Context) (int, error) {
+				dest, err := vacancies.List(ctx, vacancy.Filter{})
+				return len(dest), err
+			},
+			update: func(ctx context.Context, id string) error { return vacancies.Update(ctx, id, vacancy.Entity{}) },
+			delete: func(ctx context.Context, id string) error { return vacancies.Delete(ctx, id, 0) },
+		},
+		"team": {
+			add: func(ctx context.Context) (string, error) { return teams.Add(ctx, team.Entity{}) },
+			get: func(ctx context.Context, id string) (err error) { _, err = teams.Get(ctx, id); return },
+			list: func(ctx context.Context) (int, error) {
+				dest, err := teams.List(ctx)
+				return len(dest), err
+			},
+			update: func(ctx context.Context, id string) error { return teams.Update(ctx, id, team.Entity{}) },
+			delete: func(ctx context.Context, id string) error { return teams.Delete(ctx, id, 0) },
+		},
+		"interview": {
+			add: func(ctx context.Context) (string, error) { return interviews.Add(ctx, interview.Entity{}) },
+			get: func(ctx context.Context, id string) (err error) { _, err = interviews.Get(ctx, id); return },
+			list: func(ctx context.Context) (int, error) {
+				dest, err := interviews.List(ctx, interview.Filter{})
+				return len(dest), err
+			},
+			update: func(ctx context.Context, id string) error { return interviews.Update(ctx, id, interview.Entity{}) },
+		},
+	}
+
+	owner := organization.ContextWithTenant(context.Background(), organization.DefaultID)
+	contexts := map[string]context.Context{
+		"other organization": organization.ContextWithTenant(context.Background(), otherTenantID),
+		"no organization":    context.Background(),
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := tt.add(owner)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for scope, ctx := range contexts {
+				if err = tt.get(ctx, id); !errors.Is(err, store.ErrorNotFound) {
+					t.Errorf("%s: Get() error = %v, want not found", scope, err)
+				}
+				if count, err := tt.list(ctx); err != nil || count != 0 {
+					t.Errorf("%s: List() = %d, %v, want none", scope, count, err)
+				}
+				if err = tt.update(ctx, id); !errors.Is(err, store.ErrorNotFound) {
+					t.Errorf("%s: Update() error = %v, want not found", scope, err)
+				}
+				if tt.delete != nil {
+					if err = tt.delete(ctx, id); !errors.Is(err, store.ErrorNotFound) {
+						t.Errorf("%s: Delete() error = %v, want not found", scope, err)
+					}
+				}
+			}
+
+			// the data is untouched for its organization and visible to the system
+			for scope, ctx := range map[string]context.Context{"owner": owner, "system": organization.ContextWithSystem(context.Background())} {
+				if err = tt.get(ctx, id); err != nil {
+					t.Errorf("%s: Get() error = %v", scope, err)
+				}
+				if count, err := tt.list(ctx); err != nil || count != 1 {
+					t.Errorf("%s: List() = %d, %v, want 1", scope, count, err)
+				}
+			}
+		})
+	}
+}
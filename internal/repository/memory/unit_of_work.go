@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+// UnitOfWork runs the units of work of the memory store one at a time,
+// changes made before a failure are not rolled back
+type UnitOfWork struct {
+	mu sync.Mutex
+}
+
+func NewUnitOfWork() *UnitOfWork {
+	return &UnitOfWork{}
+}
+
+type unitOfWorkKey struct{}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(unitOfWorkKey{}) != nil {
+		return fn(ctx)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return fn(context.WithValue(ctx, unitOfWorkKey{}, u))
+}
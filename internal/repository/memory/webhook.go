@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/webhook"
+	"reservation-system/pkg/store"
+	"sort"
+	"sync"
+	"time"
+)
+
+type WebhookSubscriptionRepository struct {
+	db map[string]webhook.Subscription
+	sync.RWMutex
+}
+
+func NewWebhookSubscriptionRepository() *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		db: make(map[string]webhook.Subscription),
+	}
+}
+
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) (dest []webhook.Subscription, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]webhook.Subscription, 0, len(r.db))
+	for _, data := range r.db {
+		if visible(ctx, data.TenantID) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) Add(ctx context.Context, data webhook.Subscription) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	data.TenantID = organization.TenantFromContext(ctx)
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now().UTC()
+	}
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *WebhookSubscriptionRepository) Get(ctx context.Context, id string) (dest webhook.Subscription, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok || !visible(ctx, dest.TenantID) {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, id string, data webhook.Subscription) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok || !visible(ctx, current.TenantID) {
+		return store.ErrorNotFound
+	}
+	if data.URL != nil {
+		current.URL = data.URL
+	}
+	if data.EventTypes != nil {
+		current.EventTypes = data.EventTypes
+	}
+	if data.Secret != nil {
+		current.Secret = data.Secret
+	}
+	if data.Active != nil {
+		current.Active = data.Active
+	}
+	r.db[id] = current
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if data, ok := r.db[id]; !ok || !visible(ctx, data.TenantID) {
+		return store.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+type WebhookDeliveryRepository struct {
+	db map[string]webhook.Delivery
+	sync.RWMutex
+}
+
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db: make(map[string]webhook.Delivery),
+	}
+}
+
+func (r *WebhookDeliveryRepository) List(ctx context.Context, subscriptionID string) (dest []webhook.Delivery, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]webhook.Delivery, 0)
+	for _, data := range r.db {
+		if data.SubscriptionID == subscriptionID {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *WebhookDeliveryRepository) Add(ctx context.Context, data webhook.Delivery) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.ID = uuid.New().String()
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now().UTC()
+	}
+	r.db[data.ID] = data
+
+	return data.ID, nil
+}
+
+func (r *WebhookDeliveryRepository) Get(ctx context.Context, id string) (dest webhook.Delivery, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = store.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, id string, data webhook.Delivery) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return store.ErrorNotFound
+	}
+	data.ID = id
+	r.db[id] = data
+
+	return
+}
+
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (dest []webhook.Delivery, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, data := range r.db {
+		if data.Status == webhook.StatusPending && !data.NextAttemptAt.After(now) {
+			dest = append(dest, data)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].NextAttemptAt.Before(dest[j].NextAttemptAt)
+	})
+	if len(dest) > limit {
+		dest = dest[:limit]
+	}
+
+	for _, data := range dest {
+		data.NextAttemptAt = now.Add(lease)
+		r.db[data.ID] = data
+	}
+
+	return
+}
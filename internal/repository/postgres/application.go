@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/application"
+	"reservation-system/pkg/store"
+	"strings"
+)
+
+type ApplicationRepository struct {
+	db *sqlx.DB
+}
+
+func NewApplicationRepository(db *sqlx.DB) *ApplicationRepository {
+	return &ApplicationRepository{
+		db: db,
+	}
+}
+
+const applicationColumns = `id, tenant_id, candidate_id, vacancy_id, stage, status, applied_at, version`
+
+func (r *ApplicationRepository) List(ctx context.Context, filter application.Filter) (dest []application.Entity, err error) {
+	wheres := []string{tenantCond("tenant_id", 1)}
+	args := []any{tenant(ctx)}
+	if filter.CandidateID != "" {
+		args = append(args, filter.CandidateID)
+		wheres = append(wheres, fmt.Sprintf("candidate_id = $%d", len(args)))
+	}
+	if filter.VacancyID != "" {
+		args = append(args, filter.VacancyID)
+		wheres = append(wheres, fmt.Sprintf("vacancy_id = $%d", len(args)))
+	}
+	if filter.Stage != "" {
+		args = append(args, filter.Stage)
+		wheres = append(wheres, fmt.Sprintf("stage = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		wheres = append(wheres, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `
+		SELECT ` + applicationColumns + `
+		FROM applications`
+	query += " WHERE " + strings.Join(wheres, " AND ")
+	query += " ORDER BY applied_at, id"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	if err = r.loadHistory(ctx, dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *ApplicationRepository) Add(ctx context.Context, data application.Entity) (id string, err error) {
+	query := `
+		INSERT INTO applications (tenant_id, candidate_id, vacancy_id, stage, status, applied_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.CandidateID, data.VacancyID, data.Stage, data.Status, data.AppliedAt}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add application: %w", err)
+	}
+
+	for _, transition := range data.History {
+		if err = r.addTransition(ctx, id, transition); err != nil {
+			return "", err
+		}
+	}
+
+	return
+}
+
+func (r *ApplicationRepository) Get(ctx context.Context, id string) (dest application.Entity, err error) {
+	query := `
+		SELECT ` + applicationColumns + `
+		FROM applications
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get application with id %s: %w", id, err)
+	}
+
+	data := []application.Entity{dest}
+	if err = r.loadHistory(ctx, data); err != nil {
+		return
+	}
+	dest = data[0]
+
+	return
+}
+
+func (r *ApplicationRepository) Move(ctx context.Context, id string, version int, data application.Transition) (err error) {
+	query := `
+		UPDATE applications
+		SET stage = $1, status = $2, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $3 AND ($4 = 0 OR version = $4) AND ($5::uuid IS NULL OR tenant_id = $5)
+		RETURNING id`
+
+	args := []any{data.Stage, data.Status, id, version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to move application with id %s: %w", id, err)
+	}
+
+	return r.addTransition(ctx, id, data)
+}
+
+func (r *ApplicationRepository) addTransition(ctx context.Context, id string, data application.Transition) (err error) {
+	query := `
+		INSERT INTO application_history (application_id, from_stage, stage, status, note, actor, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	args := []any{id, data.FromStage, data.Stage, data.Status, data.Note, data.Actor, data.ChangedAt}
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add history of application with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// loadHistory sets the history of the applications
+func (r *ApplicationRepository) loadHistory(ctx context.Context, data []application.Entity) (err error) {
+	if len(data) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(data))
+	for _, entity := range data {
+		ids = append(ids, entity.ID)
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT application_id, from_stage, stage, status, note, actor, changed_at
+		FROM application_history
+		WHERE application_id IN (?)
+		ORDER BY changed_at, position`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build history query: %w", err)
+	}
+
+	var history []application.Transition
+	err = conn(ctx, r.db).SelectContext(ctx, &history, r.db.Rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to list history of applications: %w", err)
+	}
+
+	byApplication := make(map[string][]application.Transition, len(data))
+	for _, transition := range history {
+		byApplication[transition.ApplicationID] = append(byApplication[transition.ApplicationID], transition)
+	}
+	for i := range data {
+		data[i].History = byApplication[data[i].ID]
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing application from a stale version
+// after a conditional write has matched no rows
+func (r *ApplicationRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM applications WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check application with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
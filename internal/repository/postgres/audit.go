@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/audit"
+	"strings"
+)
+
+type AuditRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) List(ctx context.Context, filter audit.Filter) (dest []audit.Entity, err error) {
+	where := []string{tenantCond("tenant_id", 1)}
+	args := []any{tenant(ctx)}
+
+	if filter.EntityType != "" {
+		args = append(args, filter.EntityType)
+		where = append(where, fmt.Sprintf("entity_type = $%d", len(args)))
+	}
+
+	if filter.EntityID != "" {
+		args = append(args, filter.EntityID)
+		where = append(where, fmt.Sprintf("entity_id = $%d", len(args)))
+	}
+
+	query := `
+		SELECT id, tenant_id, actor, action, entity_type, entity_id, changes, request_id, created_at
+		FROM audit_log`
+	query += " WHERE " + strings.Join(where, " AND ")
+	query += " ORDER BY created_at"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	return
+}
+
+func (r *AuditRepository) Add(ctx context.Context, data audit.Entity) (id string, err error) {
+	query := `
+		INSERT INTO audit_log (tenant_id, actor, action, entity_type, entity_id, changes, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.Actor, data.Action, data.EntityType, data.EntityID, data.Changes, data.RequestID}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add audit entry: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/pkg/store"
+	"strings"
+)
+
+// busyInsertBatch bounds the rows of one insert below the limit of parameters
+const busyInsertBatch = 1000
+
+type BusyRepository struct {
+	db *sqlx.DB
+}
+
+func NewBusyRepository(db *sqlx.DB) *BusyRepository {
+	return &BusyRepository{
+		db: db,
+	}
+}
+
+func (r *BusyRepository) List(ctx context.Context, filter busy.Filter) (dest []busy.Block, err error) {
+	wheres := []string{tenantCond("tenant_id", 1)}
+	args := []any{tenant(ctx)}
+	if len(filter.RecruiterIDs) > 0 {
+		placeholders := make([]string, 0, len(filter.RecruiterIDs))
+		for _, id := range filter.RecruiterIDs {
+			args = append(args, id)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		wheres = append(wheres, "recruiter_id IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		wheres = append(wheres, fmt.Sprintf("ends_at > $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		wheres = append(wheres, fmt.Sprintf("starts_at < $%d", len(args)))
+	}
+
+	query := `
+		SELECT id, tenant_id, recruiter_id, source, starts_at, ends_at, created_at
+		FROM busy_blocks`
+	query += " WHERE " + strings.Join(wheres, " AND ")
+	query += " ORDER BY starts_at"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list busy blocks: %w", err)
+	}
+
+	return
+}
+
+func (r *BusyRepository) Replace(ctx context.Context, recruiterID, source string, data []busy.Block) (err error) {
+	q := conn(ctx, r.db)
+
+	query := `
+		DELETE FROM busy_blocks
+		WHERE recruiter_id = $1 AND source = $2 AND ($3::uuid IS NULL OR tenant_id = $3)`
+
+	if _, err = q.ExecContext(ctx, query, recruiterID, source, tenant(ctx)); err != nil {
+		return fmt.Errorf("failed to delete busy blocks of recruiter %s: %w", recruiterID, err)
+	}
+
+	for len(data) > 0 {
+		batch := data
+		if len(batch) > busyInsertBatch {
+			batch = batch[:busyInsertBatch]
+		}
+		data = data[len(batch):]
+
+		values := make([]string, 0, len(batch))
+		args := make([]any, 0, 5*len(batch))
+		for _, block := range batch {
+			args = append(args, tenant(ctx), recruiterID, source, block.StartsAt, block.EndsAt)
+			n := len(args)
+			values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n-4, n-3, n-2, n-1, n))
+		}
+
+		query = `
+		INSERT INTO busy_blocks (tenant_id, recruiter_id, source, starts_at, ends_at)
+		VALUES ` + strings.Join(values, ", ")
+
+		if _, err = q.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to add busy blocks of recruiter %s: %w", recruiterID, err)
+		}
+	}
+
+	return
+}
+
+type BusyFeedRepository struct {
+	db *sqlx.DB
+}
+
+func NewBusyFeedRepository(db *sqlx.DB) *BusyFeedRepository {
+	return &BusyFeedRepository{
+		db: db,
+	}
+}
+
+func (r *BusyFeedRepository) List(ctx context.Context) (dest []busy.Feed, err error) {
+	query := `
+		SELECT tenant_id, recruiter_id, url, synced_at, last_error, created_at
+		FROM busy_feeds
+		WHERE $1::uuid IS NULL OR tenant_id = $1
+		ORDER BY created_at`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, tenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list busy feeds: %w", err)
+	}
+
+	return
+}
+
+func (r *BusyFeedRepository) Get(ctx context.Context, recruiterID string) (dest busy.Feed, err error) {
+	query := `
+		SELECT tenant_id, recruiter_id, url, synced_at, last_error, created_at
+		FROM busy_feeds
+		WHERE recruiter_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, recruiterID, tenant(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get busy feed of recruiter %s: %w", recruiterID, err)
+	}
+
+	return
+}
+
+func (r *BusyFeedRepository) Save(ctx context.Context, data busy.Feed) (err error) {
+	query := `
+		INSERT INTO busy_feeds (tenant_id, recruiter_id, url, synced_at, last_error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (recruiter_id) DO UPDATE
+		SET url = EXCLUDED.url, synced_at = EXCLUDED.synced_at, last_error = EXCLUDED.last_error
+		WHERE busy_feeds.tenant_id = EXCLUDED.tenant_id`
+
+	args := []any{tenant(ctx), data.RecruiterID, data.URL, data.SyncedAt, data.LastError, data.CreatedAt}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save busy feed of recruiter %s: %w", data.RecruiterID, err)
+	}
+
+	return
+}
+
+func (r *BusyFeedRepository) Delete(ctx context.Context, recruiterID string) (err error) {
+	query := `
+		DELETE FROM busy_feeds
+		WHERE recruiter_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+		RETURNING recruiter_id`
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, recruiterID, tenant(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to delete busy feed of recruiter %s: %w", recruiterID, err)
+	}
+
+	return
+}
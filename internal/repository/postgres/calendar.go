@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/calendar"
+	"reservation-system/pkg/store"
+)
+
+type FeedTokenRepository struct {
+	db *sqlx.DB
+}
+
+func NewFeedTokenRepository(db *sqlx.DB) *FeedTokenRepository {
+	return &FeedTokenRepository{
+		db: db,
+	}
+}
+
+func (r *FeedTokenRepository) Get(ctx context.Context, ownerType, ownerID string) (dest calendar.FeedToken, err error) {
+	query := `
+		SELECT tenant_id, owner_type, owner_id, token_hash, created_at
+		FROM calendar_feed_tokens
+		WHERE owner_type = $1 AND owner_id = $2 AND ($3::uuid IS NULL OR tenant_id = $3)`
+
+	args := []any{ownerType, ownerID, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get feed token of %s %s: %w", ownerType, ownerID, err)
+	}
+
+	return
+}
+
+func (r *FeedTokenRepository) Save(ctx context.Context, data calendar.FeedToken) (err error) {
+	query := `
+		INSERT INTO calendar_feed_tokens (tenant_id, owner_type, owner_id, token_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner_type, owner_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+		WHERE calendar_feed_tokens.tenant_id = EXCLUDED.tenant_id`
+
+	args := []any{tenant(ctx), data.OwnerType, data.OwnerID, data.TokenHash, data.CreatedAt}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to save feed token of %s %s: %w", data.OwnerType, data.OwnerID, err)
+	}
+
+	return
+}
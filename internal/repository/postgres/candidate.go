@@ -9,6 +9,7 @@ import (
 	"reservation-system/internal/domain/candidate"
 	"reservation-system/pkg/store"
 	"strings"
+	"time"
 )
 
 type CandidateRepository struct {
@@ -21,13 +22,29 @@ func NewCandidateRepository(db *sqlx.DB) *CandidateRepository {
 	}
 }
 
-func (r *CandidateRepository) List(ctx context.Context) (dest []candidate.Entity, err error) {
+func (r *CandidateRepository) List(ctx context.Context, filter candidate.Filter) (dest []candidate.Entity, err error) {
 	query := `
-		SELECT id, full_name, email, phone
-		FROM candidates
-		ORDER BY id`
+		SELECT id, tenant_id, full_name, email, phone, skills, experience_years, location, links, deleted_at, version
+		FROM candidates`
+
+	var conds []string
+	args := []any{tenant(ctx)}
+	conds = append(conds, tenantCond("tenant_id", 1))
+	if !filter.IncludeDeleted {
+		conds = append(conds, "deleted_at IS NULL")
+	}
+	for _, skill := range filter.Skills {
+		args = append(args, strings.ToLower(skill))
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements_text(skills) AS skill WHERE LOWER(skill) = $%d)", len(args)))
+	}
+	if filter.Location != "" {
+		args = append(args, filter.Location)
+		conds = append(conds, fmt.Sprintf("LOWER(location) = LOWER($%d)", len(args)))
+	}
+	query += " WHERE " + strings.Join(conds, " AND ")
+	query += " ORDER BY id"
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list candidates: %w", err)
 	}
@@ -37,13 +54,21 @@ func (r *CandidateRepository) List(ctx context.Context) (dest []candidate.Entity
 
 func (r *CandidateRepository) Add(ctx context.Context, data candidate.Entity) (id string, err error) {
 	query := `
-		INSERT INTO candidates (full_name, email, phone)
-		VALUES ($1, $2, $3)
+		INSERT INTO candidates (tenant_id, full_name, email, phone, skills, experience_years, location, links)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, 0), COALESCE($7, ''), $8)
 		RETURNING id`
 
-	args := []any{data.FullName, data.Email, data.Phone}
+	skills, links := data.Skills, data.Links
+	if skills == nil {
+		skills = &candidate.Strings{}
+	}
+	if links == nil {
+		links = &candidate.Strings{}
+	}
+
+	args := []any{tenant(ctx), data.FullName, data.Email, data.Phone, skills, data.ExperienceYears, data.Location, links}
 
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
 	if err != nil {
 		return "", fmt.Errorf("failed to add candidate: %w", err)
 	}
@@ -53,13 +78,13 @@ func (r *CandidateRepository) Add(ctx context.Context, data candidate.Entity) (i
 
 func (r *CandidateRepository) Get(ctx context.Context, id string) (dest candidate.Entity, err error) {
 	query := `
-		SELECT id, full_name, email, phone
+		SELECT id, tenant_id, full_name, email, phone, skills, experience_years, location, links, deleted_at, version
 		FROM candidates
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL AND ($2::uuid IS NULL OR tenant_id = $2)`
 
-	args := []any{id}
+	args := []any{id, tenant(ctx)}
 
-	err = r.db.GetContext(ctx, &dest, query, args...)
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return dest, store.ErrorNotFound
@@ -76,19 +101,19 @@ func (r *CandidateRepository) Update(ctx context.Context, id string, data candid
 		return errors.New("no fields to update")
 	}
 
-	args = append(args, id)
-	sets = append(sets, "updated_at = CURRENT_TIMESTAMP")
+	args = append(args, id, data.Version, tenant(ctx))
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP", "version = version + 1")
 
 	setClause := strings.Join(sets, ", ")
 	argPosition := len(args)
 
-	query := fmt.Sprintf("UPDATE candidates SET %s WHERE id = $%d RETURNING id", setClause, argPosition)
+	query := fmt.Sprintf("UPDATE candidates SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) AND deleted_at IS NULL AND %s RETURNING id", setClause, argPosition-2, argPosition-1, argPosition-1, tenantCond("tenant_id", argPosition))
 
 	var returnedID string
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return store.ErrorNotFound
+			return r.conflictOrNotFound(ctx, id)
 		}
 		return fmt.Errorf("failed to update candidate with id %s: %w", id, err)
 	}
@@ -112,24 +137,266 @@ func (r *CandidateRepository) prepareArgs(data candidate.Entity) (sets []string,
 		sets = append(sets, fmt.Sprintf("phone = $%d", len(args)))
 	}
 
+	if data.Skills != nil {
+		args = append(args, data.Skills)
+		sets = append(sets, fmt.Sprintf("skills = $%d", len(args)))
+	}
+
+	if data.ExperienceYears != nil {
+		args = append(args, data.ExperienceYears)
+		sets = append(sets, fmt.Sprintf("experience_years = $%d", len(args)))
+	}
+
+	if data.Location != nil {
+		args = append(args, data.Location)
+		sets = append(sets, fmt.Sprintf("location = $%d", len(args)))
+	}
+
+	if data.Links != nil {
+		args = append(args, data.Links)
+		sets = append(sets, fmt.Sprintf("links = $%d", len(args)))
+	}
+
+	return
+}
+
+func (r *CandidateRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	query := `
+		UPDATE candidates
+		SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND deleted_at IS NULL AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING id`
+
+	args := []any{id, version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to delete candidate with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *CandidateRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE candidates
+		SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL AND ($2::uuid IS NULL OR tenant_id = $2)
+		RETURNING id`
+
+	args := []any{id, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to restore candidate with id %s: %w", id, err)
+	}
+
 	return
 }
 
-func (r *CandidateRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *CandidateRepository) Purge(ctx context.Context, before time.Time) (dest []candidate.Entity, err error) {
 	query := `
 		DELETE FROM candidates
-		WHERE id = $1
+		WHERE deleted_at < $1
+		RETURNING id, tenant_id`
+
+	args := []any{before}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge candidates: %w", err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing candidate from a stale version
+// after a conditional write has matched no rows
+func (r *CandidateRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM candidates WHERE id = $1 AND deleted_at IS NULL AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check candidate with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
+
+type CandidateNoteRepository struct {
+	db *sqlx.DB
+}
+
+func NewCandidateNoteRepository(db *sqlx.DB) *CandidateNoteRepository {
+	return &CandidateNoteRepository{
+		db: db,
+	}
+}
+
+func (r *CandidateNoteRepository) List(ctx context.Context, candidateID string) (dest []candidate.Note, err error) {
+	query := `
+		SELECT id, tenant_id, candidate_id, author_id, text, created_at
+		FROM candidate_notes
+		WHERE candidate_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+		ORDER BY created_at DESC, id`
+
+	args := []any{candidateID, tenant(ctx)}
+
+	dest = make([]candidate.Note, 0)
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes of candidate %s: %w", candidateID, err)
+	}
+
+	return
+}
+
+func (r *CandidateNoteRepository) Add(ctx context.Context, data candidate.Note) (id string, err error) {
+	query := `
+		INSERT INTO candidate_notes (tenant_id, candidate_id, author_id, text, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.CandidateID, data.AuthorID, data.Text, data.CreatedAt}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add candidate note: %w", err)
+	}
+
+	return
+}
+
+func (r *CandidateNoteRepository) Get(ctx context.Context, id string) (dest candidate.Note, err error) {
+	query := `
+		SELECT id, tenant_id, candidate_id, author_id, text, created_at
+		FROM candidate_notes
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get candidate note with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *CandidateNoteRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM candidate_notes
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
 		RETURNING id`
 
-	args := []any{id}
+	args := []any{id, tenant(ctx)}
 
 	var returnedID string
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return store.ErrorNotFound
 		}
-		return fmt.Errorf("failed to delete candidate with id %s: %w", id, err)
+		return fmt.Errorf("failed to delete candidate note with id %s: %w", id, err)
+	}
+
+	return
+}
+
+type CandidateAttachmentRepository struct {
+	db *sqlx.DB
+}
+
+func NewCandidateAttachmentRepository(db *sqlx.DB) *CandidateAttachmentRepository {
+	return &CandidateAttachmentRepository{
+		db: db,
+	}
+}
+
+func (r *CandidateAttachmentRepository) List(ctx context.Context, candidateID string) (dest []candidate.Attachment, err error) {
+	query := `
+		SELECT id, tenant_id, candidate_id, file_name, content_type, size, checksum, uploaded_at
+		FROM candidate_attachments
+		WHERE candidate_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+		ORDER BY uploaded_at DESC, id`
+
+	args := []any{candidateID, tenant(ctx)}
+
+	dest = make([]candidate.Attachment, 0)
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments of candidate %s: %w", candidateID, err)
+	}
+
+	return
+}
+
+func (r *CandidateAttachmentRepository) Add(ctx context.Context, data candidate.Attachment) (err error) {
+	query := `
+		INSERT INTO candidate_attachments (id, tenant_id, candidate_id, file_name, content_type, size, checksum, uploaded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	args := []any{data.ID, tenant(ctx), data.CandidateID, data.FileName, data.ContentType, data.Size, data.Checksum, data.UploadedAt}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to add candidate attachment: %w", err)
+	}
+
+	return
+}
+
+func (r *CandidateAttachmentRepository) Get(ctx context.Context, id string) (dest candidate.Attachment, err error) {
+	query := `
+		SELECT id, tenant_id, candidate_id, file_name, content_type, size, checksum, uploaded_at
+		FROM candidate_attachments
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get candidate attachment with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *CandidateAttachmentRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM candidate_attachments
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+		RETURNING id`
+
+	args := []any{id, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to delete candidate attachment with id %s: %w", id, err)
 	}
 
 	return
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/idempotency"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: db,
+	}
+}
+
+func (r *IdempotencyRepository) Add(ctx context.Context, data idempotency.Entity) (err error) {
+	// an expired key is taken over by the new request
+	query := `
+		INSERT INTO idempotency_keys (key, fingerprint, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = 0, content_type = '', body = NULL,
+			created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+		RETURNING key`
+
+	args := []any{data.Key, data.Fingerprint, data.ExpiresAt}
+
+	var returnedKey string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedKey, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorConflict
+		}
+		return fmt.Errorf("failed to add idempotency key %s: %w", data.Key, err)
+	}
+
+	return
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, key string) (dest idempotency.Entity, err error) {
+	query := `
+		SELECT key, fingerprint, status_code, content_type, COALESCE(body, '') AS body, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1 AND expires_at > CURRENT_TIMESTAMP`
+
+	args := []any{key}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get idempotency key %s: %w", key, err)
+	}
+
+	return
+}
+
+func (r *IdempotencyRepository) Update(ctx context.Context, key string, data idempotency.Entity) (err error) {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $2, content_type = $3, body = $4
+		WHERE key = $1
+		RETURNING key`
+
+	args := []any{key, data.StatusCode, data.ContentType, data.Body}
+
+	var returnedKey string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedKey, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to update idempotency key %s: %w", key, err)
+	}
+
+	return
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, key string) (err error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1
+		RETURNING key`
+
+	args := []any{key}
+
+	var returnedKey string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedKey, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to delete idempotency key %s: %w", key, err)
+	}
+
+	return
+}
+
+func (r *IdempotencyRepository) Purge(ctx context.Context, before time.Time) (count int64, err error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE expires_at < $1`
+
+	args := []any{before}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
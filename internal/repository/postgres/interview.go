@@ -0,0 +1,449 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/pkg/store"
+	"sort"
+	"strings"
+)
+
+type InterviewRepository struct {
+	db *sqlx.DB
+}
+
+func NewInterviewRepository(db *sqlx.DB) *InterviewRepository {
+	return &InterviewRepository{
+		db: db,
+	}
+}
+
+const interviewColumns = `id, tenant_id, candidate_id, recruiter_id, title, location, meeting_url, starts_at, ends_at, status, version`
+
+func (r *InterviewRepository) List(ctx context.Context, filter interview.Filter) (dest []interview.Entity, err error) {
+	wheres := []string{tenantCond("tenant_id", 1)}
+	args := []any{tenant(ctx)}
+	if filter.CandidateID != "" {
+		args = append(args, filter.CandidateID)
+		wheres = append(wheres, fmt.Sprintf("candidate_id = $%d", len(args)))
+	}
+	if filter.RecruiterID != "" {
+		args = append(args, interview.ParticipantRecruiter, filter.RecruiterID)
+		wheres = append(wheres, fmt.Sprintf("id IN (SELECT interview_id FROM interview_participants WHERE participant_type = $%d AND participant_id = $%d)", len(args)-1, len(args)))
+	}
+	if filter.ResourceID != "" {
+		args = append(args, filter.ResourceID)
+		wheres = append(wheres, fmt.Sprintf("id IN (SELECT interview_id FROM interview_resources WHERE resource_id = $%d)", len(args)))
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		wheres = append(wheres, fmt.Sprintf("ends_at > $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		wheres = append(wheres, fmt.Sprintf("starts_at < $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		wheres = append(wheres, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `
+		SELECT ` + interviewColumns + `
+		FROM interviews`
+	query += " WHERE " + strings.Join(wheres, " AND ")
+	query += " ORDER BY starts_at"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interviews: %w", err)
+	}
+
+	if err = r.loadParticipants(ctx, dest); err != nil {
+		return nil, err
+	}
+	if err = r.loadResources(ctx, dest); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+func (r *InterviewRepository) Add(ctx context.Context, data interview.Entity) (id string, err error) {
+	query := `
+		INSERT INTO interviews (id, tenant_id, candidate_id, recruiter_id, title, location, meeting_url, starts_at, ends_at, status)
+		VALUES (COALESCE(NULLIF($1, '')::UUID, gen_random_uuid()), $2, $3, $4, $5, $6, COALESCE($7, ''), $8, $9, $10)
+		RETURNING id`
+
+	args := []any{data.ID, tenant(ctx), data.CandidateID, data.RecruiterID, data.Title, data.Location, data.MeetingURL, data.StartsAt, data.EndsAt, data.Status}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add interview: %w", err)
+	}
+
+	if err = r.addParticipants(ctx, id, data.Participants); err != nil {
+		return "", err
+	}
+	if err = r.addResources(ctx, id, data.ResourceIDs); err != nil {
+		return "", err
+	}
+
+	return
+}
+
+func (r *InterviewRepository) Get(ctx context.Context, id string) (dest interview.Entity, err error) {
+	query := `
+		SELECT ` + interviewColumns + `
+		FROM interviews
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get interview with id %s: %w", id, err)
+	}
+
+	entities := []interview.Entity{dest}
+	if err = r.loadParticipants(ctx, entities); err != nil {
+		return
+	}
+	if err = r.loadResources(ctx, entities); err != nil {
+		return
+	}
+	dest = entities[0]
+
+	return
+}
+
+func (r *InterviewRepository) Update(ctx context.Context, id string, data interview.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) == 0 && data.Participants == nil && data.ResourceIDs == nil {
+		return errors.New("no fields to update")
+	}
+
+	args = append(args, id, data.Version, tenant(ctx))
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP", "version = version + 1")
+
+	setClause := strings.Join(sets, ", ")
+	argPosition := len(args)
+
+	query := fmt.Sprintf("UPDATE interviews SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) AND %s RETURNING id", setClause, argPosition-2, argPosition-1, argPosition-1, tenantCond("tenant_id", argPosition))
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to update interview with id %s: %w", id, err)
+	}
+
+	if data.Participants != nil {
+		query = `
+		DELETE FROM interview_participants
+		WHERE interview_id = $1`
+
+		if _, err = conn(ctx, r.db).ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("failed to delete participants of interview with id %s: %w", id, err)
+		}
+		if err = r.addParticipants(ctx, id, data.Participants); err != nil {
+			return
+		}
+	}
+
+	if data.ResourceIDs != nil {
+		query = `
+		DELETE FROM interview_resources
+		WHERE interview_id = $1`
+
+		if _, err = conn(ctx, r.db).ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("failed to delete resources of interview with id %s: %w", id, err)
+		}
+		if err = r.addResources(ctx, id, data.ResourceIDs); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (r *InterviewRepository) Respond(ctx context.Context, id string, data interview.Participant) (err error) {
+	q := conn(ctx, r.db)
+
+	query := `
+		UPDATE interview_participants
+		SET status = $4, responded_at = $5
+		WHERE interview_id = $1 AND participant_type = $2 AND participant_id = $3
+			AND EXISTS (SELECT 1 FROM interviews WHERE id = $1 AND ($6::uuid IS NULL OR tenant_id = $6))`
+
+	args := []any{id, data.Type, data.ID, data.Status, data.RespondedAt, tenant(ctx)}
+
+	result, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to respond to interview with id %s: %w", id, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to respond to interview with id %s: %w", id, err)
+	} else if rows == 0 {
+		return store.ErrorNotFound
+	}
+
+	query = `
+		UPDATE interviews
+		SET updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1`
+
+	if _, err = q.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to update interview with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *InterviewRepository) addParticipants(ctx context.Context, id string, data []interview.Participant) (err error) {
+	if len(data) == 0 {
+		return
+	}
+
+	values := make([]string, 0, len(data))
+	args := make([]any, 0, 6*len(data))
+	for _, participant := range data {
+		args = append(args, id, participant.Type, participant.ID, participant.Position, participant.Status, participant.RespondedAt)
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", n-5, n-4, n-3, n-2, n-1, n))
+	}
+
+	query := `
+		INSERT INTO interview_participants (interview_id, participant_type, participant_id, position, status, responded_at)
+		VALUES ` + strings.Join(values, ", ")
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add participants of interview with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *InterviewRepository) addResources(ctx context.Context, id string, resourceIDs []string) (err error) {
+	if len(resourceIDs) == 0 {
+		return
+	}
+
+	values := make([]string, 0, len(resourceIDs))
+	args := make([]any, 0, 2*len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		args = append(args, id, resourceID)
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d)", n-1, n))
+	}
+
+	query := `
+		INSERT INTO interview_resources (interview_id, resource_id)
+		VALUES ` + strings.Join(values, ", ")
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add resources of interview with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// loadResources fills in the resources reserved for the interviews
+func (r *InterviewRepository) loadResources(ctx context.Context, data []interview.Entity) (err error) {
+	if len(data) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(data))
+	for _, entity := range data {
+		ids = append(ids, entity.ID)
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT interview_id, resource_id
+		FROM interview_resources
+		WHERE interview_id IN (?)
+		ORDER BY resource_id`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build resources query: %w", err)
+	}
+
+	var rows []struct {
+		InterviewID string `db:"interview_id"`
+		ResourceID  string `db:"resource_id"`
+	}
+	err = conn(ctx, r.db).SelectContext(ctx, &rows, r.db.Rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to list resources of interviews: %w", err)
+	}
+
+	byInterview := make(map[string][]string, len(data))
+	for _, row := range rows {
+		byInterview[row.InterviewID] = append(byInterview[row.InterviewID], row.ResourceID)
+	}
+	for i := range data {
+		data[i].ResourceIDs = byInterview[data[i].ID]
+	}
+
+	return
+}
+
+// loadParticipants fills in the participants of the interviews
+func (r *InterviewRepository) loadParticipants(ctx context.Context, data []interview.Entity) (err error) {
+	if len(data) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(data))
+	for _, entity := range data {
+		ids = append(ids, entity.ID)
+	}
+
+	query, args, err := sqlx.In(`
+		SELECT interview_id, participant_type, participant_id, position, status, responded_at
+		FROM interview_participants
+		WHERE interview_id IN (?)
+		ORDER BY position`, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build participants query: %w", err)
+	}
+
+	var participants []interview.Participant
+	err = conn(ctx, r.db).SelectContext(ctx, &participants, r.db.Rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to list participants of interviews: %w", err)
+	}
+
+	byInterview := make(map[string][]interview.Participant, len(data))
+	for _, participant := range participants {
+		byInterview[participant.InterviewID] = append(byInterview[participant.InterviewID], participant)
+	}
+	for i := range data {
+		data[i].Participants = byInterview[data[i].ID]
+	}
+
+	return
+}
+
+func (r *InterviewRepository) prepareArgs(data interview.Entity) (sets []string, args []any) {
+	if data.RecruiterID != nil {
+		args = append(args, data.RecruiterID)
+		sets = append(sets, fmt.Sprintf("recruiter_id = $%d", len(args)))
+	}
+
+	if data.Title != nil {
+		args = append(args, data.Title)
+		sets = append(sets, fmt.Sprintf("title = $%d", len(args)))
+	}
+
+	if data.Location != nil {
+		args = append(args, data.Location)
+		sets = append(sets, fmt.Sprintf("location = $%d", len(args)))
+	}
+
+	if data.MeetingURL != nil {
+		args = append(args, data.MeetingURL)
+		sets = append(sets, fmt.Sprintf("meeting_url = $%d", len(args)))
+	}
+
+	if data.StartsAt != nil {
+		args = append(args, data.StartsAt)
+		sets = append(sets, fmt.Sprintf("starts_at = $%d", len(args)))
+	}
+
+	if data.EndsAt != nil {
+		args = append(args, data.EndsAt)
+		sets = append(sets, fmt.Sprintf("ends_at = $%d", len(args)))
+	}
+
+	if data.Status != nil {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	return
+}
+
+// LockSchedules takes transaction scoped advisory locks on the ids in a stable order,
+// so that two bookings of the same person cannot both pass the conflict check
+func (r *InterviewRepository) LockSchedules(ctx context.Context, ids ...string) (err error) {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+
+	for _, id := range sorted {
+		query := `SELECT pg_advisory_xact_lock(hashtext($1))`
+
+		if _, err = conn(ctx, r.db).ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("failed to lock schedule of %s: %w", id, err)
+		}
+	}
+
+	return
+}
+
+func (r *InterviewRepository) Purge(ctx context.Context, participantType, id string) (err error) {
+	query := `
+		DELETE FROM interviews
+		WHERE candidate_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	if participantType == interview.ParticipantRecruiter {
+		// the statements of the query see the participants as they were
+		// before the delete, so the purged recruiter is left out explicitly
+		query = `
+		WITH removed AS (
+			DELETE FROM interview_participants
+			WHERE participant_type = $3 AND participant_id = $1
+				AND interview_id IN (SELECT id FROM interviews WHERE $2::uuid IS NULL OR tenant_id = $2)
+			RETURNING interview_id
+		)
+		UPDATE interviews i
+		SET recruiter_id = (
+				SELECT p.participant_id FROM interview_participants p
+				WHERE p.interview_id = i.id AND p.participant_type = $3 AND p.participant_id <> $1
+				ORDER BY p.position
+				LIMIT 1
+			),
+			updated_at = CURRENT_TIMESTAMP,
+			version = version + 1
+		WHERE i.id IN (SELECT interview_id FROM removed)`
+	}
+
+	args := []any{id, tenant(ctx)}
+	if participantType == interview.ParticipantRecruiter {
+		args = append(args, participantType)
+	}
+
+	if _, err = conn(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to purge %s %s from interviews: %w", participantType, id, err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing interview from a stale version
+// after a conditional write has matched no rows
+func (r *InterviewRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM interviews WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check interview with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
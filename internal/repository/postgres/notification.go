@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/pkg/store"
+	"strings"
+	"time"
+)
+
+type NotificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+const notificationColumns = `id, tenant_id, channel, event_type, recipient, locale, subject, body, status, attempts,
+		last_error, next_attempt_at, sent_at, created_at`
+
+func (r *NotificationRepository) List(ctx context.Context, filter notification.Filter) (dest []notification.Entity, err error) {
+	wheres := []string{tenantCond("tenant_id", 1)}
+	args := []any{tenant(ctx)}
+	if filter.Channel != "" {
+		args = append(args, filter.Channel)
+		wheres = append(wheres, fmt.Sprintf("channel = $%d", len(args)))
+	}
+	if filter.Recipient != "" {
+		args = append(args, filter.Recipient)
+		wheres = append(wheres, fmt.Sprintf("recipient = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		wheres = append(wheres, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications`
+	query += " WHERE " + strings.Join(wheres, " AND ")
+	query += " ORDER BY created_at"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return
+}
+
+func (r *NotificationRepository) Add(ctx context.Context, data notification.Entity) (id string, err error) {
+	query := `
+		INSERT INTO notifications (tenant_id, channel, event_type, recipient, locale, subject, body, status, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.Channel, data.EventType, data.Recipient, data.Locale, data.Subject, data.Body, data.Status, data.NextAttemptAt}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add notification: %w", err)
+	}
+
+	return
+}
+
+func (r *NotificationRepository) Get(ctx context.Context, id string) (dest notification.Entity, err error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get notification with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *NotificationRepository) Update(ctx context.Context, id string, data notification.Entity) (err error) {
+	query := `
+		UPDATE notifications
+		SET status = $2, attempts = $3, last_error = $4, next_attempt_at = $5, sent_at = $6
+		WHERE id = $1 AND ($7::uuid IS NULL OR tenant_id = $7)
+		RETURNING id`
+
+	args := []any{id, data.Status, data.Attempts, data.LastError, data.NextAttemptAt, data.SentAt, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to update notification with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *NotificationRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (dest []notification.Entity, err error) {
+	query := `
+		UPDATE notifications
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id
+			FROM notifications
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + notificationColumns
+
+	args := []any{now, now.Add(lease), limit}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim notifications: %w", err)
+	}
+
+	return
+}
+
+type NotificationOptOutRepository struct {
+	db *sqlx.DB
+}
+
+func NewNotificationOptOutRepository(db *sqlx.DB) *NotificationOptOutRepository {
+	return &NotificationOptOutRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationOptOutRepository) List(ctx context.Context, channel string) (dest []notification.OptOut, err error) {
+	query := `
+		SELECT tenant_id, channel, recipient, created_at
+		FROM notification_opt_outs
+		WHERE ($1 = '' OR channel = $1) AND ($2::uuid IS NULL OR tenant_id = $2)
+		ORDER BY created_at`
+
+	args := []any{channel, tenant(ctx)}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification opt-outs: %w", err)
+	}
+
+	return
+}
+
+func (r *NotificationOptOutRepository) Add(ctx context.Context, data notification.OptOut) (err error) {
+	query := `
+		INSERT INTO notification_opt_outs (tenant_id, channel, recipient)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, channel, recipient) DO NOTHING`
+
+	args := []any{tenant(ctx), data.Channel, data.Recipient}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to add notification opt-out: %w", err)
+	}
+
+	return
+}
+
+func (r *NotificationOptOutRepository) Delete(ctx context.Context, channel, recipient string) (err error) {
+	query := `
+		DELETE FROM notification_opt_outs
+		WHERE channel = $1 AND recipient = $2 AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING recipient`
+
+	args := []any{channel, recipient, tenant(ctx)}
+
+	var returnedRecipient string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedRecipient, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to delete notification opt-out: %w", err)
+	}
+
+	return
+}
+
+func (r *NotificationOptOutRepository) Exists(ctx context.Context, channel, recipient string) (exists bool, err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM notification_opt_outs WHERE channel = $1 AND recipient = $2 AND ($3::uuid IS NULL OR tenant_id = $3))`
+
+	args := []any{channel, recipient, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to check notification opt-out: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+)
+
+type OrganizationRepository struct {
+	db *sqlx.DB
+}
+
+func NewOrganizationRepository(db *sqlx.DB) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+func (r *OrganizationRepository) List(ctx context.Context) (dest []organization.Entity, err error) {
+	query := `
+		SELECT id, name, status, created_at, version
+		FROM organizations
+		ORDER BY created_at`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return
+}
+
+func (r *OrganizationRepository) Add(ctx context.Context, data organization.Entity) (id string, err error) {
+	query := `
+		INSERT INTO organizations (name, status, created_at)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	args := []any{data.Name, data.Status, data.CreatedAt}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add organization: %w", err)
+	}
+
+	return
+}
+
+func (r *OrganizationRepository) Get(ctx context.Context, id string) (dest organization.Entity, err error) {
+	query := `
+		SELECT id, name, status, created_at, version
+		FROM organizations
+		WHERE id = $1`
+
+	args := []any{id}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get organization with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, id string, data organization.Entity) (err error) {
+	query := `
+		UPDATE organizations
+		SET name = $1, status = $2, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $3 AND ($4 = 0 OR version = $4)
+		RETURNING id`
+
+	args := []any{data.Name, data.Status, id, data.Version}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to update organization with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing organization from a stale version
+// after a conditional write has matched no rows
+func (r *OrganizationRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM organizations WHERE id = $1)`
+
+	args := []any{id}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check organization with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
+
+type OrganizationKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewOrganizationKeyRepository(db *sqlx.DB) *OrganizationKeyRepository {
+	return &OrganizationKeyRepository{
+		db: db,
+	}
+}
+
+func (r *OrganizationKeyRepository) List(ctx context.Context, organizationID string) (dest []organization.Key, err error) {
+	query := `
+		SELECT id, organization_id, recruiter_id, name, prefix, secret_hash, created_at, revoked_at
+		FROM organization_keys
+		WHERE organization_id = $1
+		ORDER BY created_at`
+
+	args := []any{organizationID}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys of organization %s: %w", organizationID, err)
+	}
+
+	return
+}
+
+func (r *OrganizationKeyRepository) Add(ctx context.Context, data organization.Key) (id string, err error) {
+	query := `
+		INSERT INTO organization_keys (organization_id, recruiter_id, name, prefix, secret_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{data.OrganizationID, data.RecruiterID, data.Name, data.Prefix, data.SecretHash, data.CreatedAt}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add key of organization %s: %w", data.OrganizationID, err)
+	}
+
+	return
+}
+
+func (r *OrganizationKeyRepository) GetBySecret(ctx context.Context, secretHash string) (dest organization.Key, err error) {
+	query := `
+		SELECT id, organization_id, recruiter_id, name, prefix, secret_hash, created_at, revoked_at
+		FROM organization_keys
+		WHERE secret_hash = $1`
+
+	args := []any{secretHash}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get key: %w", err)
+	}
+
+	return
+}
+
+func (r *OrganizationKeyRepository) Revoke(ctx context.Context, organizationID, id string) (err error) {
+	query := `
+		UPDATE organization_keys
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND organization_id = $2 AND revoked_at IS NULL
+		RETURNING id`
+
+	args := []any{id, organizationID}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to revoke key with id %s: %w", id, err)
+	}
+
+	return
+}
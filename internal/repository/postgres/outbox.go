@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/outbox"
+	"time"
+)
+
+type OutboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, data outbox.Entity) (id int64, err error) {
+	query := `
+		INSERT INTO outbox (event_type, payload)
+		VALUES ($1, $2)
+		RETURNING id`
+
+	args := []any{data.EventType, data.Payload}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add outbox entry: %w", err)
+	}
+
+	return
+}
+
+func (r *OutboxRepository) ListPending(ctx context.Context, limit int) (dest []outbox.Entity, err error) {
+	query := `
+		SELECT id, event_type, payload, created_at, published_at
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+
+	args := []any{limit}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending outbox entries: %w", err)
+	}
+
+	return
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, ids []int64, at time.Time) (err error) {
+	query, args, err := sqlx.In(`
+		UPDATE outbox
+		SET published_at = ?
+		WHERE id IN (?)`, at, ids)
+	if err != nil {
+		return fmt.Errorf("failed to build outbox update: %w", err)
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, r.db.Rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox entries published: %w", err)
+	}
+
+	return
+}
+
+func (r *OutboxRepository) Purge(ctx context.Context, before time.Time) (count int64, err error) {
+	query := `
+		DELETE FROM outbox
+		WHERE published_at < $1`
+
+	args := []any{before}
+
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox entries: %w", err)
+	}
+
+	return res.RowsAffected()
+}
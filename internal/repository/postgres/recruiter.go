@@ -9,6 +9,7 @@ import (
 	"reservation-system/internal/domain/recruiter"
 	"reservation-system/pkg/store"
 	"strings"
+	"time"
 )
 
 type RecruiterRepository struct {
@@ -21,13 +22,29 @@ func NewRecruiterRepository(db *sqlx.DB) *RecruiterRepository {
 	}
 }
 
-func (r *RecruiterRepository) List(ctx context.Context) (dest []recruiter.Entity, err error) {
+func (r *RecruiterRepository) List(ctx context.Context, filter recruiter.Filter) (dest []recruiter.Entity, err error) {
 	query := `
-		SELECT id, full_name, email, phone
-		FROM recruiters
-		ORDER BY id`
+		SELECT id, tenant_id, full_name, email, phone, title, team, interview_types, working_hours, max_interviews_per_day, max_interviews_per_week, out_of_office, deleted_at, version
+		FROM recruiters`
+
+	var conds []string
+	args := []any{tenant(ctx)}
+	conds = append(conds, tenantCond("tenant_id", 1))
+	if !filter.IncludeDeleted {
+		conds = append(conds, "deleted_at IS NULL")
+	}
+	if filter.Team != "" {
+		args = append(args, filter.Team)
+		conds = append(conds, fmt.Sprintf("LOWER(team) = LOWER($%d)", len(args)))
+	}
+	if filter.InterviewType != "" {
+		args = append(args, strings.ToLower(filter.InterviewType))
+		conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements_text(interview_types) AS type WHERE LOWER(type) = $%d)", len(args)))
+	}
+	query += " WHERE " + strings.Join(conds, " AND ")
+	query += " ORDER BY id"
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list candidates: %w", err)
 	}
@@ -37,13 +54,21 @@ func (r *RecruiterRepository) List(ctx context.Context) (dest []recruiter.Entity
 
 func (r *RecruiterRepository) Add(ctx context.Context, data recruiter.Entity) (id string, err error) {
 	query := `
-		INSERT INTO recruiters (full_name, email, phone)
-		VALUES ($1, $2, $3)
+		INSERT INTO recruiters (tenant_id, full_name, email, phone, title, team, interview_types, working_hours, max_interviews_per_day, max_interviews_per_week, out_of_office)
+		VALUES ($1, $2, $3, $4, COALESCE($5, ''), COALESCE($6, ''), $7, $8, COALESCE($9, 0), COALESCE($10, 0), $11)
 		RETURNING id`
 
-	args := []any{data.FullName, data.Email, data.Phone}
+	interviewTypes, outOfOffice := data.InterviewTypes, data.OutOfOffice
+	if interviewTypes == nil {
+		interviewTypes = &recruiter.Strings{}
+	}
+	if outOfOffice == nil {
+		outOfOffice = &recruiter.Absences{}
+	}
+
+	args := []any{tenant(ctx), data.FullName, data.Email, data.Phone, data.Title, data.Team, interviewTypes, data.WorkingHours, data.MaxPerDay, data.MaxPerWeek, outOfOffice}
 
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
 	if err != nil {
 		return "", fmt.Errorf("failed to add candidate: %w", err)
 	}
@@ -53,13 +78,13 @@ func (r *RecruiterRepository) Add(ctx context.Context, data recruiter.Entity) (i
 
 func (r *RecruiterRepository) Get(ctx context.Context, id string) (dest recruiter.Entity, err error) {
 	query := `
-		SELECT id, full_name, email, phone
+		SELECT id, tenant_id, full_name, email, phone, title, team, interview_types, working_hours, max_interviews_per_day, max_interviews_per_week, out_of_office, deleted_at, version
 		FROM recruiters
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL AND ($2::uuid IS NULL OR tenant_id = $2)`
 
-	args := []any{id}
+	args := []any{id, tenant(ctx)}
 
-	err = r.db.GetContext(ctx, &dest, query, args...)
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return dest, store.ErrorNotFound
@@ -76,19 +101,19 @@ func (r *RecruiterRepository) Update(ctx context.Context, id string, data recrui
 		return errors.New("no fields to update")
 	}
 
-	args = append(args, id)
-	sets = append(sets, "updated_at = CURRENT_TIMESTAMP")
+	args = append(args, id, data.Version, tenant(ctx))
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP", "version = version + 1")
 
 	setClause := strings.Join(sets, ", ")
 	argPosition := len(args)
 
-	query := fmt.Sprintf("UPDATE recruiters SET %s WHERE id = $%d RETURNING id", setClause, argPosition)
+	query := fmt.Sprintf("UPDATE recruiters SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) AND deleted_at IS NULL AND %s RETURNING id", setClause, argPosition-2, argPosition-1, argPosition-1, tenantCond("tenant_id", argPosition))
 
 	var returnedID string
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return store.ErrorNotFound
+			return r.conflictOrNotFound(ctx, id)
 		}
 		return fmt.Errorf("failed to update candidate with id %s: %w", id, err)
 	}
@@ -112,25 +137,118 @@ func (r *RecruiterRepository) prepareArgs(data recruiter.Entity) (sets []string,
 		sets = append(sets, fmt.Sprintf("phone = $%d", len(args)))
 	}
 
+	if data.Title != nil {
+		args = append(args, data.Title)
+		sets = append(sets, fmt.Sprintf("title = $%d", len(args)))
+	}
+
+	if data.Team != nil {
+		args = append(args, data.Team)
+		sets = append(sets, fmt.Sprintf("team = $%d", len(args)))
+	}
+
+	if data.InterviewTypes != nil {
+		args = append(args, data.InterviewTypes)
+		sets = append(sets, fmt.Sprintf("interview_types = $%d", len(args)))
+	}
+
+	// an empty schedule is stored as NULL, restoring the default working hours
+	if data.WorkingHours != nil {
+		args = append(args, data.WorkingHours)
+		sets = append(sets, fmt.Sprintf("working_hours = $%d", len(args)))
+	}
+
+	if data.MaxPerDay != nil {
+		args = append(args, data.MaxPerDay)
+		sets = append(sets, fmt.Sprintf("max_interviews_per_day = $%d", len(args)))
+	}
+
+	if data.MaxPerWeek != nil {
+		args = append(args, data.MaxPerWeek)
+		sets = append(sets, fmt.Sprintf("max_interviews_per_week = $%d", len(args)))
+	}
+
+	if data.OutOfOffice != nil {
+		args = append(args, data.OutOfOffice)
+		sets = append(sets, fmt.Sprintf("out_of_office = $%d", len(args)))
+	}
+
 	return
 }
 
-func (r *RecruiterRepository) Delete(ctx context.Context, id string) (err error) {
+func (r *RecruiterRepository) Delete(ctx context.Context, id string, version int) (err error) {
 	query := `
-		DELETE FROM recruiters
-		WHERE id = $1
+		UPDATE recruiters
+		SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND deleted_at IS NULL AND ($3::uuid IS NULL OR tenant_id = $3)
 		RETURNING id`
 
-	args := []any{id}
+	args := []any{id, version, tenant(ctx)}
 
 	var returnedID string
-	err = r.db.QueryRowContext(ctx, query, args...).Scan(&returnedID)
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return store.ErrorNotFound
+			return r.conflictOrNotFound(ctx, id)
 		}
 		return fmt.Errorf("failed to delete candidate with id %s: %w", id, err)
 	}
 
 	return
 }
+
+func (r *RecruiterRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE recruiters
+		SET deleted_at = NULL, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND deleted_at IS NOT NULL AND ($2::uuid IS NULL OR tenant_id = $2)
+		RETURNING id`
+
+	args := []any{id, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to restore recruiter with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *RecruiterRepository) Purge(ctx context.Context, before time.Time) (dest []recruiter.Entity, err error) {
+	query := `
+		DELETE FROM recruiters
+		WHERE deleted_at < $1
+		RETURNING id, tenant_id`
+
+	args := []any{before}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge recruiters: %w", err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing recruiter from a stale version
+// after a conditional write has matched no rows
+func (r *RecruiterRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM recruiters WHERE id = $1 AND deleted_at IS NULL AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check recruiter with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/reminder"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+type ReminderRepository struct {
+	db *sqlx.DB
+}
+
+func NewReminderRepository(db *sqlx.DB) *ReminderRepository {
+	return &ReminderRepository{
+		db: db,
+	}
+}
+
+func (r *ReminderRepository) Add(ctx context.Context, data reminder.Entity) (err error) {
+	query := `
+		INSERT INTO interview_reminders (interview_id, offset_seconds, starts_at, due_at, status)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (interview_id, offset_seconds, starts_at) DO NOTHING`
+
+	args := []any{data.InterviewID, data.OffsetSeconds, data.StartsAt, data.DueAt, data.Status}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to add reminder: %w", err)
+	}
+
+	return
+}
+
+func (r *ReminderRepository) ClaimDue(ctx context.Context, now time.Time, limit int) (dest []reminder.Entity, err error) {
+	query := `
+		SELECT id, interview_id, offset_seconds, starts_at, due_at, status, sent_at, created_at
+		FROM interview_reminders
+		WHERE status = 'pending' AND due_at <= $1
+		ORDER BY due_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`
+
+	args := []any{now, limit}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim reminders: %w", err)
+	}
+
+	return
+}
+
+func (r *ReminderRepository) Update(ctx context.Context, id string, data reminder.Entity) (err error) {
+	query := `
+		UPDATE interview_reminders
+		SET status = $2, sent_at = $3
+		WHERE id = $1
+		RETURNING id`
+
+	args := []any{id, data.Status, data.SentAt}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to update reminder with id %s: %w", id, err)
+	}
+
+	return
+}
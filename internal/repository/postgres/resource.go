@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/pkg/store"
+	"strings"
+)
+
+type ResourceRepository struct {
+	db *sqlx.DB
+}
+
+func NewResourceRepository(db *sqlx.DB) *ResourceRepository {
+	return &ResourceRepository{
+		db: db,
+	}
+}
+
+const resourceColumns = `id, tenant_id, name, kind, capacity, location, equipment, version`
+
+func (r *ResourceRepository) List(ctx context.Context, filter resource.Filter) (dest []resource.Entity, err error) {
+	wheres := []string{tenantCond("tenant_id", 1)}
+	args := []any{tenant(ctx)}
+	if filter.Kind != "" {
+		args = append(args, filter.Kind)
+		wheres = append(wheres, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	if filter.Location != "" {
+		args = append(args, filter.Location)
+		wheres = append(wheres, fmt.Sprintf("LOWER(location) = LOWER($%d)", len(args)))
+	}
+	if filter.MinCapacity > 0 {
+		args = append(args, filter.MinCapacity)
+		wheres = append(wheres, fmt.Sprintf("(capacity = 0 OR capacity >= $%d)", len(args)))
+	}
+	for _, tag := range filter.Equipment {
+		args = append(args, strings.ToLower(tag))
+		wheres = append(wheres, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements_text(equipment) AS tag WHERE LOWER(tag) = $%d)", len(args)))
+	}
+
+	query := `
+		SELECT ` + resourceColumns + `
+		FROM resources`
+	query += " WHERE " + strings.Join(wheres, " AND ")
+	query += " ORDER BY name"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	return
+}
+
+func (r *ResourceRepository) Add(ctx context.Context, data resource.Entity) (id string, err error) {
+	query := `
+		INSERT INTO resources (tenant_id, name, kind, capacity, location, equipment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.Name, data.Kind, data.Capacity, data.Location, data.Equipment}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add resource: %w", err)
+	}
+
+	return
+}
+
+func (r *ResourceRepository) Get(ctx context.Context, id string) (dest resource.Entity, err error) {
+	query := `
+		SELECT ` + resourceColumns + `
+		FROM resources
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get resource with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *ResourceRepository) Update(ctx context.Context, id string, data resource.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) == 0 {
+		return errors.New("no fields to update")
+	}
+
+	args = append(args, id, data.Version, tenant(ctx))
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP", "version = version + 1")
+
+	setClause := strings.Join(sets, ", ")
+	argPosition := len(args)
+
+	query := fmt.Sprintf("UPDATE resources SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) AND %s RETURNING id", setClause, argPosition-2, argPosition-1, argPosition-1, tenantCond("tenant_id", argPosition))
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to update resource with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *ResourceRepository) prepareArgs(data resource.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name = $%d", len(args)))
+	}
+
+	if data.Kind != nil {
+		args = append(args, data.Kind)
+		sets = append(sets, fmt.Sprintf("kind = $%d", len(args)))
+	}
+
+	if data.Capacity != nil {
+		args = append(args, data.Capacity)
+		sets = append(sets, fmt.Sprintf("capacity = $%d", len(args)))
+	}
+
+	if data.Location != nil {
+		args = append(args, data.Location)
+		sets = append(sets, fmt.Sprintf("location = $%d", len(args)))
+	}
+
+	if data.Equipment != nil {
+		args = append(args, data.Equipment)
+		sets = append(sets, fmt.Sprintf("equipment = $%d", len(args)))
+	}
+
+	return
+}
+
+func (r *ResourceRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	query := `
+		DELETE FROM resources
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING id`
+
+	args := []any{id, version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to delete resource with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing resource from a stale version
+// after a conditional write has matched no rows
+func (r *ResourceRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM resources WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check resource with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/pkg/store"
+	"strings"
+)
+
+type ScorecardTemplateRepository struct {
+	db *sqlx.DB
+}
+
+func NewScorecardTemplateRepository(db *sqlx.DB) *ScorecardTemplateRepository {
+	return &ScorecardTemplateRepository{
+		db: db,
+	}
+}
+
+func (r *ScorecardTemplateRepository) List(ctx context.Context) (dest []scorecard.Template, err error) {
+	query := `
+		SELECT id, tenant_id, name, criteria, version
+		FROM scorecard_templates
+		WHERE $1::uuid IS NULL OR tenant_id = $1
+		ORDER BY name`
+
+	args := []any{tenant(ctx)}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scorecard templates: %w", err)
+	}
+
+	return
+}
+
+func (r *ScorecardTemplateRepository) Add(ctx context.Context, data scorecard.Template) (id string, err error) {
+	query := `
+		INSERT INTO scorecard_templates (tenant_id, name, criteria)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.Name, data.Criteria}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add scorecard template: %w", err)
+	}
+
+	return
+}
+
+func (r *ScorecardTemplateRepository) Get(ctx context.Context, id string) (dest scorecard.Template, err error) {
+	query := `
+		SELECT id, tenant_id, name, criteria, version
+		FROM scorecard_templates
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get scorecard template with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *ScorecardTemplateRepository) Update(ctx context.Context, id string, data scorecard.Template) (err error) {
+	query := `
+		UPDATE scorecard_templates
+		SET name = $1, criteria = $2, updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $3 AND ($4 = 0 OR version = $4) AND ($5::uuid IS NULL OR tenant_id = $5)
+		RETURNING id`
+
+	args := []any{data.Name, data.Criteria, id, data.Version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to update scorecard template with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *ScorecardTemplateRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	query := `
+		DELETE FROM scorecard_templates
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING id`
+
+	args := []any{id, version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to delete scorecard template with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing scorecard template from a stale version
+// after a conditional write has matched no rows
+func (r *ScorecardTemplateRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM scorecard_templates WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check scorecard template with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
+
+type ScorecardRepository struct {
+	db *sqlx.DB
+}
+
+func NewScorecardRepository(db *sqlx.DB) *ScorecardRepository {
+	return &ScorecardRepository{
+		db: db,
+	}
+}
+
+const scorecardColumns = `id, tenant_id, interview_id, recruiter_id, template_id, ratings, recommendation, comment, submitted_at, version`
+
+func (r *ScorecardRepository) List(ctx context.Context, filter scorecard.Filter) (dest []scorecard.Submission, err error) {
+	if filter.InterviewIDs != nil && len(filter.InterviewIDs) == 0 {
+		return
+	}
+
+	wheres := []string{"(CAST(? AS uuid) IS NULL OR tenant_id = ?)"}
+	args := []any{tenant(ctx), tenant(ctx)}
+	if filter.InterviewIDs != nil {
+		in, inArgs, err := sqlx.In("interview_id IN (?)", filter.InterviewIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build scorecards query: %w", err)
+		}
+		wheres = append(wheres, in)
+		args = append(args, inArgs...)
+	}
+	if filter.RecruiterID != "" {
+		args = append(args, filter.RecruiterID)
+		wheres = append(wheres, "recruiter_id = ?")
+	}
+	if filter.TemplateID != "" {
+		args = append(args, filter.TemplateID)
+		wheres = append(wheres, "template_id = ?")
+	}
+
+	query := `
+		SELECT ` + scorecardColumns + `
+		FROM scorecards`
+	query += " WHERE " + strings.Join(wheres, " AND ")
+	query += " ORDER BY submitted_at, id"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, r.db.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scorecards: %w", err)
+	}
+
+	return
+}
+
+func (r *ScorecardRepository) Add(ctx context.Context, data scorecard.Submission) (id string, err error) {
+	query := `
+		INSERT INTO scorecards (tenant_id, interview_id, recruiter_id, template_id, ratings, recommendation, comment, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.InterviewID, data.RecruiterID, data.TemplateID, data.Ratings, data.Recommendation, data.Comment, data.SubmittedAt}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add scorecard: %w", err)
+	}
+
+	return
+}
+
+func (r *ScorecardRepository) Get(ctx context.Context, id string) (dest scorecard.Submission, err error) {
+	query := `
+		SELECT ` + scorecardColumns + `
+		FROM scorecards
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get scorecard with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *ScorecardRepository) Update(ctx context.Context, id string, data scorecard.Submission) (err error) {
+	query := `
+		UPDATE scorecards
+		SET template_id = $1, ratings = $2, recommendation = $3, comment = $4, submitted_at = $5,
+			updated_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $6 AND ($7 = 0 OR version = $7) AND ($8::uuid IS NULL OR tenant_id = $8)
+		RETURNING id`
+
+	args := []any{data.TemplateID, data.Ratings, data.Recommendation, data.Comment, data.SubmittedAt, id, data.Version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to update scorecard with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing scorecard from a stale version
+// after a conditional write has matched no rows
+func (r *ScorecardRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM scorecards WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check scorecard with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
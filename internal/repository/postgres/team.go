@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/team"
+	"reservation-system/pkg/store"
+	"strings"
+)
+
+type TeamRepository struct {
+	db *sqlx.DB
+}
+
+func NewTeamRepository(db *sqlx.DB) *TeamRepository {
+	return &TeamRepository{
+		db: db,
+	}
+}
+
+const teamColumns = `id, tenant_id, name, strategy, last_assigned_id, version`
+
+func (r *TeamRepository) List(ctx context.Context) (dest []team.Entity, err error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams
+		WHERE ($1::uuid IS NULL OR tenant_id = $1)
+		ORDER BY name`
+
+	args := []any{tenant(ctx)}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	return
+}
+
+func (r *TeamRepository) Add(ctx context.Context, data team.Entity) (id string, err error) {
+	query := `
+		INSERT INTO teams (tenant_id, name, strategy)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.Name, data.Strategy}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add team: %w", err)
+	}
+
+	return
+}
+
+func (r *TeamRepository) Get(ctx context.Context, id string) (dest team.Entity, err error) {
+	query := `
+		SELECT ` + teamColumns + `
+		FROM teams
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get team with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *TeamRepository) Update(ctx context.Context, id string, data team.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) == 0 {
+		return errors.New("no fields to update")
+	}
+
+	args = append(args, id, data.Version, tenant(ctx))
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP", "version = version + 1")
+
+	setClause := strings.Join(sets, ", ")
+	argPosition := len(args)
+
+	query := fmt.Sprintf("UPDATE teams SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) AND %s RETURNING id", setClause, argPosition-2, argPosition-1, argPosition-1, tenantCond("tenant_id", argPosition))
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to update team with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *TeamRepository) prepareArgs(data team.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name = $%d", len(args)))
+	}
+
+	if data.Strategy != nil {
+		args = append(args, data.Strategy)
+		sets = append(sets, fmt.Sprintf("strategy = $%d", len(args)))
+	}
+
+	return
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	query := `
+		DELETE FROM teams
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING id`
+
+	args := []any{id, version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to delete team with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *TeamRepository) Advance(ctx context.Context, id, recruiterID string) (err error) {
+	query := `
+		UPDATE teams
+		SET last_assigned_id = $2
+		WHERE id = $1 AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING id`
+
+	args := []any{id, recruiterID, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to advance team with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing team from a stale version
+// after a conditional write has matched no rows
+func (r *TeamRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM teams WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check team with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
+
+type TeamMemberRepository struct {
+	db *sqlx.DB
+}
+
+func NewTeamMemberRepository(db *sqlx.DB) *TeamMemberRepository {
+	return &TeamMemberRepository{
+		db: db,
+	}
+}
+
+func (r *TeamMemberRepository) List(ctx context.Context, teamID string) (dest []team.Member, err error) {
+	query := `
+		SELECT team_id, tenant_id, recruiter_id, joined_at
+		FROM team_members
+		WHERE team_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+		ORDER BY joined_at, recruiter_id`
+
+	args := []any{teamID, tenant(ctx)}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of team %s: %w", teamID, err)
+	}
+
+	return
+}
+
+func (r *TeamMemberRepository) Add(ctx context.Context, data team.Member) (err error) {
+	query := `
+		INSERT INTO team_members (tenant_id, team_id, recruiter_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (team_id, recruiter_id) DO NOTHING
+		RETURNING team_id`
+
+	args := []any{tenant(ctx), data.TeamID, data.RecruiterID}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorConflict
+		}
+		return fmt.Errorf("failed to add recruiter %s to team %s: %w", data.RecruiterID, data.TeamID, err)
+	}
+
+	return
+}
+
+func (r *TeamMemberRepository) Delete(ctx context.Context, teamID, recruiterID string) (err error) {
+	query := `
+		DELETE FROM team_members
+		WHERE team_id = $1 AND recruiter_id = $2 AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING team_id`
+
+	args := []any{teamID, recruiterID, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to remove recruiter %s from team %s: %w", recruiterID, teamID, err)
+	}
+
+	return
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/store"
+)
+
+// noTenant is the id of no organization, the queries of a context that is
+// neither scoped nor marked as system are given it so that they match no row
+const noTenant = "00000000-0000-0000-0000-000000000000"
+
+// tenant returns the organization of ctx as a query argument, it is NULL in
+// a system context so that the condition of tenantCond holds for every row
+func tenant(ctx context.Context) any {
+	if id := organization.TenantFromContext(ctx); id != "" {
+		return id
+	}
+	if organization.IsSystem(ctx) {
+		return nil
+	}
+	return noTenant
+}
+
+// tenantCond scopes the rows of column to the organization passed as the
+// argument at position
+func tenantCond(column string, position int) string {
+	return fmt.Sprintf("($%d::uuid IS NULL OR %s = $%d)", position, column, position)
+}
+
+// scope names the organization of ctx in the settings of the transaction
+// in progress so that the row level security policies apply on top of the
+// conditions of the queries, a system context sets the bypass flag instead
+// and sees every row. The policies match no row when neither is set.
+func scope(ctx context.Context, q store.Querier) (err error) {
+	query := `
+	SELECT set_config('app.tenant_id', $1, true), set_config('app.bypass_rls', $2, true)`
+
+	id, bypass := "", "off"
+	switch value := tenant(ctx).(type) {
+	case string:
+		id = value
+	case nil:
+		bypass = "on"
+	}
+	args := []any{id, bypass}
+
+	if _, err = q.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to scope transaction to organization: %w", err)
+	}
+
+	return
+}
+
+// conn returns the transaction of the unit of work in progress, outside of
+// one the queries run in a scoped transaction of their own
+func conn(ctx context.Context, db *sqlx.DB) store.Querier {
+	if tx, ok := store.Conn(ctx, db).(*sqlx.Tx); ok {
+		return tx
+	}
+	return scopedConn{store: store.SQLX{Client: db}}
+}
+
+// scopedConn runs every query in a transaction scoped to the organization
+// of its context
+type scopedConn struct {
+	store store.SQLX
+}
+
+func (c scopedConn) do(ctx context.Context, fn func(ctx context.Context, q store.Querier) error) error {
+	return c.store.Do(ctx, func(ctx context.Context) (err error) {
+		q := store.Conn(ctx, c.store.Client)
+		if err = scope(ctx, q); err != nil {
+			return
+		}
+		return fn(ctx, q)
+	})
+}
+
+func (c scopedConn) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	return c.do(ctx, func(ctx context.Context, q store.Querier) error {
+		return q.GetContext(ctx, dest, query, args...)
+	})
+}
+
+func (c scopedConn) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return c.do(ctx, func(ctx context.Context, q store.Querier) error {
+		return q.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+func (c scopedConn) ExecContext(ctx context.Context, query string, args ...any) (res sql.Result, err error) {
+	err = c.do(ctx, func(ctx context.Context, q store.Querier) (err error) {
+		res, err = q.ExecContext(ctx, query, args...)
+		return
+	})
+	return
+}
+
+// UnitOfWork scopes the transactions to the organization of their context
+type UnitOfWork struct {
+	store store.SQLX
+}
+
+func NewUnitOfWork(s store.SQLX) *UnitOfWork {
+	return &UnitOfWork{
+		store: s,
+	}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return u.store.Do(ctx, func(ctx context.Context) (err error) {
+		if err = scope(ctx, store.Conn(ctx, u.store.Client)); err != nil {
+			return
+		}
+
+		return fn(ctx)
+	})
+}
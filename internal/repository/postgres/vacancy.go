@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/pkg/store"
+	"strings"
+)
+
+type VacancyRepository struct {
+	db *sqlx.DB
+}
+
+func NewVacancyRepository(db *sqlx.DB) *VacancyRepository {
+	return &VacancyRepository{
+		db: db,
+	}
+}
+
+const vacancyColumns = `id, tenant_id, title, department, owner_id, status, stages, version`
+
+func (r *VacancyRepository) List(ctx context.Context, filter vacancy.Filter) (dest []vacancy.Entity, err error) {
+	wheres := []string{tenantCond("tenant_id", 1)}
+	args := []any{tenant(ctx)}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		wheres = append(wheres, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Department != "" {
+		args = append(args, filter.Department)
+		wheres = append(wheres, fmt.Sprintf("department = $%d", len(args)))
+	}
+	if filter.OwnerID != "" {
+		args = append(args, filter.OwnerID)
+		wheres = append(wheres, fmt.Sprintf("owner_id = $%d", len(args)))
+	}
+
+	query := `
+		SELECT ` + vacancyColumns + `
+		FROM vacancies`
+	query += " WHERE " + strings.Join(wheres, " AND ")
+	query += " ORDER BY title"
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vacancies: %w", err)
+	}
+
+	return
+}
+
+func (r *VacancyRepository) Add(ctx context.Context, data vacancy.Entity) (id string, err error) {
+	query := `
+		INSERT INTO vacancies (tenant_id, title, department, owner_id, status, stages)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.Title, data.Department, data.OwnerID, data.Status, data.Stages}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add vacancy: %w", err)
+	}
+
+	return
+}
+
+func (r *VacancyRepository) Get(ctx context.Context, id string) (dest vacancy.Entity, err error) {
+	query := `
+		SELECT ` + vacancyColumns + `
+		FROM vacancies
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get vacancy with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *VacancyRepository) Update(ctx context.Context, id string, data vacancy.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) == 0 {
+		return errors.New("no fields to update")
+	}
+
+	args = append(args, id, data.Version, tenant(ctx))
+	sets = append(sets, "updated_at = CURRENT_TIMESTAMP", "version = version + 1")
+
+	setClause := strings.Join(sets, ", ")
+	argPosition := len(args)
+
+	query := fmt.Sprintf("UPDATE vacancies SET %s WHERE id = $%d AND ($%d = 0 OR version = $%d) AND %s RETURNING id", setClause, argPosition-2, argPosition-1, argPosition-1, tenantCond("tenant_id", argPosition))
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to update vacancy with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *VacancyRepository) prepareArgs(data vacancy.Entity) (sets []string, args []any) {
+	if data.Title != nil {
+		args = append(args, data.Title)
+		sets = append(sets, fmt.Sprintf("title = $%d", len(args)))
+	}
+
+	if data.Department != nil {
+		args = append(args, data.Department)
+		sets = append(sets, fmt.Sprintf("department = $%d", len(args)))
+	}
+
+	if data.OwnerID != nil {
+		args = append(args, data.OwnerID)
+		sets = append(sets, fmt.Sprintf("owner_id = $%d", len(args)))
+	}
+
+	if data.Status != nil {
+		args = append(args, data.Status)
+		sets = append(sets, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	if data.Stages != nil {
+		args = append(args, data.Stages)
+		sets = append(sets, fmt.Sprintf("stages = $%d", len(args)))
+	}
+
+	return
+}
+
+func (r *VacancyRepository) Delete(ctx context.Context, id string, version int) (err error) {
+	query := `
+		DELETE FROM vacancies
+		WHERE id = $1 AND ($2 = 0 OR version = $2) AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING id`
+
+	args := []any{id, version, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.conflictOrNotFound(ctx, id)
+		}
+		return fmt.Errorf("failed to delete vacancy with id %s: %w", id, err)
+	}
+
+	return
+}
+
+// conflictOrNotFound tells apart a missing vacancy from a stale version
+// after a conditional write has matched no rows
+func (r *VacancyRepository) conflictOrNotFound(ctx context.Context, id string) (err error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM vacancies WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2))`
+
+	args := []any{id, tenant(ctx)}
+
+	var exists bool
+	if err = conn(ctx, r.db).GetContext(ctx, &exists, query, args...); err != nil {
+		return fmt.Errorf("failed to check vacancy with id %s: %w", id, err)
+	}
+	if exists {
+		return store.ErrorConflict
+	}
+
+	return store.ErrorNotFound
+}
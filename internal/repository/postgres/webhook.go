@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"reservation-system/internal/domain/webhook"
+	"reservation-system/pkg/store"
+	"strings"
+	"time"
+)
+
+type WebhookSubscriptionRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookSubscriptionRepository(db *sqlx.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) (dest []webhook.Subscription, err error) {
+	query := `
+		SELECT id, tenant_id, url, event_types, secret, active, created_at
+		FROM webhook_subscriptions
+		WHERE $1::uuid IS NULL OR tenant_id = $1
+		ORDER BY created_at`
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, tenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) Add(ctx context.Context, data webhook.Subscription) (id string, err error) {
+	query := `
+		INSERT INTO webhook_subscriptions (tenant_id, url, event_types, secret, active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	args := []any{tenant(ctx), data.URL, data.EventTypes, data.Secret, data.Active}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add webhook subscription: %w", err)
+	}
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) Get(ctx context.Context, id string) (dest webhook.Subscription, err error) {
+	query := `
+		SELECT id, tenant_id, url, event_types, secret, active, created_at
+		FROM webhook_subscriptions
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`
+
+	args := []any{id, tenant(ctx)}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get webhook subscription with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, id string, data webhook.Subscription) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) == 0 {
+		return errors.New("no fields to update")
+	}
+
+	args = append(args, id, tenant(ctx))
+
+	setClause := strings.Join(sets, ", ")
+	argPosition := len(args)
+
+	query := fmt.Sprintf("UPDATE webhook_subscriptions SET %s WHERE id = $%d AND %s RETURNING id", setClause, argPosition-1, tenantCond("tenant_id", argPosition))
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to update webhook subscription with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) prepareArgs(data webhook.Subscription) (sets []string, args []any) {
+	if data.URL != nil {
+		args = append(args, data.URL)
+		sets = append(sets, fmt.Sprintf("url = $%d", len(args)))
+	}
+
+	if data.EventTypes != nil {
+		args = append(args, data.EventTypes)
+		sets = append(sets, fmt.Sprintf("event_types = $%d", len(args)))
+	}
+
+	if data.Secret != nil {
+		args = append(args, data.Secret)
+		sets = append(sets, fmt.Sprintf("secret = $%d", len(args)))
+	}
+
+	if data.Active != nil {
+		args = append(args, data.Active)
+		sets = append(sets, fmt.Sprintf("active = $%d", len(args)))
+	}
+
+	return
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM webhook_subscriptions
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+		RETURNING id`
+
+	args := []any{id, tenant(ctx)}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to delete webhook subscription with id %s: %w", id, err)
+	}
+
+	return
+}
+
+type WebhookDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookDeliveryRepository(db *sqlx.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db: db,
+	}
+}
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts,
+		response_code, last_error, next_attempt_at, delivered_at, created_at`
+
+func (r *WebhookDeliveryRepository) List(ctx context.Context, subscriptionID string) (dest []webhook.Delivery, err error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at`
+
+	args := []any{subscriptionID}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return
+}
+
+func (r *WebhookDeliveryRepository) Add(ctx context.Context, data webhook.Delivery) (id string, err error) {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{data.SubscriptionID, data.EventID, data.EventType, data.Payload, data.Status, data.NextAttemptAt}
+
+	err = conn(ctx, r.db).GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to add webhook delivery: %w", err)
+	}
+
+	return
+}
+
+func (r *WebhookDeliveryRepository) Get(ctx context.Context, id string) (dest webhook.Delivery, err error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = $1`
+
+	args := []any{id}
+
+	err = conn(ctx, r.db).GetContext(ctx, &dest, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dest, store.ErrorNotFound
+		}
+		return dest, fmt.Errorf("failed to get webhook delivery with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, id string, data webhook.Delivery) (err error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_code = $4, last_error = $5, next_attempt_at = $6, delivered_at = $7
+		WHERE id = $1
+		RETURNING id`
+
+	args := []any{id, data.Status, data.Attempts, data.ResponseCode, data.LastError, data.NextAttemptAt, data.DeliveredAt}
+
+	var returnedID string
+	err = conn(ctx, r.db).GetContext(ctx, &returnedID, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.ErrorNotFound
+		}
+		return fmt.Errorf("failed to update webhook delivery with id %s: %w", id, err)
+	}
+
+	return
+}
+
+func (r *WebhookDeliveryRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) (dest []webhook.Delivery, err error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + webhookDeliveryColumns
+
+	args := []any{now, now.Add(lease), limit}
+
+	err = conn(ctx, r.db).SelectContext(ctx, &dest, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return
+}
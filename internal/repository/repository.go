@@ -1,9 +1,25 @@
 package repository
 
 import (
+	"reservation-system/internal/domain/application"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/calendar"
 	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/idempotency"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/outbox"
 	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/reminder"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/internal/domain/team"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/internal/domain/webhook"
 	"reservation-system/internal/repository/memory"
+	"reservation-system/internal/repository/postgres"
 	"reservation-system/pkg/store"
 )
 
@@ -13,8 +29,41 @@ type Repository struct {
 	postgres store.SQLX
 	//TODO: mongo?
 
+	UnitOfWork store.UnitOfWork
+
+	Organization    organization.Repository
+	OrganizationKey organization.KeyRepository
+
 	Recruiter recruiter.Repository
 	Candidate candidate.Repository
+	Interview interview.Repository
+	Reminder  reminder.Repository
+	FeedToken calendar.FeedTokenRepository
+	Resource  resource.Repository
+	Busy      busy.Repository
+	BusyFeed  busy.FeedRepository
+	Audit     audit.Repository
+	Outbox    outbox.Repository
+
+	CandidateNote       candidate.NoteRepository
+	CandidateAttachment candidate.AttachmentRepository
+
+	Vacancy     vacancy.Repository
+	Application application.Repository
+
+	ScorecardTemplate scorecard.TemplateRepository
+	Scorecard         scorecard.Repository
+
+	Team       team.Repository
+	TeamMember team.MemberRepository
+
+	Idempotency idempotency.Repository
+
+	WebhookSubscription webhook.SubscriptionRepository
+	WebhookDelivery     webhook.DeliveryRepository
+
+	Notification       notification.Repository
+	NotificationOptOut notification.OptOutRepository
 }
 
 func New(configs ...Configuration) (s *Repository, err error) {
@@ -40,8 +89,70 @@ func (r *Repository) Close() {
 
 func WithMemoryStore() Configuration {
 	return func(s *Repository) (err error) {
+		s.UnitOfWork = memory.NewUnitOfWork()
+		s.Organization = memory.NewOrganizationRepository()
+		s.OrganizationKey = memory.NewOrganizationKeyRepository()
 		s.Recruiter = memory.NewRecruiterRepository()
 		s.Candidate = memory.NewCandidateRepository()
+		s.Interview = memory.NewInterviewRepository()
+		s.Reminder = memory.NewReminderRepository()
+		s.FeedToken = memory.NewFeedTokenRepository()
+		s.Resource = memory.NewResourceRepository()
+		s.CandidateNote = memory.NewCandidateNoteRepository()
+		s.CandidateAttachment = memory.NewCandidateAttachmentRepository()
+		s.Vacancy = memory.NewVacancyRepository()
+		s.Application = memory.NewApplicationRepository()
+		s.ScorecardTemplate = memory.NewScorecardTemplateRepository()
+		s.Scorecard = memory.NewScorecardRepository()
+		s.Team = memory.NewTeamRepository()
+		s.TeamMember = memory.NewTeamMemberRepository()
+		s.Busy = memory.NewBusyRepository()
+		s.BusyFeed = memory.NewBusyFeedRepository()
+		s.Audit = memory.NewAuditRepository()
+		s.Outbox = memory.NewOutboxRepository()
+		s.Idempotency = memory.NewIdempotencyRepository()
+		s.WebhookSubscription = memory.NewWebhookSubscriptionRepository()
+		s.WebhookDelivery = memory.NewWebhookDeliveryRepository()
+		s.Notification = memory.NewNotificationRepository()
+		s.NotificationOptOut = memory.NewNotificationOptOutRepository()
+
+		return
+	}
+}
+
+func WithPostgresStore(dataSourceName string) Configuration {
+	return func(s *Repository) (err error) {
+		s.postgres, err = store.NewSQL(dataSourceName)
+		if err != nil {
+			return
+		}
+
+		s.UnitOfWork = postgres.NewUnitOfWork(s.postgres)
+		s.Organization = postgres.NewOrganizationRepository(s.postgres.Client)
+		s.OrganizationKey = postgres.NewOrganizationKeyRepository(s.postgres.Client)
+		s.Recruiter = postgres.NewRecruiterRepository(s.postgres.Client)
+		s.Candidate = postgres.NewCandidateRepository(s.postgres.Client)
+		s.Interview = postgres.NewInterviewRepository(s.postgres.Client)
+		s.Reminder = postgres.NewReminderRepository(s.postgres.Client)
+		s.FeedToken = postgres.NewFeedTokenRepository(s.postgres.Client)
+		s.Resource = postgres.NewResourceRepository(s.postgres.Client)
+		s.CandidateNote = postgres.NewCandidateNoteRepository(s.postgres.Client)
+		s.CandidateAttachment = postgres.NewCandidateAttachmentRepository(s.postgres.Client)
+		s.Vacancy = postgres.NewVacancyRepository(s.postgres.Client)
+		s.Application = postgres.NewApplicationRepository(s.postgres.Client)
+		s.ScorecardTemplate = postgres.NewScorecardTemplateRepository(s.postgres.Client)
+		s.Scorecard = postgres.NewScorecardRepository(s.postgres.Client)
+		s.Team = postgres.NewTeamRepository(s.postgres.Client)
+		s.TeamMember = postgres.NewTeamMemberRepository(s.postgres.Client)
+		s.Busy = postgres.NewBusyRepository(s.postgres.Client)
+		s.BusyFeed = postgres.NewBusyFeedRepository(s.postgres.Client)
+		s.Audit = postgres.NewAuditRepository(s.postgres.Client)
+		s.Outbox = postgres.NewOutboxRepository(s.postgres.Client)
+		s.Idempotency = postgres.NewIdempotencyRepository(s.postgres.Client)
+		s.WebhookSubscription = postgres.NewWebhookSubscriptionRepository(s.postgres.Client)
+		s.WebhookDelivery = postgres.NewWebhookDeliveryRepository(s.postgres.Client)
+		s.Notification = postgres.NewNotificationRepository(s.postgres.Client)
+		s.NotificationOptOut = postgres.NewNotificationOptOutRepository(s.postgres.Client)
 
 		return
 	}
@@ -0,0 +1,165 @@
+package event
+
+import (
+	"context"
+	"reservation-system/internal/domain/event"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBufferSize       = 1000
+	defaultSubscriptionSize = 64
+)
+
+type Configuration func(b *Bus) error
+
+// Bus is an in-process event.Publisher that keeps the last events in a bounded
+// replay buffer so that subscribers can resume from the last event they saw.
+type Bus struct {
+	bufferSize int
+
+	mu          sync.RWMutex
+	sequence    uint64
+	buffer      []event.Event
+	subscribers map[*Subscription]struct{}
+	done        chan struct{}
+}
+
+// New takes a variable amount of Configuration functions and returns a new Bus
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (b *Bus, err error) {
+	// Create the bus
+	b = &Bus{
+		bufferSize:  defaultBufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+		done:        make(chan struct{}),
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the bus into the configuration function
+		if err = cfg(b); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func WithBufferSize(size int) Configuration {
+	return func(b *Bus) error {
+		if size > 0 {
+			b.bufferSize = size
+		}
+		return nil
+	}
+}
+
+// Subscription receives the events matching its filter until it is closed,
+// a subscriber that falls behind is closed and may resume with Subscribe.
+type Subscription struct {
+	C <-chan event.Event
+
+	ch     chan event.Event
+	filter event.Filter
+	once   sync.Once
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.ch) })
+}
+
+func (b *Bus) Publish(ctx context.Context, data event.Event) event.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sequence++
+	data.ID = b.sequence
+	if data.Time.IsZero() {
+		data.Time = time.Now().UTC()
+	}
+
+	b.buffer = append(b.buffer, data)
+	if len(b.buffer) > b.bufferSize {
+		b.buffer = b.buffer[len(b.buffer)-b.bufferSize:]
+	}
+
+	for s := range b.subscribers {
+		if !s.filter.Match(data) {
+			continue
+		}
+		select {
+		case s.ch <- data:
+		default:
+			delete(b.subscribers, s)
+			s.close()
+		}
+	}
+
+	return data
+}
+
+// Subscribe registers a subscription for the events matching the filter and
+// returns the buffered events published after lastID. A lastID unknown to the
+// bus, e.g. issued before a restart, replays the whole buffer.
+func (b *Bus) Subscribe(filter event.Filter, lastID uint64) (s *Subscription, replay []event.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan event.Event, defaultSubscriptionSize)
+	s = &Subscription{C: ch, ch: ch, filter: filter}
+	select {
+	case <-b.done:
+		s.close()
+		return
+	default:
+	}
+	b.subscribers[s] = struct{}{}
+
+	if lastID == 0 {
+		return
+	}
+	if lastID > b.sequence {
+		lastID = 0
+	}
+	for _, data := range b.buffer {
+		if data.ID > lastID && filter.Match(data) {
+			replay = append(replay, data)
+		}
+	}
+
+	return
+}
+
+// Unsubscribe removes the subscription and closes its channel
+func (b *Bus) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers, s)
+	s.close()
+}
+
+// Close closes every subscription and the ones made afterwards so that the
+// long-lived streams end before the servers shut down
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return
+	default:
+	}
+	close(b.done)
+
+	for s := range b.subscribers {
+		delete(b.subscribers, s)
+		s.close()
+	}
+}
+
+// Done is closed when the bus is closed
+func (b *Bus) Done() <-chan struct{} {
+	return b.done
+}
@@ -0,0 +1,123 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/idempotency"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+// Fingerprint identifies the request an idempotency key is used with
+func Fingerprint(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Begin claims the key for the request with the fingerprint. When the key
+// was already used by the same request that has completed, the stored
+// response is returned with replay set to true.
+func (s *Service) Begin(ctx context.Context, key, fingerprint string) (res idempotency.Entity, replay bool, err error) {
+	logger := log.LoggerFromContext(ctx).Named("Begin").With(zap.String("key", key))
+
+	data := idempotency.Entity{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now().UTC(),
+		ExpiresAt:   time.Now().UTC().Add(s.ttl),
+	}
+
+	err = s.idempotencyRepository.Add(ctx, data)
+	if err == nil {
+		return data, false, nil
+	}
+	if !errors.Is(err, store.ErrorConflict) {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+
+	res, err = s.idempotencyRepository.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			// the key expired in between, the client may retry
+			err = apperror.From(apperror.KindConflict, idempotency.ErrorInProgress)
+			return
+		}
+		logger.Error("failed to get by key", zap.Error(err))
+		return
+	}
+
+	switch {
+	case res.Fingerprint != fingerprint:
+		err = apperror.From(apperror.KindUnprocessable, idempotency.ErrorMismatch)
+	case !res.Completed():
+		err = apperror.From(apperror.KindConflict, idempotency.ErrorInProgress)
+	default:
+		replay = true
+	}
+
+	return
+}
+
+// Complete stores the response of the request to be replayed on retries
+func (s *Service) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("Complete").With(zap.String("key", key))
+
+	data := idempotency.Entity{
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Body:        body,
+	}
+
+	err = s.idempotencyRepository.Update(ctx, key, data)
+	if err != nil {
+		logger.Error("failed to update by key", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// Release frees the key of a failed request so that it can be retried
+func (s *Service) Release(ctx context.Context, key string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("Release").With(zap.String("key", key))
+
+	err = s.idempotencyRepository.Delete(ctx, key)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to delete by key", zap.Error(err))
+		return
+	}
+
+	return nil
+}
+
+// StartPurge removes expired keys every ttl until the context is done
+func (s *Service) StartPurge(ctx context.Context) {
+	logger := log.LoggerFromContext(ctx).Named("PurgeIdempotencyKeys")
+	ticker := time.NewTicker(s.ttl)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.idempotencyRepository.Purge(ctx, time.Now()); err != nil {
+					logger.Error("failed to purge", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
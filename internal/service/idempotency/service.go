@@ -0,0 +1,54 @@
+package idempotency
+
+import (
+	"reservation-system/internal/domain/idempotency"
+	"time"
+)
+
+const defaultTTL = 24 * time.Hour
+
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	idempotencyRepository idempotency.Repository
+	ttl                   time.Duration
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Add the service
+	s = &Service{
+		ttl: defaultTTL,
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func WithIdempotencyRepository(idempotencyRepository idempotency.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.idempotencyRepository = idempotencyRepository
+		return nil
+	}
+}
+
+func WithTTL(ttl time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if ttl > 0 {
+			s.ttl = ttl
+		}
+		return nil
+	}
+}
@@ -0,0 +1,26 @@
+package notification
+
+import (
+	"context"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/pkg/mail"
+)
+
+// EmailSender sends the email notifications through an SMTP server
+type EmailSender struct {
+	client mail.SMTP
+}
+
+func NewEmailSender(client mail.SMTP) *EmailSender {
+	return &EmailSender{client: client}
+}
+
+func (s *EmailSender) Send(ctx context.Context, data notification.Entity) error {
+	msg := mail.Message{
+		To:      data.Recipient,
+		Subject: data.Subject,
+		HTML:    data.Body,
+	}
+
+	return s.client.Send(ctx, msg)
+}
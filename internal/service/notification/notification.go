@@ -0,0 +1,246 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+// view is passed to the templates
+type view struct {
+	Name  string
+	Event event.Event
+	Data  map[string]any
+}
+
+// Send renders the notifications of the event for every enabled channel and
+// queues them in the send log, it is an outbox.Sink so that with a transactional
+// store the notifications are queued in the transaction relaying the event
+func (s *Service) Send(ctx context.Context, data event.Event) (err error) {
+	src, err := json.Marshal(data.Data)
+	if err != nil {
+		return
+	}
+
+	var (
+		recipient notification.Recipient
+		fields    map[string]any
+	)
+	if err = json.Unmarshal(src, &recipient); err != nil {
+		// the event is not about someone we can notify
+		return nil
+	}
+	json.Unmarshal(src, &fields)
+
+	return s.enqueue(ctx, recipient, view{Name: recipient.FullName, Event: data, Data: fields})
+}
+
+// Notify renders the notifications of the event type to the recipient for every
+// enabled channel and queues them in the send log, within the unit of work in ctx
+func (s *Service) Notify(ctx context.Context, eventType string, recipient notification.Recipient, data map[string]any) (err error) {
+	return s.enqueue(ctx, recipient, view{Name: recipient.FullName, Event: event.Event{Type: eventType}, Data: data})
+}
+
+func (s *Service) enqueue(ctx context.Context, recipient notification.Recipient, data view) (err error) {
+	locale := recipient.Locale
+	if locale == "" {
+		locale = s.locale
+	}
+
+	for channel := range s.senders {
+		address := recipient.Address(channel)
+		if address == "" {
+			continue
+		}
+
+		var optedOut bool
+		if optedOut, err = s.optedOut(ctx, channel, address); err != nil {
+			return
+		}
+		if optedOut {
+			continue
+		}
+
+		entity := notification.Entity{
+			Channel:       channel,
+			EventType:     data.Event.Type,
+			Recipient:     address,
+			Status:        notification.StatusPending,
+			NextAttemptAt: time.Now().UTC(),
+			CreatedAt:     time.Now().UTC(),
+		}
+
+		var ok bool
+		entity.Subject, entity.Body, entity.Locale, ok, err = s.templates.render(channel, locale, s.locale, data.Event.Type, data)
+		if err != nil {
+			return
+		}
+		if !ok {
+			continue
+		}
+
+		if _, err = s.notificationRepository.Add(ctx, entity); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (s *Service) ListNotifications(ctx context.Context, filter notification.Filter) (res []notification.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListNotifications")
+
+	data, err := s.notificationRepository.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = notification.ParseFromEntities(data)
+
+	return
+}
+
+// RetryNotification puts a notification back to the queue with a fresh attempt budget
+func (s *Service) RetryNotification(ctx context.Context, id string) (res notification.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RetryNotification").With(zap.String("id", id))
+
+	data, err := s.notificationRepository.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = apperror.Wrap(apperror.KindNotFound, err, "notification %s not found", id)
+			return
+		}
+		logger.Error("failed to get by id", zap.Error(err))
+		return
+	}
+	if data.Status == notification.StatusSent {
+		err = apperror.Conflict("notification %s is already sent", id)
+		return
+	}
+
+	data.Status = notification.StatusPending
+	data.Attempts = 0
+	data.NextAttemptAt = time.Now().UTC()
+
+	if err = s.notificationRepository.Update(ctx, id, data); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+	res = notification.ParseFromEntity(data)
+
+	return
+}
+
+// Start runs the dispatcher of the send log until ctx is done
+func (s *Service) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Dispatch(ctx)
+			}
+		}
+	}()
+}
+
+// Dispatch sends the notifications that are due
+func (s *Service) Dispatch(ctx context.Context) {
+	logger := log.LoggerFromContext(ctx).Named("DispatchNotifications")
+
+	// the notifications of every organization are claimed
+	ctx = organization.ContextWithSystem(ctx)
+
+	notifications, err := s.notificationRepository.Claim(ctx, time.Now().UTC(), claimLease, defaultBatchSize)
+	if err != nil {
+		logger.Error("failed to claim notifications", zap.Error(err))
+		return
+	}
+
+	for _, data := range notifications {
+		s.deliver(ctx, data)
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, data notification.Entity) {
+	logger := log.LoggerFromContext(ctx).Named("DeliverNotification").With(zap.String("id", data.ID), zap.String("channel", data.Channel))
+	ctx = organization.ContextWithTenant(ctx, data.TenantID)
+
+	sender, ok := s.senders[data.Channel]
+	if !ok {
+		// the channel was disabled since, keep the notification for when it is back
+		return
+	}
+
+	// the recipient may have opted out after the notification was queued
+	optedOut, err := s.optedOut(ctx, data.Channel, data.Recipient)
+	if err != nil {
+		logger.Error("failed to check opt-out", zap.Error(err))
+		return
+	}
+	if optedOut {
+		data.Status = notification.StatusSuppressed
+		if err = s.notificationRepository.Update(ctx, data.ID, data); err != nil {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	data.Attempts++
+	err = sender.Send(ctx, data)
+
+	var rateLimit notification.RateLimitError
+	switch {
+	case errors.As(err, &rateLimit):
+		data.Attempts--
+		data.NextAttemptAt = time.Now().UTC().Add(rateLimit.RetryAfter)
+	case err == nil:
+		now := time.Now().UTC()
+		data.Status = notification.StatusSent
+		data.SentAt = &now
+		data.LastError = ""
+	case data.Attempts >= s.maxAttempts:
+		data.Status = notification.StatusDead
+		data.LastError = err.Error()
+		logger.Warn("notification is dead", zap.Int("attempts", data.Attempts), zap.Error(err))
+	default:
+		data.LastError = err.Error()
+		data.NextAttemptAt = time.Now().UTC().Add(s.delay(data.Attempts))
+	}
+
+	if err = s.notificationRepository.Update(ctx, data.ID, data); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+	}
+}
+
+// delay doubles the backoff with every failed attempt up to a day
+func (s *Service) delay(attempts int) time.Duration {
+	delay := s.backoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+func (s *Service) optedOut(ctx context.Context, channel, recipient string) (bool, error) {
+	if s.optOutRepository == nil {
+		return false, nil
+	}
+	return s.optOutRepository.Exists(ctx, channel, recipient)
+}
@@ -0,0 +1,261 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/repository/memory"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const tenantID = "00000000-0000-0000-0000-000000000001"
+
+// sender records the notifications it is given and fails with the errors in
+// order, the last one is repeated
+type sender struct {
+	errs []error
+
+	mu   sync.Mutex
+	sent []notification.Entity
+}
+
+func (s *sender) Send(ctx context.Context, data notification.Entity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent = append(s.sent, data)
+	if len(s.errs) == 0 {
+		return nil
+	}
+	if n := len(s.sent); n <= len(s.errs) {
+		return s.errs[n-1]
+	}
+	return s.errs[len(s.errs)-1]
+}
+
+func newService(t *testing.T, configs ...Configuration) *Service {
+	t.Helper()
+
+	configs = append([]Configuration{
+		WithNotificationRepository(memory.NewNotificationRepository()),
+		WithOptOutRepository(memory.NewNotificationOptOutRepository()),
+	}, configs...)
+
+	s, err := New(configs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func tenant() context.Context {
+	return organization.ContextWithTenant(context.Background(), tenantID)
+}
+
+// list returns the send log, with the rendered bodies the responses leave out
+func list(t *testing.T, s *Service) []notification.Entity {
+	t.Helper()
+
+	res, err := s.notificationRepository.List(tenant(), notification.Filter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	return res
+}
+
+func TestSendRendersTemplates(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        event.Event
+		wantSubject string
+		wantBody    string
+		wantLocale  string
+	}{
+		{
+			name:        "default locale",
+			data:        event.Event{Type: "candidate.create", Data: map[string]any{"fullName": "Jane", "email": "jane@example.com"}},
+			wantSubject: "Welcome, Jane",
+			wantBody:    "<p>Hello Jane,</p>",
+			wantLocale:  "en",
+		},
+		{
+			name:        "locale of the recipient",
+			data:        event.Event{Type: "candidate.create", Data: map[string]any{"fullName": "Jürgen", "email": "j@example.com", "locale": "de"}},
+			wantSubject: "Willkommen, Jürgen",
+			wantBody:    "<p>Hallo Jürgen,</p>",
+			wantLocale:  "de",
+		},
+		{
+			name:        "falls back to the default locale",
+			data:        event.Event{Type: "candidate.create", Data: map[string]any{"fullName": "Jeanne", "email": "jeanne@example.com", "locale": "fr"}},
+			wantSubject: "Welcome, Jeanne",
+			wantBody:    "<p>Hello Jeanne,</p>",
+			wantLocale:  "en",
+		},
+		{
+			name:        "markup is escaped in the body only",
+			data:        event.Event{Type: "candidate.create", Data: map[string]any{"fullName": "<b>Tom & Co</b>", "email": "tom@example.com"}},
+			wantSubject: "Welcome, <b>Tom & Co</b>",
+			wantBody:    "<p>Hello &lt;b&gt;Tom &amp; Co&lt;/b&gt;,</p>",
+			wantLocale:  "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newService(t, WithSender(notification.ChannelEmail, &sender{}))
+
+			if err := s.Send(tenant(), tt.data); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			res := list(t, s)
+			if len(res) != 1 {
+				t.Fatalf("got %d notifications, want 1", len(res))
+			}
+			if res[0].Subject != tt.wantSubject || res[0].Locale != tt.wantLocale || !strings.HasPrefix(res[0].Body, tt.wantBody) {
+				t.Errorf("notification = %+v, want subject %q, locale %s and body starting with %q", res[0], tt.wantSubject, tt.wantLocale, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSendSkips(t *testing.T) {
+	tests := []struct {
+		name string
+		data event.Event
+	}{
+		{name: "no template for the event", data: event.Event{Type: "candidate.update", Data: map[string]any{"fullName": "Jane", "email": "jane@example.com"}}},
+		{name: "no address", data: event.Event{Type: "candidate.create", Data: map[string]any{"fullName": "Jane"}}},
+		{name: "not about a person", data: event.Event{Type: "candidate.create", Data: "text"}},
+		{name: "opted out", data: event.Event{Type: "candidate.create", Data: map[string]any{"fullName": "Opt", "email": "out@example.com"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newService(t, WithSender(notification.ChannelEmail, &sender{}))
+			if _, err := s.AddOptOut(tenant(), notification.OptOutRequest{Channel: notification.ChannelEmail, Recipient: "out@example.com"}); err != nil {
+				t.Fatalf("AddOptOut() error = %v", err)
+			}
+
+			if err := s.Send(tenant(), tt.data); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if res := list(t, s); len(res) != 0 {
+				t.Errorf("got notifications %+v, want none", res)
+			}
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		optOut       bool
+		wantStatus   string
+		wantAttempts int
+		wantSent     int
+	}{
+		{name: "sent", wantStatus: notification.StatusSent, wantAttempts: 1, wantSent: 1},
+		{name: "sent after a failure", errs: []error{failure, nil}, wantStatus: notification.StatusSent, wantAttempts: 2, wantSent: 2},
+		{name: "dead after the attempts", errs: []error{failure}, wantStatus: notification.StatusDead, wantAttempts: 3, wantSent: 3},
+		{name: "suppressed after opting out", optOut: true, wantStatus: notification.StatusSuppressed, wantSent: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := &sender{errs: tt.errs}
+			s := newService(t, WithSender(notification.ChannelEmail, email), WithRetries(3, time.Nanosecond))
+
+			recipient := notification.Recipient{FullName: "Jane", Email: "jane@example.com"}
+			if err := s.Notify(tenant(), "candidate.create", recipient, nil); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+			if tt.optOut {
+				if _, err := s.AddOptOut(tenant(), notification.OptOutRequest{Channel: notification.ChannelEmail, Recipient: recipient.Email}); err != nil {
+					t.Fatalf("AddOptOut() error = %v", err)
+				}
+			}
+
+			// dispatch more often than needed, a settled notification is not sent again
+			for i := 0; i < 5; i++ {
+				time.Sleep(time.Millisecond)
+				s.Dispatch(context.Background())
+			}
+
+			if len(email.sent) != tt.wantSent {
+				t.Errorf("sender got %d notifications, want %d", len(email.sent), tt.wantSent)
+			}
+			res := list(t, s)
+			if len(res) != 1 || res[0].Status != tt.wantStatus || res[0].Attempts != tt.wantAttempts {
+				t.Errorf("notifications = %+v, want one %s after %d attempts", res, tt.wantStatus, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestNotifyInterviewTemplates(t *testing.T) {
+	data := map[string]any{
+		"title":      "Technical interview",
+		"location":   "Room 1",
+		"meetingUrl": "https://meet.example.com/abc",
+		"startsAt":   time.Date(2027, 1, 4, 9, 0, 0, 0, time.UTC),
+		"endsAt":     time.Date(2027, 1, 4, 10, 0, 0, 0, time.UTC),
+		"candidate":  "Jane",
+		"recruiter":  "Rita, Ralf",
+	}
+
+	tests := []struct {
+		eventType string
+		locale    string
+		channel   string
+		want      []string
+	}{
+		{eventType: "interview.scheduled", locale: "en", channel: notification.ChannelEmail, want: []string{"Interview scheduled: Technical interview on Mon, 04 Jan 09:00 UTC", "with Jane has been scheduled", "Where: Room 1"}},
+		{eventType: "interview.scheduled", locale: "de", channel: notification.ChannelEmail, want: []string{"Gespräch geplant: Technical interview am 04.01. 09:00 UTC", "mit Jane wurde geplant"}},
+		{eventType: "interview.scheduled", locale: "en", channel: notification.ChannelSMS, want: []string{"Interview scheduled: Technical interview on Mon, 04 Jan 09:00 UTC at Room 1. Join: https://meet.example.com/abc"}},
+		{eventType: "interview.scheduled", locale: "de", channel: notification.ChannelSMS, want: []string{"Gespräch geplant: Technical interview am 04.01. 09:00 UTC in Room 1. Teilnehmen: https://meet.example.com/abc"}},
+		{eventType: "interview.rescheduled", locale: "en", channel: notification.ChannelEmail, want: []string{"Interview moved: Technical interview on Mon, 04 Jan 09:00 UTC", "has been rescheduled"}},
+		{eventType: "interview.rescheduled", locale: "de", channel: notification.ChannelEmail, want: []string{"Gespräch verschoben: Technical interview am 04.01. 09:00 UTC", "wurde verschoben"}},
+		{eventType: "interview.rescheduled", locale: "en", channel: notification.ChannelSMS, want: []string{"Interview moved: Technical interview is now on Mon, 04 Jan 09:00 UTC"}},
+		{eventType: "interview.rescheduled", locale: "de", channel: notification.ChannelSMS, want: []string{"Gespräch verschoben: Technical interview ist jetzt am 04.01. 09:00 UTC"}},
+		{eventType: "interview.cancelled", locale: "en", channel: notification.ChannelEmail, want: []string{"Interview cancelled: Technical interview on Mon, 04 Jan 09:00 UTC", "has been cancelled"}},
+		{eventType: "interview.cancelled", locale: "de", channel: notification.ChannelEmail, want: []string{"Gespräch abgesagt: Technical interview am 04.01. 09:00 UTC", "wurde abgesagt"}},
+		{eventType: "interview.cancelled", locale: "en", channel: notification.ChannelSMS, want: []string{"Interview cancelled: Technical interview on Mon, 04 Jan 09:00 UTC."}},
+		{eventType: "interview.cancelled", locale: "de", channel: notification.ChannelSMS, want: []string{"Gespräch abgesagt: Technical interview am 04.01. 09:00 UTC."}},
+		{eventType: "recruiter.assigned", locale: "en", channel: notification.ChannelEmail, want: []string{"New interview with Jane", "You have been assigned the interview <strong>Technical interview</strong> with Jane."}},
+		{eventType: "recruiter.assigned", locale: "de", channel: notification.ChannelEmail, want: []string{"Neues Gespräch mit Jane", "Ihnen wurde das Gespräch <strong>Technical interview</strong> mit Jane zugewiesen."}},
+		{eventType: "recruiter.assigned", locale: "en", channel: notification.ChannelSMS, want: []string{"Hi Rita, you have a new interview with Jane on Mon, 04 Jan 09:00 UTC."}},
+		{eventType: "recruiter.assigned", locale: "de", channel: notification.ChannelSMS, want: []string{"Hallo Rita, Sie haben ein neues Gespräch mit Jane am 04.01. 09:00 UTC."}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType+"/"+tt.locale+"/"+tt.channel, func(t *testing.T) {
+			s := newService(t, WithSender(tt.channel, &sender{}))
+
+			recipient := notification.Recipient{FullName: "Rita", Email: "rita@example.com", Phone: 4915112345678, Locale: tt.locale}
+			if err := s.Notify(tenant(), tt.eventType, recipient, data); err != nil {
+				t.Fatalf("Notify() error = %v", err)
+			}
+
+			res := list(t, s)
+			if len(res) != 1 || res[0].Locale != tt.locale || res[0].EventType != tt.eventType {
+				t.Fatalf("notifications = %+v, want one %s in %s", res, tt.eventType, tt.locale)
+			}
+			rendered := res[0].Subject + "\n" + res[0].Body
+			for _, want := range tt.want {
+				if !strings.Contains(rendered, want) {
+					t.Errorf("rendered %q, want it to contain %q", rendered, want)
+				}
+			}
+		})
+	}
+}
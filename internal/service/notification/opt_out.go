@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+func (s *Service) ListOptOuts(ctx context.Context, channel string) (res []notification.OptOutResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListOptOuts")
+
+	data, err := s.optOutRepository.List(ctx, channel)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = notification.ParseFromOptOuts(data)
+
+	return
+}
+
+// AddOptOut stops the notifications of the channel to the recipient, including
+// the ones already queued
+func (s *Service) AddOptOut(ctx context.Context, req notification.OptOutRequest) (res notification.OptOutResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddOptOut")
+
+	data := notification.OptOut{
+		Channel:   req.Channel,
+		Recipient: req.Recipient,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err = s.optOutRepository.Add(ctx, data); err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+	res = notification.ParseFromOptOut(data)
+
+	return
+}
+
+func (s *Service) DeleteOptOut(ctx context.Context, channel, recipient string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteOptOut").With(zap.String("channel", channel))
+
+	err = s.optOutRepository.Delete(ctx, channel, recipient)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = apperror.Wrap(apperror.KindNotFound, err, "%s opt-out of %s not found", channel, recipient)
+			return
+		}
+		logger.Error("failed to delete", zap.Error(err))
+		return
+	}
+
+	return
+}
@@ -0,0 +1,123 @@
+package notification
+
+import (
+	"reservation-system/internal/domain/notification"
+	"time"
+)
+
+const (
+	defaultLocale       = "en"
+	defaultPollInterval = time.Second
+	defaultMaxAttempts  = 5
+	defaultBackoff      = 30 * time.Second
+	defaultBatchSize    = 50
+
+	maxBackoff = 24 * time.Hour
+	claimLease = 5 * time.Minute
+)
+
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	notificationRepository notification.Repository
+	optOutRepository       notification.OptOutRepository
+	senders                map[string]notification.Sender
+	templates              templateSet
+
+	locale       string
+	pollInterval time.Duration
+	maxAttempts  int
+	backoff      time.Duration
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Add the service
+	s = &Service{
+		senders:      make(map[string]notification.Sender),
+		locale:       defaultLocale,
+		pollInterval: defaultPollInterval,
+		maxAttempts:  defaultMaxAttempts,
+		backoff:      defaultBackoff,
+	}
+
+	s.templates, err = parseTemplates()
+	if err != nil {
+		return
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func WithNotificationRepository(notificationRepository notification.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.notificationRepository = notificationRepository
+		return nil
+	}
+}
+
+func WithOptOutRepository(optOutRepository notification.OptOutRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.optOutRepository = optOutRepository
+		return nil
+	}
+}
+
+// WithSender enables the channel, notifications are only rendered for enabled channels
+func WithSender(channel string, sender notification.Sender) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.senders[channel] = sender
+		return nil
+	}
+}
+
+func WithLocale(locale string) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if locale != "" {
+			s.locale = locale
+		}
+		return nil
+	}
+}
+
+func WithRetries(maxAttempts int, backoff time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if maxAttempts > 0 {
+			s.maxAttempts = maxAttempts
+		}
+		if backoff > 0 {
+			s.backoff = backoff
+		}
+		return nil
+	}
+}
+
+func WithPollInterval(pollInterval time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if pollInterval > 0 {
+			s.pollInterval = pollInterval
+		}
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"context"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/pkg/sms"
+	"sync"
+	"time"
+)
+
+// SMSSender sends the text notifications through a provider, allowing at most
+// limit messages per recipient within window. The limit is kept in memory,
+// so every replica enforces it on its own.
+type SMSSender struct {
+	provider sms.Provider
+	limit    int
+	window   time.Duration
+
+	mu   sync.Mutex
+	sent map[string][]time.Time
+}
+
+// NewSMSSender returns a sender without a rate limit when limit is zero
+func NewSMSSender(provider sms.Provider, limit int, window time.Duration) *SMSSender {
+	return &SMSSender{
+		provider: provider,
+		limit:    limit,
+		window:   window,
+		sent:     make(map[string][]time.Time),
+	}
+}
+
+func (s *SMSSender) Send(ctx context.Context, data notification.Entity) (err error) {
+	if err = s.allow(data.Recipient); err != nil {
+		return
+	}
+
+	return s.provider.Send(ctx, data.Recipient, data.Body)
+}
+
+// allow records a message to the recipient unless it would exceed the limit
+func (s *SMSSender) allow(recipient string) error {
+	if s.limit <= 0 || s.window <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	recent := s.sent[recipient][:0]
+	for _, at := range s.sent[recipient] {
+		if now.Sub(at) < s.window {
+			recent = append(recent, at)
+		}
+	}
+
+	if len(recent) >= s.limit {
+		s.sent[recipient] = recent
+		return notification.RateLimitError{RetryAfter: recent[0].Add(s.window).Sub(now)}
+	}
+	s.sent[recipient] = append(recent, now)
+
+	return nil
+}
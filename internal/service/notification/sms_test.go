@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"reservation-system/internal/domain/notification"
+	"sync"
+	"testing"
+	"time"
+)
+
+// provider records the text messages it is given
+type provider struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (p *provider) Send(ctx context.Context, to, text string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, to+": "+text)
+	return nil
+}
+
+func TestSMSSenderRateLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		recipients []string
+		want       []bool
+	}{
+		{name: "no limit", limit: 0, recipients: []string{"1", "1", "1"}, want: []bool{true, true, true}},
+		{name: "limit per recipient", limit: 2, recipients: []string{"1", "1", "2", "1", "2"}, want: []bool{true, true, true, false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &provider{}
+			s := NewSMSSender(p, tt.limit, time.Hour)
+
+			sent := 0
+			for i, recipient := range tt.recipients {
+				err := s.Send(context.Background(), notification.Entity{Recipient: recipient, Body: "hi"})
+
+				var rateLimit notification.RateLimitError
+				switch {
+				case tt.want[i] && err != nil:
+					t.Errorf("message %d: Send() error = %v", i, err)
+				case !tt.want[i] && !errors.As(err, &rateLimit):
+					t.Errorf("message %d: Send() error = %v, want a rate limit", i, err)
+				case !tt.want[i] && (rateLimit.RetryAfter <= 0 || rateLimit.RetryAfter > time.Hour):
+					t.Errorf("message %d: retry after %v, want within the window", i, rateLimit.RetryAfter)
+				}
+				if tt.want[i] {
+					sent++
+				}
+			}
+
+			if len(p.messages) != sent {
+				t.Errorf("provider got %d messages, want %d", len(p.messages), sent)
+			}
+		})
+	}
+}
+
+func TestSMSSenderWindow(t *testing.T) {
+	s := NewSMSSender(&provider{}, 1, 20*time.Millisecond)
+	data := notification.Entity{Recipient: "1", Body: "hi"}
+
+	if err := s.Send(context.Background(), data); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := s.Send(context.Background(), data); err == nil {
+		t.Fatal("Send() error = nil, want a rate limit")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := s.Send(context.Background(), data); err != nil {
+		t.Fatalf("Send() after the window error = %v", err)
+	}
+}
+
+func TestDispatchRateLimited(t *testing.T) {
+	p := &provider{}
+	s := newService(t, WithSender(notification.ChannelSMS, NewSMSSender(p, 1, time.Hour)), WithRetries(1, time.Nanosecond))
+
+	recipient := notification.Recipient{FullName: "Jane", Phone: 4915112345678}
+	for i := 0; i < 2; i++ {
+		if err := s.Notify(tenant(), "candidate.create", recipient, nil); err != nil {
+			t.Fatalf("Notify() error = %v", err)
+		}
+	}
+	s.Dispatch(context.Background())
+
+	if len(p.messages) != 1 || p.messages[0] != "4915112345678: Hi Jane, your candidate profile has been created." {
+		t.Fatalf("provider got %q, want one message to the phone number", p.messages)
+	}
+
+	var sent, held int
+	for _, data := range list(t, s) {
+		switch {
+		case data.Status == notification.StatusSent:
+			sent++
+		case data.Status == notification.StatusPending && data.Attempts == 0 && data.NextAttemptAt.After(time.Now().Add(50*time.Minute)):
+			// held back without using up the only attempt
+			held++
+		default:
+			t.Errorf("unexpected notification %+v", data)
+		}
+	}
+	if sent != 1 || held != 1 {
+		t.Errorf("got %d sent and %d held back, want 1 and 1", sent, held)
+	}
+}
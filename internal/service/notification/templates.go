@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"html"
+	htmlTemplate "html/template"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	textTemplate "text/template"
+)
+
+// templates holds a template per channel, locale and event type laid out as
+// templates/<channel>/<locale>/<event type>.<ext>, each defining a body and
+// optionally a subject. Markup channels use .html files parsed with html/template
+// and plain text channels use .txt files parsed with text/template.
+//
+//go:embed templates
+var templates embed.FS
+
+// executor is implemented by both html/template and text/template
+type executor interface {
+	ExecuteTemplate(w io.Writer, name string, data any) error
+}
+
+type entry struct {
+	executor   executor
+	html       bool
+	hasSubject bool
+}
+
+type templateSet map[string]entry
+
+func templateKey(channel, locale, eventType string) string {
+	return channel + "/" + locale + "/" + eventType
+}
+
+func parseTemplates() (set templateSet, err error) {
+	set = make(templateSet)
+
+	err = fs.WalkDir(templates, "templates", func(name string, dirEntry fs.DirEntry, err error) error {
+		if err != nil || dirEntry.IsDir() {
+			return err
+		}
+
+		// templates/<channel>/<locale>/<event type>.<ext>
+		ext := path.Ext(name)
+		parts := strings.Split(strings.TrimSuffix(name, ext), "/")
+		if len(parts) != 4 {
+			return nil
+		}
+
+		var item entry
+		switch ext {
+		case ".html":
+			tmpl, err := htmlTemplate.ParseFS(templates, name)
+			if err != nil {
+				return err
+			}
+			item = entry{executor: tmpl, html: true, hasSubject: tmpl.Lookup("subject") != nil}
+		case ".txt":
+			tmpl, err := textTemplate.ParseFS(templates, name)
+			if err != nil {
+				return err
+			}
+			item = entry{executor: tmpl, hasSubject: tmpl.Lookup("subject") != nil}
+		default:
+			return nil
+		}
+		set[templateKey(parts[1], parts[2], parts[3])] = item
+
+		return nil
+	})
+
+	return
+}
+
+// render executes the template of the event in the locale, falling back to
+// the fallback locale, ok is false when the event has no template at all
+func (set templateSet) render(channel, locale, fallback, eventType string, data any) (subject, body, usedLocale string, ok bool, err error) {
+	item, ok := set[templateKey(channel, locale, eventType)]
+	usedLocale = locale
+	if !ok {
+		item, ok = set[templateKey(channel, fallback, eventType)]
+		usedLocale = fallback
+	}
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if item.hasSubject {
+		if err = item.executor.ExecuteTemplate(&buf, "subject", data); err != nil {
+			return
+		}
+		subject = strings.TrimSpace(buf.String())
+		if item.html {
+			// the subject is a header rather than markup
+			subject = html.UnescapeString(subject)
+		}
+		buf.Reset()
+	}
+
+	if err = item.executor.ExecuteTemplate(&buf, "body", data); err != nil {
+		return
+	}
+	body = strings.TrimSpace(buf.String())
+
+	return
+}
@@ -0,0 +1,227 @@
+package organization
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+const (
+	// keyPrefix marks the secrets of organization keys so that they are
+	// recognized when leaked
+	keyPrefix = "rsk_"
+
+	// keyPrefixLength is the length of the part of a secret kept in clear to
+	// tell the keys apart
+	keyPrefixLength = 12
+)
+
+func (s *Service) ListOrganizations(ctx context.Context) (res []organization.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListOrganizations")
+
+	data, err := s.organizationRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = organization.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddOrganization(ctx context.Context, req organization.Request) (res organization.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddOrganization")
+
+	data := organization.Entity{
+		Name:      &req.Name,
+		Status:    &req.Status,
+		CreatedAt: time.Now().UTC(),
+		Version:   1,
+	}
+
+	data.ID, err = s.organizationRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+	res = organization.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) GetOrganization(ctx context.Context, id string) (res organization.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetOrganization").With(zap.String("id", id))
+
+	data, err := s.organizationRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = organization.ParseFromEntity(data)
+
+	return
+}
+
+// UpdateOrganization renames the organization or suspends it, the principals
+// of a suspended organization are turned away until it is active again
+func (s *Service) UpdateOrganization(ctx context.Context, id string, version int, req organization.Request) (res organization.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateOrganization").With(zap.String("id", id))
+
+	data := organization.Entity{
+		Name:    &req.Name,
+		Status:  &req.Status,
+		Version: version,
+	}
+
+	err = s.organizationRepository.Update(ctx, id, data)
+	if err != nil {
+		err = repositoryError(err, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return s.GetOrganization(ctx, id)
+}
+
+func (s *Service) ListKeys(ctx context.Context, organizationID string) (res []organization.KeyResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListKeys").With(zap.String("organization_id", organizationID))
+
+	if _, err = s.GetOrganization(ctx, organizationID); err != nil {
+		return
+	}
+
+	data, err := s.keyRepository.List(ctx, organizationID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = organization.ParseFromKeys(data)
+
+	return
+}
+
+// IssueKey creates a key authenticating the principals of the organization,
+// or one of its recruiters when the recruiter is given, its secret is only
+// returned once
+func (s *Service) IssueKey(ctx context.Context, organizationID string, req organization.KeyRequest) (res organization.KeyResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("IssueKey").With(zap.String("organization_id", organizationID))
+
+	if _, err = s.GetOrganization(ctx, organizationID); err != nil {
+		return
+	}
+
+	var recruiterID *string
+	if req.RecruiterID != "" {
+		if _, err = s.recruiterRepository.Get(organization.ContextWithTenant(ctx, organizationID), req.RecruiterID); err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				return res, apperror.Wrap(apperror.KindUnprocessable, err, "recruiter %s not found", req.RecruiterID)
+			}
+			logger.Error("failed to get recruiter", zap.String("recruiter_id", req.RecruiterID), zap.Error(err))
+			return
+		}
+		recruiterID = &req.RecruiterID
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		logger.Error("failed to generate secret", zap.Error(err))
+		return
+	}
+
+	data := organization.Key{
+		OrganizationID: organizationID,
+		RecruiterID:    recruiterID,
+		Name:           req.Name,
+		Prefix:         secret[:keyPrefixLength],
+		SecretHash:     organization.HashSecret(secret),
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	data.ID, err = s.keyRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+	res = organization.ParseFromKey(data)
+	res.Secret = secret
+
+	return
+}
+
+func (s *Service) RevokeKey(ctx context.Context, organizationID, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RevokeKey").With(zap.String("id", id))
+
+	err = s.keyRepository.Revoke(ctx, organizationID, id)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			return apperror.Wrap(apperror.KindNotFound, err, "key %s not found", id)
+		}
+		logger.Error("failed to revoke by id", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// Authenticate returns the key the secret belongs to, revoked keys and the
+// keys of suspended organizations are turned away
+func (s *Service) Authenticate(ctx context.Context, secret string) (key organization.Key, err error) {
+	logger := log.LoggerFromContext(ctx).Named("Authenticate")
+
+	key, err = s.keyRepository.GetBySecret(ctx, organization.HashSecret(secret))
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			return key, apperror.Unauthorized("invalid organization key")
+		}
+		logger.Error("failed to get key", zap.Error(err))
+		return
+	}
+	if key.RevokedAt != nil {
+		return key, apperror.Unauthorized("organization key was revoked")
+	}
+
+	data, err := s.organizationRepository.Get(ctx, key.OrganizationID)
+	if err != nil {
+		logger.Error("failed to get organization", zap.String("organization_id", key.OrganizationID), zap.Error(err))
+		return
+	}
+	if !data.Active() {
+		return key, apperror.Forbidden("organization %s is suspended", data.ID)
+	}
+
+	return key, nil
+}
+
+// generateSecret returns a random secret for a new key
+func generateSecret() (string, error) {
+	src := make([]byte, 24)
+	if _, err := rand.Read(src); err != nil {
+		return "", err
+	}
+	return keyPrefix + hex.EncodeToString(src), nil
+}
+
+// repositoryError maps the errors of the repositories to the typed errors
+// of the service, errors it does not know about stay internal
+func repositoryError(err error, id string) error {
+	switch {
+	case errors.Is(err, store.ErrorNotFound):
+		return apperror.Wrap(apperror.KindNotFound, err, "organization %s not found", id)
+	case errors.Is(err, store.ErrorConflict):
+		return apperror.Wrap(apperror.KindPreconditionFailed, err, "organization %s was modified by another request", id)
+	}
+
+	return err
+}
@@ -0,0 +1,58 @@
+package organization
+
+import (
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/recruiter"
+)
+
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	organizationRepository organization.Repository
+	keyRepository          organization.KeyRepository
+	recruiterRepository    recruiter.Repository
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Add the service
+	s = &Service{}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func WithOrganizationRepository(organizationRepository organization.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.organizationRepository = organizationRepository
+		return nil
+	}
+}
+
+func WithKeyRepository(keyRepository organization.KeyRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.keyRepository = keyRepository
+		return nil
+	}
+}
+
+func WithRecruiterRepository(recruiterRepository recruiter.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.recruiterRepository = recruiterRepository
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/outbox"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+const (
+	defaultInterval  = 500 * time.Millisecond
+	defaultBatchSize = 100
+	defaultRetention = 7 * 24 * time.Hour
+	purgeInterval    = time.Hour
+)
+
+type Configuration func(r *Relay) error
+
+// Relay hands the events written to the outbox to the sinks in the order they
+// were written and marks them published, an event is relayed at least once.
+type Relay struct {
+	outboxRepository outbox.Repository
+	unitOfWork       store.UnitOfWork
+	sinks            []outbox.Sink
+
+	interval  time.Duration
+	batchSize int
+	retention time.Duration
+}
+
+// New takes a variable amount of Configuration functions and returns a new Relay
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (r *Relay, err error) {
+	// Create the relay
+	r = &Relay{
+		interval:  defaultInterval,
+		batchSize: defaultBatchSize,
+		retention: defaultRetention,
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the relay into the configuration function
+		if err = cfg(r); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func WithOutboxRepository(outboxRepository outbox.Repository) Configuration {
+	return func(r *Relay) error {
+		r.outboxRepository = outboxRepository
+		return nil
+	}
+}
+
+func WithUnitOfWork(unitOfWork store.UnitOfWork) Configuration {
+	return func(r *Relay) error {
+		r.unitOfWork = unitOfWork
+		return nil
+	}
+}
+
+func WithSink(sink outbox.Sink) Configuration {
+	return func(r *Relay) error {
+		r.sinks = append(r.sinks, sink)
+		return nil
+	}
+}
+
+func WithInterval(interval time.Duration) Configuration {
+	return func(r *Relay) error {
+		if interval > 0 {
+			r.interval = interval
+		}
+		return nil
+	}
+}
+
+func WithBatchSize(batchSize int) Configuration {
+	return func(r *Relay) error {
+		if batchSize > 0 {
+			r.batchSize = batchSize
+		}
+		return nil
+	}
+}
+
+func WithRetention(retention time.Duration) Configuration {
+	return func(r *Relay) error {
+		if retention > 0 {
+			r.retention = retention
+		}
+		return nil
+	}
+}
+
+// Relay sends one batch of pending events to the sinks. The batch stays locked
+// while it is sent so that concurrent relays never send the same event, and it
+// stops at the first event a sink fails on to keep the order of events.
+func (r *Relay) Relay(ctx context.Context) (count int, err error) {
+	logger := log.LoggerFromContext(ctx).Named("Relay")
+
+	// the events of every organization are relayed, the sinks are scoped to
+	// the organization of each event
+	ctx = organization.ContextWithSystem(ctx)
+
+	err = r.unitOfWork.Do(ctx, func(ctx context.Context) (err error) {
+		entries, err := r.outboxRepository.ListPending(ctx, r.batchSize)
+		if err != nil {
+			return
+		}
+
+		published := make([]int64, 0, len(entries))
+		for _, entry := range entries {
+			if err = r.send(ctx, entry); err != nil {
+				logger.Warn("failed to relay event", zap.Int64("id", entry.ID), zap.String("event_type", entry.EventType), zap.Error(err))
+				break
+			}
+			published = append(published, entry.ID)
+		}
+		count = len(published)
+
+		if count == 0 {
+			return nil
+		}
+		return r.outboxRepository.MarkPublished(ctx, published, time.Now().UTC())
+	})
+	if err != nil {
+		logger.Error("failed to relay", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+func (r *Relay) send(ctx context.Context, entry outbox.Entity) (err error) {
+	var data event.Event
+	if err = json.Unmarshal(entry.Payload, &data); err != nil {
+		return fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	// the outbox id stays the same when the event is relayed again
+	data.ID = uint64(entry.ID)
+
+	// the sinks act on behalf of the organization the event happened in
+	ctx = organization.ContextWithTenant(ctx, data.TenantID)
+	for _, sink := range r.sinks {
+		if err = sink.Send(ctx, data); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Start relays the outbox every interval and purges the events published
+// more than retention ago every hour, until the context is done
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	purgeTicker := time.NewTicker(purgeInterval)
+
+	go func() {
+		defer ticker.Stop()
+		defer purgeTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// drain the backlog before waiting for the next tick
+				for {
+					count, err := r.Relay(ctx)
+					if err != nil || count < r.batchSize {
+						break
+					}
+				}
+			case <-purgeTicker.C:
+				r.purge(ctx)
+			}
+		}
+	}()
+}
+
+func (r *Relay) purge(ctx context.Context) {
+	logger := log.LoggerFromContext(ctx).Named("PurgeOutbox")
+
+	count, err := r.outboxRepository.Purge(ctx, time.Now().Add(-r.retention))
+	if err != nil {
+		logger.Error("failed to purge", zap.Error(err))
+		return
+	}
+	if count > 0 {
+		logger.Info("purged published events", zap.Int64("count", count))
+	}
+}
@@ -0,0 +1,157 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/outbox"
+	"reservation-system/internal/repository/memory"
+	"testing"
+)
+
+// sink records the events it receives, it fails once for the events in fail
+type sink struct {
+	fail     map[uint64]bool
+	received []uint64
+	tenants  []string
+}
+
+func (s *sink) Send(ctx context.Context, data event.Event) error {
+	s.received = append(s.received, data.ID)
+	s.tenants = append(s.tenants, organization.TenantFromContext(ctx))
+	if s.fail[data.ID] {
+		delete(s.fail, data.ID)
+		return errors.New("sink is unavailable")
+	}
+	return nil
+}
+
+func newRelay(t *testing.T, batchSize int, sinks ...outbox.Sink) (*Relay, *memory.OutboxRepository) {
+	t.Helper()
+
+	repository := memory.NewOutboxRepository()
+	configs := []Configuration{
+		WithOutboxRepository(repository),
+		WithUnitOfWork(memory.NewUnitOfWork()),
+		WithBatchSize(batchSize),
+	}
+	for _, s := range sinks {
+		configs = append(configs, WithSink(s))
+	}
+
+	r, err := New(configs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the events alternate between two organizations
+	for i, tenantID := range []string{"a", "b", "a", "b", "a"} {
+		payload, err := json.Marshal(event.Event{TenantID: tenantID, Type: "candidate.create", EntityID: string(rune('1' + i))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = repository.Add(context.Background(), outbox.Entity{EventType: "candidate.create", Payload: payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return r, repository
+}
+
+func equal(got, want []uint64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRelay(t *testing.T) {
+	tests := []struct {
+		name         string
+		batchSize    int
+		fail         []uint64
+		wantCounts   []int
+		wantReceived []uint64
+	}{
+		{
+			name:         "in order in batches",
+			batchSize:    2,
+			wantCounts:   []int{2, 2, 1, 0},
+			wantReceived: []uint64{1, 2, 3, 4, 5},
+		},
+		{
+			name:         "stops at a failed event and sends it again",
+			batchSize:    10,
+			fail:         []uint64{3},
+			wantCounts:   []int{2, 3, 0},
+			wantReceived: []uint64{1, 2, 3, 3, 4, 5},
+		},
+		{
+			name:         "failed first event",
+			batchSize:    10,
+			fail:         []uint64{1},
+			wantCounts:   []int{0, 5, 0},
+			wantReceived: []uint64{1, 1, 2, 3, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &sink{fail: make(map[uint64]bool)}
+			for _, id := range tt.fail {
+				s.fail[id] = true
+			}
+			r, repository := newRelay(t, tt.batchSize, s)
+
+			for i, want := range tt.wantCounts {
+				count, err := r.Relay(context.Background())
+				if err != nil {
+					t.Fatalf("Relay() error = %v", err)
+				}
+				if count != want {
+					t.Fatalf("Relay() %d = %d, want %d", i, count, want)
+				}
+			}
+
+			if !equal(s.received, tt.wantReceived) {
+				t.Errorf("received %v, want %v", s.received, tt.wantReceived)
+			}
+			for i, id := range s.received {
+				if want := []string{"a", "b", "a", "b", "a"}[id-1]; s.tenants[i] != want {
+					t.Errorf("event %d sent on behalf of %q, want %q", id, s.tenants[i], want)
+				}
+			}
+
+			pending, err := repository.ListPending(context.Background(), 10)
+			if err != nil || len(pending) != 0 {
+				t.Errorf("pending after relay = %v, %v, want none", pending, err)
+			}
+		})
+	}
+}
+
+func TestRelaySinks(t *testing.T) {
+	// the second sink fails, the first one gets the event again
+	first, second := &sink{}, &sink{fail: map[uint64]bool{2: true}}
+	r, _ := newRelay(t, 10, first, second)
+
+	for _, want := range []int{1, 4, 0} {
+		if count, err := r.Relay(context.Background()); err != nil || count != want {
+			t.Fatalf("Relay() = %d, %v, want %d", count, err, want)
+		}
+	}
+
+	if want := []uint64{1, 2, 2, 3, 4, 5}; !equal(first.received, want) {
+		t.Errorf("first sink received %v, want %v", first.received, want)
+	}
+	if want := []uint64{1, 2, 2, 3, 4, 5}; !equal(second.received, want) {
+		t.Errorf("second sink received %v, want %v", second.received, want)
+	}
+}
@@ -0,0 +1,38 @@
+package outbox
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/event"
+	"reservation-system/pkg/log"
+)
+
+// PublisherSink relays the events to an event.Publisher such as the in-process bus
+type PublisherSink struct {
+	publisher event.Publisher
+}
+
+func NewPublisherSink(publisher event.Publisher) *PublisherSink {
+	return &PublisherSink{publisher: publisher}
+}
+
+func (s *PublisherSink) Send(ctx context.Context, data event.Event) error {
+	s.publisher.Publish(ctx, data)
+	return nil
+}
+
+// LogSink writes the relayed events to the log
+type LogSink struct{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) Send(ctx context.Context, data event.Event) error {
+	log.LoggerFromContext(ctx).Named("LogSink").Info("event",
+		zap.String("type", data.Type),
+		zap.String("entity_id", data.EntityID),
+		zap.String("actor", data.Actor),
+		zap.String("request_id", data.RequestID))
+	return nil
+}
@@ -0,0 +1,222 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/reminder"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"strings"
+	"time"
+)
+
+const eventInterviewReminder = "interview.reminder"
+
+// Start plans and sends the reminders every interval until ctx is done
+func (s *Service) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Plan(ctx)
+				// drain the due reminders before waiting for the next tick
+				for {
+					count, err := s.Dispatch(ctx)
+					if err != nil || count < defaultBatchSize {
+						break
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Plan adds the reminders of the scheduled interviews starting before the
+// largest offset has passed again. Planning is idempotent, so every replica
+// may plan the same interviews and a rescheduled interview gets new reminders.
+func (s *Service) Plan(ctx context.Context) {
+	logger := log.LoggerFromContext(ctx).Named("PlanReminders")
+
+	// the interviews of every organization are planned
+	ctx = organization.ContextWithSystem(ctx)
+
+	now := time.Now().UTC()
+	interviews, err := s.interviewRepository.List(ctx, interview.Filter{
+		From:   now,
+		To:     now.Add(s.offsets[0] + s.interval),
+		Status: interview.StatusScheduled,
+	})
+	if err != nil {
+		logger.Error("failed to select interviews", zap.Error(err))
+		return
+	}
+
+	for _, data := range interviews {
+		if !data.StartsAt.After(now) {
+			continue
+		}
+
+		for _, offset := range s.offsets {
+			entity := reminder.Entity{
+				InterviewID:   data.ID,
+				OffsetSeconds: int64(offset / time.Second),
+				StartsAt:      data.StartsAt.UTC(),
+				DueAt:         data.StartsAt.Add(-offset).UTC(),
+				Status:        reminder.StatusPending,
+			}
+			if err = s.reminderRepository.Add(ctx, entity); err != nil {
+				logger.Error("failed to add reminder", zap.String("interview_id", data.ID), zap.Error(err))
+				return
+			}
+		}
+	}
+}
+
+// Dispatch sends one batch of due reminders. The batch is claimed, the
+// notifications are queued and the reminders are marked sent in one unit of
+// work, so that each reminder is sent once across restarts and replicas.
+func (s *Service) Dispatch(ctx context.Context) (count int, err error) {
+	logger := log.LoggerFromContext(ctx).Named("DispatchReminders")
+
+	// the reminders of every organization are due, each is sent in the organization of its interview
+	ctx = organization.ContextWithSystem(ctx)
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) (err error) {
+		now := time.Now().UTC()
+
+		reminders, err := s.reminderRepository.ClaimDue(ctx, now, defaultBatchSize)
+		if err != nil {
+			return
+		}
+		count = len(reminders)
+
+		// of the reminders due together for an interview, only the closest to its start is sent
+		closest := make(map[string]reminder.Entity)
+		for _, data := range reminders {
+			if prev, ok := closest[data.InterviewID]; !ok || data.OffsetSeconds < prev.OffsetSeconds {
+				closest[data.InterviewID] = data
+			}
+		}
+
+		for _, data := range reminders {
+			data.Status = reminder.StatusSkipped
+			if data.ID == closest[data.InterviewID].ID {
+				var sent bool
+				if sent, err = s.remind(ctx, data, now); err != nil {
+					return
+				}
+				if sent {
+					data.Status = reminder.StatusSent
+					data.SentAt = &now
+				}
+			}
+
+			if err = s.reminderRepository.Update(ctx, data.ID, data); err != nil {
+				return
+			}
+		}
+
+		return
+	})
+	if err != nil {
+		logger.Error("failed to dispatch", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+// remind notifies the participants of the interview, it reports false for an
+// interview that was cancelled, rescheduled or started since the reminder was planned
+func (s *Service) remind(ctx context.Context, data reminder.Entity, now time.Time) (sent bool, err error) {
+	entity, err := s.interviewRepository.Get(ctx, data.InterviewID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			return false, nil
+		}
+		return
+	}
+	if *entity.Status != interview.StatusScheduled || !entity.StartsAt.Equal(data.StartsAt) || !entity.StartsAt.After(now) {
+		return false, nil
+	}
+	ctx = organization.ContextWithTenant(ctx, entity.TenantID)
+
+	var candidateName string
+	recruiterIDs := entity.RecruiterIDs()
+	recruiterNames := make([]string, 0, len(recruiterIDs))
+	recipients := make([]notification.Recipient, 0, len(recruiterIDs)+1)
+
+	if participant, err := s.candidateRepository.Get(ctx, *entity.CandidateID); err == nil {
+		to := recipient(participant.FullName, participant.Email, participant.Phone)
+		candidateName = to.FullName
+		if !declined(entity, interview.ParticipantCandidate, *entity.CandidateID) {
+			recipients = append(recipients, to)
+		}
+	} else if !errors.Is(err, store.ErrorNotFound) {
+		return false, err
+	}
+
+	for _, recruiterID := range recruiterIDs {
+		if participant, err := s.recruiterRepository.Get(ctx, recruiterID); err == nil {
+			to := recipient(participant.FullName, participant.Email, participant.Phone)
+			recruiterNames = append(recruiterNames, to.FullName)
+			if !declined(entity, interview.ParticipantRecruiter, recruiterID) {
+				recipients = append(recipients, to)
+			}
+		} else if !errors.Is(err, store.ErrorNotFound) {
+			return false, err
+		}
+	}
+
+	var meetingURL string
+	if entity.MeetingURL != nil {
+		meetingURL = *entity.MeetingURL
+	}
+
+	fields := map[string]any{
+		"interviewId": entity.ID,
+		"title":       *entity.Title,
+		"location":    *entity.Location,
+		"meetingUrl":  meetingURL,
+		"startsAt":    *entity.StartsAt,
+		"endsAt":      *entity.EndsAt,
+		"candidate":   candidateName,
+		"recruiter":   strings.Join(recruiterNames, ", "),
+	}
+	for _, to := range recipients {
+		if err = s.notifier.Notify(ctx, eventInterviewReminder, to, fields); err != nil {
+			return
+		}
+	}
+
+	return true, nil
+}
+
+// declined reports whether the participant declined the interview, they are not reminded of it
+func declined(entity interview.Entity, participantType, id string) bool {
+	participant, ok := entity.Participant(participantType, id)
+	return ok && participant.Status == interview.ResponseDeclined
+}
+
+func recipient(fullName, email *string, phone *int) (dest notification.Recipient) {
+	if fullName != nil {
+		dest.FullName = *fullName
+	}
+	if email != nil {
+		dest.Email = *email
+	}
+	if phone != nil {
+		dest.Phone = *phone
+	}
+	return
+}
@@ -0,0 +1,209 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/repository/memory"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+const tenantID = "00000000-0000-0000-0000-000000000001"
+
+// notifier records the reminders queued for each recipient
+type notifier struct {
+	mu   sync.Mutex
+	sent []string
+}
+
+func (n *notifier) Notify(ctx context.Context, eventType string, recipient notification.Recipient, data map[string]any) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if eventType == eventInterviewReminder && organization.TenantFromContext(ctx) == tenantID {
+		n.sent = append(n.sent, recipient.FullName)
+	}
+	return nil
+}
+
+func (n *notifier) names() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	names := append([]string(nil), n.sent...)
+	sort.Strings(names)
+	return names
+}
+
+type fixture struct {
+	service    *Service
+	interviews *memory.InterviewRepository
+	reminders  *memory.ReminderRepository
+	notifier   *notifier
+	id         string
+}
+
+// newFixture returns a service reminding 24 hours and 1 hour before an
+// interview of Carl with Rita and Ralf starting in startsIn, Ralf declined it
+func newFixture(t *testing.T, startsIn time.Duration) fixture {
+	t.Helper()
+
+	ctx := organization.ContextWithTenant(context.Background(), tenantID)
+	f := fixture{
+		interviews: memory.NewInterviewRepository(),
+		reminders:  memory.NewReminderRepository(),
+		notifier:   &notifier{},
+	}
+	candidates := memory.NewCandidateRepository()
+	recruiters := memory.NewRecruiterRepository()
+
+	name := func(s string) *string { return &s }
+	candidateID, _ := candidates.Add(ctx, candidate.Entity{FullName: name("Carl")})
+	ritaID, _ := recruiters.Add(ctx, recruiter.Entity{FullName: name("Rita")})
+	ralfID, _ := recruiters.Add(ctx, recruiter.Entity{FullName: name("Ralf")})
+
+	startsAt := time.Now().UTC().Add(startsIn).Truncate(time.Second)
+	endsAt := startsAt.Add(time.Hour)
+	participants := interview.NewParticipants(candidateID, []string{ritaID, ralfID})
+	participants[2].Status = interview.ResponseDeclined
+
+	f.id, _ = f.interviews.Add(ctx, interview.Entity{
+		CandidateID:  &candidateID,
+		RecruiterID:  &ritaID,
+		Title:        name("Interview"),
+		Location:     name("Room 1"),
+		StartsAt:     &startsAt,
+		EndsAt:       &endsAt,
+		Status:       name(interview.StatusScheduled),
+		Participants: participants,
+	})
+
+	var err error
+	f.service, err = New(
+		WithInterviewRepository(f.interviews),
+		WithReminderRepository(f.reminders),
+		WithCandidateRepository(candidates),
+		WithRecruiterRepository(recruiters),
+		WithUnitOfWork(memory.NewUnitOfWork()),
+		WithNotifier(f.notifier),
+		WithOffsets(time.Hour, 24*time.Hour),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+// pending returns the number of reminders not sent nor skipped yet
+func (f fixture) pending(t *testing.T) int {
+	t.Helper()
+
+	ctx := organization.ContextWithSystem(context.Background())
+	due, err := f.reminders.ClaimDue(ctx, time.Now().Add(48*time.Hour), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(due)
+}
+
+func TestRemind(t *testing.T) {
+	tests := []struct {
+		name     string
+		startsIn time.Duration
+		// change returns the update made to the interview once its reminders are planned
+		change      func(current interview.Entity) interview.Entity
+		want        []string
+		wantPending int
+	}{
+		{
+			name:     "only the closest due reminder is sent",
+			startsIn: 30 * time.Minute,
+			want:     []string{"Carl", "Rita"},
+		},
+		{
+			name:        "reminders not due yet are kept",
+			startsIn:    3 * time.Hour,
+			want:        []string{"Carl", "Rita"},
+			wantPending: 1,
+		},
+		{
+			name:     "cancelled interviews are not reminded",
+			startsIn: 30 * time.Minute,
+			change: func(current interview.Entity) interview.Entity {
+				status := interview.StatusCancelled
+				return interview.Entity{Status: &status}
+			},
+		},
+		{
+			name:     "rescheduled interviews are not reminded of the old start",
+			startsIn: 30 * time.Minute,
+			change: func(current interview.Entity) interview.Entity {
+				startsAt := current.StartsAt.Add(20 * time.Minute)
+				return interview.Entity{StartsAt: &startsAt}
+			},
+		},
+		{
+			name:     "interviews too far ahead are not planned",
+			startsIn: 48 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.startsIn)
+			ctx := context.Background()
+
+			f.service.Plan(ctx)
+			// planning again does not add the reminders twice
+			f.service.Plan(ctx)
+
+			if tt.change != nil {
+				tenant := organization.ContextWithTenant(ctx, tenantID)
+				current, err := f.interviews.Get(tenant, f.id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err = f.interviews.Update(tenant, f.id, tt.change(current)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := f.service.Dispatch(ctx); err != nil {
+				t.Fatalf("Dispatch() error = %v", err)
+			}
+			// the reminders are not sent again
+			if _, err := f.service.Dispatch(ctx); err != nil {
+				t.Fatalf("Dispatch() error = %v", err)
+			}
+
+			if got := f.notifier.names(); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("reminded %q, want %q", got, tt.want)
+			}
+			if got := f.pending(t); got != tt.wantPending {
+				t.Errorf("%d reminders are pending, want %d", got, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestWithOffsets(t *testing.T) {
+	s, err := New(WithOffsets(time.Hour, 24*time.Hour, 15*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []time.Duration{24 * time.Hour, time.Hour, 15 * time.Minute}; len(s.offsets) != 3 || s.offsets[0] != want[0] || s.offsets[2] != want[2] {
+		t.Errorf("offsets = %v, want %v", s.offsets, want)
+	}
+
+	if _, err = New(WithOffsets(time.Hour, 0)); err == nil {
+		t.Error("New() error = nil, want an error for a zero offset")
+	}
+}
@@ -0,0 +1,148 @@
+package reminder
+
+import (
+	"errors"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/reminder"
+	"reservation-system/pkg/store"
+	"sort"
+	"time"
+)
+
+const (
+	defaultInterval  = time.Minute
+	defaultBatchSize = 100
+)
+
+var defaultOffsets = []time.Duration{24 * time.Hour, time.Hour}
+
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	interviewRepository interview.Repository
+	reminderRepository  reminder.Repository
+	candidateRepository candidate.Repository
+	recruiterRepository recruiter.Repository
+	unitOfWork          store.UnitOfWork
+	notifier            notification.Notifier
+
+	offsets  []time.Duration
+	interval time.Duration
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Create the service
+	s = &Service{
+		offsets:  defaultOffsets,
+		interval: defaultInterval,
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// WithInterviewRepository applies a given interview repository to the Service
+func WithInterviewRepository(interviewRepository interview.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.interviewRepository = interviewRepository
+		return nil
+	}
+}
+
+// WithReminderRepository applies a given reminder repository to the Service
+func WithReminderRepository(reminderRepository reminder.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.reminderRepository = reminderRepository
+		return nil
+	}
+}
+
+// WithCandidateRepository applies a given candidate repository to the Service
+func WithCandidateRepository(candidateRepository candidate.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.candidateRepository = candidateRepository
+		return nil
+	}
+}
+
+// WithRecruiterRepository applies a given recruiter repository to the Service
+func WithRecruiterRepository(recruiterRepository recruiter.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.recruiterRepository = recruiterRepository
+		return nil
+	}
+}
+
+// WithUnitOfWork applies a given unit of work to the Service, the reminders are
+// claimed and marked sent in the unit of work queueing their notifications
+func WithUnitOfWork(unitOfWork store.UnitOfWork) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.unitOfWork = unitOfWork
+		return nil
+	}
+}
+
+// WithNotifier applies a given notifier to the Service
+func WithNotifier(notifier notification.Notifier) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.notifier = notifier
+		return nil
+	}
+}
+
+// WithOffsets sets how long before the start of an interview its reminders are sent
+func WithOffsets(offsets ...time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if len(offsets) == 0 {
+			return nil
+		}
+		for _, offset := range offsets {
+			if offset <= 0 {
+				return errors.New("reminder offsets must be positive")
+			}
+		}
+		s.offsets = append([]time.Duration(nil), offsets...)
+		sort.Slice(s.offsets, func(i, j int) bool {
+			return s.offsets[i] > s.offsets[j]
+		})
+		return nil
+	}
+}
+
+// WithInterval sets how often the upcoming interviews are scanned
+func WithInterval(interval time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if interval > 0 {
+			s.interval = interval
+		}
+		return nil
+	}
+}
@@ -0,0 +1,171 @@
+package reservation
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/application"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"time"
+)
+
+func (s *Service) ListApplications(ctx context.Context, filter application.Filter) (res []application.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListApplications")
+
+	data, err := s.applicationRepository.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = application.ParseFromEntities(data)
+
+	return
+}
+
+// AddApplication applies the candidate to an open vacancy, the application
+// starts at the first stage of the pipeline of the vacancy. A candidate has
+// one active application to a vacancy at a time.
+func (s *Service) AddApplication(ctx context.Context, req application.Request) (res application.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddApplication")
+
+	now := time.Now().UTC()
+	status := application.StatusActive
+	data := application.Entity{
+		CandidateID: &req.CandidateID,
+		VacancyID:   &req.VacancyID,
+		Status:      &status,
+		AppliedAt:   &now,
+		Version:     1,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if _, err = s.candidateRepository.Get(ctx, req.CandidateID); err != nil {
+			return participantError(err, entityCandidate, req.CandidateID)
+		}
+		opening, err := s.vacancyRepository.Get(ctx, req.VacancyID)
+		if err != nil {
+			return participantError(err, entityVacancy, req.VacancyID)
+		}
+		if *opening.Status != vacancy.StatusOpen {
+			return apperror.Conflict("vacancy %s is %s", req.VacancyID, *opening.Status)
+		}
+
+		active, err := s.applicationRepository.List(ctx, application.Filter{CandidateID: req.CandidateID, VacancyID: req.VacancyID, Status: application.StatusActive})
+		if err != nil {
+			return
+		}
+		if len(active) > 0 {
+			return apperror.Conflict("candidate %s already applied to vacancy %s with application %s", req.CandidateID, req.VacancyID, active[0].ID)
+		}
+
+		stage := opening.Stages.First()
+		data.Stage = &stage
+		data.History = []application.Transition{{
+			Stage:     stage,
+			Status:    status,
+			Note:      req.Note,
+			Actor:     audit.ActorFromContext(ctx),
+			ChangedAt: now,
+		}}
+
+		data.ID, err = s.applicationRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = application.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionCreate, entityApplication, data.ID, nil, res)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) GetApplication(ctx context.Context, id string) (res application.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetApplication").With(zap.String("id", id))
+
+	data, err := s.applicationRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, entityApplication, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = application.ParseFromEntity(data)
+
+	return
+}
+
+// MoveApplication moves the application to another stage of the pipeline of
+// its vacancy, in any direction, or closes it. A candidate is only hired at
+// the last stage of the pipeline and closed applications do not move anymore.
+func (s *Service) MoveApplication(ctx context.Context, id string, version int, req application.MoveRequest) (res application.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("MoveApplication").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.applicationRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		if application.IsFinal(*current.Status) {
+			return apperror.Conflict("application %s is %s", id, *current.Status)
+		}
+		opening, err := s.vacancyRepository.Get(ctx, *current.VacancyID)
+		if err != nil {
+			return
+		}
+
+		transition := application.Transition{
+			FromStage: *current.Stage,
+			Stage:     req.Stage,
+			Status:    req.Status,
+			Note:      req.Note,
+			Actor:     audit.ActorFromContext(ctx),
+			ChangedAt: time.Now().UTC(),
+		}
+		if transition.Stage == "" {
+			transition.Stage = *current.Stage
+		}
+		if transition.Status == "" {
+			transition.Status = application.StatusActive
+		}
+
+		switch {
+		case opening.Stages.Index(transition.Stage) < 0:
+			return apperror.Unprocessable("stage: %s is not part of the pipeline of vacancy %s", transition.Stage, *current.VacancyID)
+		case transition.Status == application.StatusHired && transition.Stage != opening.Stages.Last():
+			return apperror.Unprocessable("status: candidates are hired at the %s stage", opening.Stages.Last())
+		case transition.Status == application.StatusActive && transition.Stage == *current.Stage:
+			return apperror.Conflict("application %s is already at stage %s", id, transition.Stage)
+		}
+
+		if err = s.applicationRepository.Move(ctx, id, version, transition); err != nil {
+			return
+		}
+		moved := current
+		moved.Stage = &transition.Stage
+		moved.Status = &transition.Status
+		moved.History = append(append([]application.Transition{}, current.History...), transition)
+		moved.Version = current.Version + 1
+		res = application.ParseFromEntity(moved)
+
+		return s.record(ctx, audit.ActionMove, entityApplication, id, application.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityApplication, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to move by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
@@ -0,0 +1,204 @@
+package reservation
+
+import (
+	"reservation-system/internal/domain/application"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/pkg/apperror"
+	"testing"
+)
+
+func newPipelineFixture(t *testing.T) fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	for _, cfg := range []Configuration{
+		WithVacancyRepository(memory.NewVacancyRepository()),
+		WithApplicationRepository(memory.NewApplicationRepository()),
+	} {
+		if err := cfg(f.service); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func (f fixture) vacancy(t *testing.T, status string, stages ...string) vacancy.Response {
+	t.Helper()
+
+	req := vacancy.Request{Title: "Backend engineer", OwnerID: f.recruiter(t, recruiter.Entity{}), Status: status, Stages: stages}
+	if err := req.Bind(nil); err != nil {
+		t.Fatal(err)
+	}
+	res, err := f.service.AddVacancy(f.ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestAddApplication(t *testing.T) {
+	f := newPipelineFixture(t)
+	open := f.vacancy(t, vacancy.StatusOpen, "screening", "onsite", "offer")
+	onHold := f.vacancy(t, vacancy.StatusOnHold)
+	closed := f.vacancy(t, vacancy.StatusClosed)
+	applied := f.candidate(t)
+	if _, err := f.service.AddApplication(f.ctx, application.Request{CandidateID: applied, VacancyID: open.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		req      application.Request
+		wantKind apperror.Kind
+	}{
+		{name: "open vacancy", req: application.Request{CandidateID: f.candidate(t), VacancyID: open.ID, Note: "referral"}},
+		{name: "vacancy on hold", req: application.Request{CandidateID: f.candidate(t), VacancyID: onHold.ID}, wantKind: apperror.KindConflict},
+		{name: "closed vacancy", req: application.Request{CandidateID: f.candidate(t), VacancyID: closed.ID}, wantKind: apperror.KindConflict},
+		{name: "already applied", req: application.Request{CandidateID: applied, VacancyID: open.ID}, wantKind: apperror.KindConflict},
+		{name: "unknown candidate", req: application.Request{CandidateID: "00000000-0000-0000-0000-000000000000", VacancyID: open.ID}, wantKind: apperror.KindUnprocessable},
+		{name: "unknown vacancy", req: application.Request{CandidateID: f.candidate(t), VacancyID: "00000000-0000-0000-0000-000000000000"}, wantKind: apperror.KindUnprocessable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := f.service.AddApplication(f.ctx, tt.req)
+			if tt.wantKind != 0 {
+				if apperror.KindOf(err) != tt.wantKind {
+					t.Errorf("AddApplication() error = %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AddApplication() error = %v", err)
+			}
+			if res.Stage != "screening" || res.Status != application.StatusActive || len(res.History) != 1 || res.History[0].Note != tt.req.Note {
+				t.Errorf("AddApplication() = %+v, want it active at the first stage", res)
+			}
+		})
+	}
+}
+
+func TestMoveApplication(t *testing.T) {
+	tests := []struct {
+		name       string
+		moves      []application.MoveRequest
+		wantStage  string
+		wantStatus string
+		wantKind   apperror.Kind
+	}{
+		{name: "forward", moves: []application.MoveRequest{{Stage: "onsite"}}, wantStage: "onsite", wantStatus: application.StatusActive},
+		{name: "back", moves: []application.MoveRequest{{Stage: "offer"}, {Stage: "screening"}}, wantStage: "screening", wantStatus: application.StatusActive},
+		{name: "rejected at its stage", moves: []application.MoveRequest{{Status: application.StatusRejected}}, wantStage: "screening", wantStatus: application.StatusRejected},
+		{name: "hired at the last stage", moves: []application.MoveRequest{{Stage: "offer", Status: application.StatusHired}}, wantStage: "offer", wantStatus: application.StatusHired},
+		{name: "hired before the last stage", moves: []application.MoveRequest{{Stage: "onsite", Status: application.StatusHired}}, wantKind: apperror.KindUnprocessable},
+		{name: "stage outside the pipeline", moves: []application.MoveRequest{{Stage: "technical"}}, wantKind: apperror.KindUnprocessable},
+		{name: "already at the stage", moves: []application.MoveRequest{{Stage: "screening"}}, wantKind: apperror.KindConflict},
+		{name: "closed application", moves: []application.MoveRequest{{Status: application.StatusWithdrawn}, {Stage: "onsite"}}, wantKind: apperror.KindConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPipelineFixture(t)
+			opening := f.vacancy(t, vacancy.StatusOpen, "screening", "onsite", "offer")
+			res, err := f.service.AddApplication(f.ctx, application.Request{CandidateID: f.candidate(t), VacancyID: opening.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, req := range tt.moves {
+				res, err = f.service.MoveApplication(f.ctx, res.ID, res.Version, req)
+				if err != nil && i < len(tt.moves)-1 {
+					t.Fatal(err)
+				}
+			}
+			if tt.wantKind != 0 {
+				if apperror.KindOf(err) != tt.wantKind {
+					t.Errorf("MoveApplication() error = %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MoveApplication() error = %v", err)
+			}
+
+			stored, err := f.service.GetApplication(f.ctx, res.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Stage != tt.wantStage || stored.Status != tt.wantStatus {
+				t.Errorf("GetApplication() at %s and %s, want %s and %s", stored.Stage, stored.Status, tt.wantStage, tt.wantStatus)
+			}
+			if len(stored.History) != len(tt.moves)+1 || stored.Version != len(tt.moves)+1 {
+				t.Errorf("GetApplication() has %d transitions at version %d, want %d", len(stored.History), stored.Version, len(tt.moves)+1)
+			}
+		})
+	}
+}
+
+func TestMoveApplicationOutdated(t *testing.T) {
+	f := newPipelineFixture(t)
+	opening := f.vacancy(t, vacancy.StatusOpen)
+	res, err := f.service.AddApplication(f.ctx, application.Request{CandidateID: f.candidate(t), VacancyID: opening.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.service.MoveApplication(f.ctx, res.ID, res.Version, application.MoveRequest{Stage: "technical"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = f.service.MoveApplication(f.ctx, res.ID, res.Version, application.MoveRequest{Stage: "onsite"})
+	if apperror.KindOf(err) != apperror.KindPreconditionFailed {
+		t.Errorf("MoveApplication() error = %v, want a failed precondition", err)
+	}
+}
+
+func TestUpdateVacancyStages(t *testing.T) {
+	f := newPipelineFixture(t)
+	opening := f.vacancy(t, vacancy.StatusOpen, "screening", "onsite", "offer")
+	res, err := f.service.AddApplication(f.ctx, application.Request{CandidateID: f.candidate(t), VacancyID: opening.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.service.MoveApplication(f.ctx, res.ID, res.Version, application.MoveRequest{Stage: "onsite"}); err != nil {
+		t.Fatal(err)
+	}
+	rejected, err := f.service.AddApplication(f.ctx, application.Request{CandidateID: f.candidate(t), VacancyID: opening.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.service.MoveApplication(f.ctx, rejected.ID, rejected.Version, application.MoveRequest{Stage: "offer", Status: application.StatusRejected}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := vacancy.Request{Title: opening.Title, OwnerID: opening.OwnerID, Status: opening.Status, Stages: []string{"screening", "offer"}}
+	if _, err = f.service.UpdateVacancy(f.ctx, opening.ID, opening.Version, req); apperror.KindOf(err) != apperror.KindConflict {
+		t.Fatalf("UpdateVacancy() without the stage of an active application error = %v, want a conflict", err)
+	}
+
+	// only the active applications hold on to their stage
+	req.Stages = []string{"screening", "onsite"}
+	if _, err = f.service.UpdateVacancy(f.ctx, opening.ID, opening.Version, req); err != nil {
+		t.Errorf("UpdateVacancy() error = %v", err)
+	}
+}
+
+func TestDeleteVacancy(t *testing.T) {
+	f := newPipelineFixture(t)
+	unused := f.vacancy(t, vacancy.StatusOpen)
+	opening := f.vacancy(t, vacancy.StatusOpen)
+	if _, err := f.service.AddApplication(f.ctx, application.Request{CandidateID: f.candidate(t), VacancyID: opening.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.service.DeleteVacancy(f.ctx, opening.ID, opening.Version); apperror.KindOf(err) != apperror.KindConflict {
+		t.Errorf("DeleteVacancy() with applications error = %v, want a conflict", err)
+	}
+	if err := f.service.DeleteVacancy(f.ctx, unused.ID, unused.Version); err != nil {
+		t.Errorf("DeleteVacancy() error = %v", err)
+	}
+	if _, err := f.service.GetVacancy(f.ctx, unused.ID); apperror.KindOf(err) != apperror.KindNotFound {
+		t.Errorf("GetVacancy() error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,181 @@
+package reservation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"path/filepath"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/blob"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"strings"
+	"time"
+)
+
+const defaultMaxAttachmentSize = 10 << 20
+
+func (s *Service) ListAttachments(ctx context.Context, candidateID string) (res []candidate.AttachmentResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListAttachments").With(zap.String("candidate_id", candidateID))
+
+	if _, err = s.candidateRepository.Get(ctx, candidateID); err != nil {
+		err = repositoryError(err, entityCandidate, candidateID)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get candidate", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.attachmentRepository.List(ctx, candidateID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = candidate.ParseFromAttachments(data)
+
+	return
+}
+
+// UploadAttachment streams the content to the blob store before recording
+// the attachment, the blob is removed again when the recording fails
+func (s *Service) UploadAttachment(ctx context.Context, candidateID, fileName string, content io.Reader) (res candidate.AttachmentResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UploadAttachment").With(zap.String("candidate_id", candidateID))
+
+	if s.blobStore == nil {
+		return res, apperror.Unprocessable("attachments are not available")
+	}
+
+	fileName = filepath.Base(strings.ReplaceAll(fileName, `\`, "/"))
+	contentType, ok := candidate.AttachmentTypes[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return res, apperror.Validation("file: %s is not a supported document", fileName)
+	}
+
+	if _, err = s.candidateRepository.Get(ctx, candidateID); err != nil {
+		err = repositoryError(err, entityCandidate, candidateID)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get candidate", zap.Error(err))
+		}
+		return
+	}
+
+	data := candidate.Attachment{
+		ID:          uuid.New().String(),
+		CandidateID: candidateID,
+		FileName:    fileName,
+		ContentType: contentType,
+		UploadedAt:  time.Now().UTC(),
+	}
+
+	// one byte past the limit is read to tell a file of the maximum size
+	// from a larger one
+	hash := sha256.New()
+	data.Size, err = s.blobStore.Put(ctx, data.Key(), io.TeeReader(io.LimitReader(content, s.maxAttachmentSize+1), hash))
+	if err != nil {
+		logger.Error("failed to store content", zap.Error(err))
+		s.deleteBlob(ctx, data.Key())
+		return
+	}
+	if data.Size > s.maxAttachmentSize {
+		s.deleteBlob(ctx, data.Key())
+		return res, apperror.Validation("file: at most %d bytes", s.maxAttachmentSize)
+	}
+	data.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.attachmentRepository.Add(ctx, data); err != nil {
+			return
+		}
+		res = candidate.ParseFromAttachment(data)
+
+		return s.record(ctx, audit.ActionCreate, entityAttachment, data.ID, nil, res)
+	})
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		s.deleteBlob(ctx, data.Key())
+		return
+	}
+
+	return
+}
+
+// DownloadAttachment returns the attachment along with its content, which
+// the caller has to close
+func (s *Service) DownloadAttachment(ctx context.Context, candidateID, id string) (res candidate.AttachmentResponse, content io.ReadCloser, err error) {
+	logger := log.LoggerFromContext(ctx).Named("DownloadAttachment").With(zap.String("candidate_id", candidateID), zap.String("id", id))
+
+	data, err := s.getAttachment(ctx, candidateID, id)
+	if err == nil && s.blobStore == nil {
+		err = apperror.Unprocessable("attachments are not available")
+	}
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	content, err = s.blobStore.Get(ctx, data.Key())
+	if err != nil {
+		logger.Error("failed to get content", zap.Error(err))
+		return
+	}
+	res = candidate.ParseFromAttachment(data)
+
+	return
+}
+
+// DeleteAttachment forgets the attachment before removing its content, a
+// content left behind by a failure is only wasted space
+func (s *Service) DeleteAttachment(ctx context.Context, candidateID, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteAttachment").With(zap.String("candidate_id", candidateID), zap.String("id", id))
+
+	var current candidate.Attachment
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err = s.getAttachment(ctx, candidateID, id)
+		if err != nil {
+			return
+		}
+
+		if err = s.attachmentRepository.Delete(ctx, id); err != nil {
+			return repositoryError(err, entityAttachment, id)
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityAttachment, id, candidate.ParseFromAttachment(current), nil)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+	s.deleteBlob(ctx, current.Key())
+
+	return
+}
+
+// getAttachment returns the attachment when it belongs to the candidate
+func (s *Service) getAttachment(ctx context.Context, candidateID, id string) (data candidate.Attachment, err error) {
+	data, err = s.attachmentRepository.Get(ctx, id)
+	if err == nil && data.CandidateID != candidateID {
+		err = store.ErrorNotFound
+	}
+
+	return data, repositoryError(err, entityAttachment, id)
+}
+
+// deleteBlob removes the content from the blob store, failures are only logged
+func (s *Service) deleteBlob(ctx context.Context, key string) {
+	if s.blobStore == nil {
+		return
+	}
+	if err := s.blobStore.Delete(ctx, key); err != nil && !errors.Is(err, blob.ErrorNotFound) {
+		log.LoggerFromContext(ctx).Warn("failed to delete blob", zap.String("key", key), zap.Error(err))
+	}
+}
@@ -0,0 +1,94 @@
+package reservation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/blob"
+	"strings"
+	"testing"
+)
+
+func TestAttachments(t *testing.T) {
+	f := newFixture(t)
+	store, err := blob.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cfg := range []Configuration{
+		WithAttachmentRepository(memory.NewCandidateAttachmentRepository()),
+		WithBlobStore(store),
+		WithMaxAttachmentSize(16),
+	} {
+		if err = cfg(f.service); err != nil {
+			t.Fatal(err)
+		}
+	}
+	candidateID, otherID := f.candidate(t), f.candidate(t)
+
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		wantName string
+		wantType string
+		wantKind apperror.Kind
+	}{
+		{name: "pdf", fileName: "cv.PDF", content: "%PDF-1.7", wantName: "cv.PDF", wantType: "application/pdf"},
+		{name: "of the maximum size", fileName: "notes.txt", content: strings.Repeat("a", 16), wantName: "notes.txt", wantType: "text/plain"},
+		{name: "directories are dropped", fileName: `C:\Users\jane\..\cv.docx`, content: "docx", wantName: "cv.docx", wantType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{name: "too large", fileName: "cv.pdf", content: strings.Repeat("a", 17), wantKind: apperror.KindValidation},
+		{name: "unsupported type", fileName: "cv.exe", content: "MZ", wantKind: apperror.KindValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := f.service.UploadAttachment(f.ctx, candidateID, tt.fileName, strings.NewReader(tt.content))
+			if tt.wantKind != apperror.KindInternal {
+				if apperror.KindOf(err) != tt.wantKind {
+					t.Fatalf("UploadAttachment() error = %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UploadAttachment() error = %v", err)
+			}
+
+			sum := sha256.Sum256([]byte(tt.content))
+			if res.FileName != tt.wantName || res.ContentType != tt.wantType || res.Size != int64(len(tt.content)) || res.Checksum != hex.EncodeToString(sum[:]) {
+				t.Errorf("UploadAttachment() = %+v, want %s of type %s", res, tt.wantName, tt.wantType)
+			}
+
+			_, content, err := f.service.DownloadAttachment(f.ctx, candidateID, res.ID)
+			if err != nil {
+				t.Fatalf("DownloadAttachment() error = %v", err)
+			}
+			src, _ := io.ReadAll(content)
+			content.Close()
+			if string(src) != tt.content {
+				t.Errorf("DownloadAttachment() content = %q, want %q", src, tt.content)
+			}
+
+			// the attachment is only reachable through its candidate
+			if _, _, err = f.service.DownloadAttachment(f.ctx, otherID, res.ID); apperror.KindOf(err) != apperror.KindNotFound {
+				t.Errorf("DownloadAttachment() of another candidate error = %v, want not found", err)
+			}
+
+			if err = f.service.DeleteAttachment(f.ctx, candidateID, res.ID); err != nil {
+				t.Fatalf("DeleteAttachment() error = %v", err)
+			}
+			if _, err = store.Get(f.ctx, "candidates/"+candidateID+"/"+res.ID); err != blob.ErrorNotFound {
+				t.Errorf("content after DeleteAttachment() error = %v, want it removed", err)
+			}
+		})
+	}
+
+	if res, err := f.service.ListAttachments(f.ctx, candidateID); err != nil || len(res) != 0 {
+		t.Errorf("ListAttachments() = %v, %v, want none left", res, err)
+	}
+	if _, err := f.service.UploadAttachment(f.ctx, "unknown", "cv.pdf", strings.NewReader("%PDF")); apperror.KindOf(err) != apperror.KindNotFound {
+		t.Errorf("UploadAttachment() for an unknown candidate error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,120 @@
+package reservation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/outbox"
+	"reservation-system/pkg/log"
+	"time"
+)
+
+const (
+	entityCandidate         = "candidate"
+	entityNote              = "candidate_note"
+	entityAttachment        = "candidate_attachment"
+	entityRecruiter         = "recruiter"
+	entityInterview         = "interview"
+	entityResource          = "resource"
+	entityVacancy           = "vacancy"
+	entityApplication       = "application"
+	entityScorecardTemplate = "scorecard_template"
+	entityScorecard         = "scorecard"
+	entityTeam              = "team"
+	entityTeamMember        = "team_member"
+)
+
+func (s *Service) ListAudit(ctx context.Context, filter audit.Filter) (res []audit.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListAudit")
+
+	data, err := s.auditRepository.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = audit.ParseFromEntities(data)
+
+	return
+}
+
+// record appends an audit entry for the mutation of the entity and publishes
+// the change event, before is nil for created entities and after is nil for
+// deleted ones. Within a transaction a failure to record rolls the mutation back.
+func (s *Service) record(ctx context.Context, action, entityType, entityID string, before, after any) (err error) {
+	if err = s.publish(ctx, action, entityType, entityID, before, after); err != nil {
+		return
+	}
+
+	if s.auditRepository == nil {
+		return
+	}
+
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s %s: %w", entityType, entityID, err)
+	}
+
+	data := audit.Entity{
+		Actor:      audit.ActorFromContext(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		RequestID:  middleware.GetReqID(ctx),
+	}
+
+	data.Changes, err = json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal changes of %s %s: %w", entityType, entityID, err)
+	}
+
+	_, err = s.auditRepository.Add(ctx, data)
+
+	return
+}
+
+// publish writes the change event to the outbox when there is one, so that it
+// is only relayed once the mutation has committed, or publishes it right away
+func (s *Service) publish(ctx context.Context, action, entityType, entityID string, before, after any) (err error) {
+	data := event.Event{
+		TenantID:   organization.TenantFromContext(ctx),
+		Type:       event.Type(entityType, action),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Actor:      audit.ActorFromContext(ctx),
+		RequestID:  middleware.GetReqID(ctx),
+		Data:       after,
+		Time:       time.Now().UTC(),
+	}
+	if after == nil {
+		data.Data = before
+	}
+
+	switch {
+	case s.outboxRepository != nil:
+		entry := outbox.Entity{
+			EventType: data.Type,
+		}
+		if entry.Payload, err = json.Marshal(data); err != nil {
+			return fmt.Errorf("failed to marshal event %s: %w", data.Type, err)
+		}
+		_, err = s.outboxRepository.Add(ctx, entry)
+	case s.eventPublisher != nil:
+		s.eventPublisher.Publish(ctx, data)
+	}
+
+	return
+}
+
+// transaction runs fn in a unit of work when the service has one
+func (s *Service) transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.unitOfWork == nil {
+		return fn(ctx)
+	}
+	return s.unitOfWork.Do(ctx, fn)
+}
@@ -0,0 +1,236 @@
+package reservation
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"time"
+)
+
+const (
+	defaultAvailabilityRange = 7 * 24 * time.Hour
+	maxAvailabilityRange     = 62 * 24 * time.Hour
+
+	defaultAvailabilityLimit = 50
+	maxAvailabilityLimit     = 500
+
+	maxAvailabilityRecruiters = 20
+)
+
+// FindAvailability returns the windows in which all the recruiters are
+// within their working hours and free of interviews and busy times, the
+// windows shorter than the requested duration are left out
+func (s *Service) FindAvailability(ctx context.Context, req availability.Request) (res []availability.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("FindAvailability")
+
+	if req, err = s.checkAvailabilityRequest(req); err != nil {
+		return
+	}
+
+	free, err := s.freeWindows(ctx, req.RecruiterIDs, req.From, req.To)
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to compute availability", zap.Error(err))
+		}
+		return
+	}
+
+	windows := make([]availability.Window, 0, len(free))
+	for _, window := range free {
+		if window.Duration() < req.Duration {
+			continue
+		}
+		windows = append(windows, window)
+		if len(windows) == req.Limit {
+			break
+		}
+	}
+	res = availability.ParseFromWindows(windows)
+
+	return
+}
+
+// freeWindows returns the sorted windows within [from, to) in which all the
+// recruiters are free
+func (s *Service) freeWindows(ctx context.Context, recruiterIDs []string, from, to time.Time) (free []availability.Window, err error) {
+	blocks, err := s.busyBlocks(ctx, recruiterIDs, from, to)
+	if err != nil {
+		return
+	}
+
+	for i, recruiterID := range recruiterIDs {
+		var data recruiter.Entity
+		if data, err = s.recruiterRepository.Get(ctx, recruiterID); err != nil {
+			return nil, participantError(err, entityRecruiter, recruiterID)
+		}
+		hours := s.recruiterHours(data)
+
+		// the interviews of the whole weeks around the range count towards the caps
+		var interviews []interview.Entity
+		interviews, err = s.interviewRepository.List(ctx, interview.Filter{
+			RecruiterID: recruiterID,
+			From:        availability.Week(from, hours.Location).StartsAt,
+			To:          availability.Week(to.Add(-time.Nanosecond), hours.Location).EndsAt,
+			Status:      interview.StatusScheduled,
+		})
+		if err != nil {
+			return
+		}
+
+		taken := append(interviewWindows(interviews), blocks[recruiterID]...)
+		taken = append(taken, data.Absences()...)
+		taken = append(taken, fullWindows(data, hours.Location, interviews)...)
+
+		windows := availability.Subtract(hours.Windows(from, to), taken)
+		if i == 0 {
+			free = windows
+		} else {
+			free = availability.Intersect(free, windows)
+		}
+		if len(free) == 0 {
+			return
+		}
+	}
+
+	return
+}
+
+// recruiterHours returns the working hours of the recruiter, the ones of the
+// service when the recruiter has none of their own
+func (s *Service) recruiterHours(data recruiter.Entity) availability.WorkingHours {
+	if data.WorkingHours == nil || data.WorkingHours.IsZero() {
+		return s.workingHours
+	}
+	hours, err := data.WorkingHours.Hours()
+	if err != nil {
+		return s.workingHours
+	}
+	return hours
+}
+
+// fullWindows returns the days and weeks of loc in which the interviews of
+// the recruiter have reached their caps
+func fullWindows(data recruiter.Entity, loc *time.Location, interviews []interview.Entity) (dest []availability.Window) {
+	days, weeks := capacityUsage(loc, interviews, "")
+	if data.MaxPerDay != nil && *data.MaxPerDay > 0 {
+		for _, usage := range days {
+			if usage.count >= *data.MaxPerDay {
+				dest = append(dest, usage.window)
+			}
+		}
+	}
+	if data.MaxPerWeek != nil && *data.MaxPerWeek > 0 {
+		for _, usage := range weeks {
+			if usage.count >= *data.MaxPerWeek {
+				dest = append(dest, usage.window)
+			}
+		}
+	}
+	return
+}
+
+type usage struct {
+	window availability.Window
+	count  int
+}
+
+// usages are keyed by the date their window starts on
+type usages map[string]*usage
+
+func (u usages) add(window availability.Window) {
+	key := window.StartsAt.Format("2006-01-02")
+	if u[key] == nil {
+		u[key] = &usage{window: window}
+	}
+	u[key].count++
+}
+
+// of returns the count of the window
+func (u usages) of(window availability.Window) int {
+	if item := u[window.StartsAt.Format("2006-01-02")]; item != nil {
+		return item.count
+	}
+	return 0
+}
+
+// capacityUsage counts the interviews by the day and the week of loc they
+// start in, leaving out the interview with the excluded id
+func capacityUsage(loc *time.Location, interviews []interview.Entity, excluded string) (days, weeks usages) {
+	days, weeks = make(usages), make(usages)
+	for _, item := range interviews {
+		if item.ID == excluded {
+			continue
+		}
+		days.add(availability.Day(*item.StartsAt, loc))
+		weeks.add(availability.Week(*item.StartsAt, loc))
+	}
+	return
+}
+
+// busyBlocks returns the busy times of the recruiters imported from external calendars
+func (s *Service) busyBlocks(ctx context.Context, recruiterIDs []string, from, to time.Time) (dest map[string][]availability.Window, err error) {
+	dest = make(map[string][]availability.Window)
+	if s.busyRepository == nil {
+		return
+	}
+
+	data, err := s.busyRepository.List(ctx, busy.Filter{RecruiterIDs: recruiterIDs, From: from, To: to})
+	if err != nil {
+		return
+	}
+	for _, block := range data {
+		dest[block.RecruiterID] = append(dest[block.RecruiterID], availability.Window{StartsAt: block.StartsAt, EndsAt: block.EndsAt})
+	}
+
+	return
+}
+
+func (s *Service) checkAvailabilityRequest(req availability.Request) (availability.Request, error) {
+	seen := make(map[string]bool, len(req.RecruiterIDs))
+	recruiterIDs := make([]string, 0, len(req.RecruiterIDs))
+	for _, id := range req.RecruiterIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			recruiterIDs = append(recruiterIDs, id)
+		}
+	}
+	req.RecruiterIDs = recruiterIDs
+
+	switch {
+	case len(req.RecruiterIDs) == 0:
+		return req, apperror.Validation("recruiters: cannot be blank")
+	case len(req.RecruiterIDs) > maxAvailabilityRecruiters:
+		return req, apperror.Validation("recruiters: at most %d recruiters", maxAvailabilityRecruiters)
+	}
+
+	if req.From.IsZero() {
+		req.From = time.Now().UTC()
+	}
+	if req.To.IsZero() {
+		req.To = req.From.Add(defaultAvailabilityRange)
+	}
+	switch {
+	case !req.To.After(req.From):
+		return req, apperror.Validation("to: must be after from")
+	case req.To.Sub(req.From) > maxAvailabilityRange:
+		return req, apperror.Validation("to: at most %d days after from", int(maxAvailabilityRange/(24*time.Hour)))
+	}
+
+	if req.Duration <= 0 {
+		return req, apperror.Validation("duration: must be positive")
+	}
+
+	switch {
+	case req.Limit == 0:
+		req.Limit = defaultAvailabilityLimit
+	case req.Limit < 0 || req.Limit > maxAvailabilityLimit:
+		return req, apperror.Validation("limit: must be between 1 and %d", maxAvailabilityLimit)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,199 @@
+package reservation
+
+import (
+	"context"
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/pkg/apperror"
+	"testing"
+	"time"
+)
+
+const tenantID = "00000000-0000-0000-0000-000000000001"
+
+// fixture is a service on the memory repositories with the default working
+// hours from 09:00 to 17:00 UTC on weekdays
+type fixture struct {
+	service    *Service
+	ctx        context.Context
+	recruiters *memory.RecruiterRepository
+	candidates *memory.CandidateRepository
+	interviews *memory.InterviewRepository
+	busy       *memory.BusyRepository
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+
+	f := fixture{
+		ctx:        organization.ContextWithTenant(context.Background(), tenantID),
+		recruiters: memory.NewRecruiterRepository(),
+		candidates: memory.NewCandidateRepository(),
+		interviews: memory.NewInterviewRepository(),
+		busy:       memory.NewBusyRepository(),
+	}
+
+	var err error
+	f.service, err = New(
+		WithRecruiterRepository(f.recruiters),
+		WithCandidateRepository(f.candidates),
+		WithInterviewRepository(f.interviews),
+		WithBusyRepository(f.busy),
+		WithUnitOfWork(memory.NewUnitOfWork()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func (f fixture) recruiter(t *testing.T, data recruiter.Entity) string {
+	t.Helper()
+
+	id, err := f.recruiters.Add(f.ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// interview stores a scheduled interview of the recruiter
+func (f fixture) interview(t *testing.T, recruiterID string, startsAt, endsAt time.Time) string {
+	t.Helper()
+
+	candidateID, status := "candidate", interview.StatusScheduled
+	id, err := f.interviews.Add(f.ctx, interview.Entity{
+		CandidateID:  &candidateID,
+		RecruiterID:  &recruiterID,
+		StartsAt:     &startsAt,
+		EndsAt:       &endsAt,
+		Status:       &status,
+		Participants: interview.NewParticipants(candidateID, []string{recruiterID}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// monday returns Monday 4 January 2027 at the hour and minute in UTC
+func monday(hour, minute int) time.Time {
+	return time.Date(2027, 1, 4, hour, minute, 0, 0, time.UTC)
+}
+
+func windows(res []availability.Response) (dest []availability.Window) {
+	for _, item := range res {
+		dest = append(dest, availability.Window{StartsAt: item.StartsAt, EndsAt: item.EndsAt})
+	}
+	return
+}
+
+func equalWindows(t *testing.T, got, want []availability.Window) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].StartsAt.Equal(want[i].StartsAt) || !got[i].EndsAt.Equal(want[i].EndsAt) {
+			t.Errorf("window %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFindAvailability(t *testing.T) {
+	f := newFixture(t)
+
+	// Rita works the default hours and has an interview from 10:00 to 11:00
+	rita := f.recruiter(t, recruiter.Entity{})
+	f.interview(t, rita, monday(10, 0), monday(11, 0))
+
+	// Ralf works from 12:00 to 18:00 and is busy in their calendar from 14:00 to 15:00
+	ralf := f.recruiter(t, recruiter.Entity{WorkingHours: &recruiter.Schedule{Days: []string{"MO", "TU"}, Start: "12:00", End: "18:00"}})
+	if err := f.busy.Replace(f.ctx, ralf, busy.SourceUpload, []busy.Block{{StartsAt: monday(14, 0), EndsAt: monday(15, 0)}}); err != nil {
+		t.Fatal(err)
+	}
+
+	window := func(startHour, startMinute, endHour, endMinute int) availability.Window {
+		return availability.Window{StartsAt: monday(startHour, startMinute), EndsAt: monday(endHour, endMinute)}
+	}
+
+	tests := []struct {
+		name     string
+		req      availability.Request
+		want     []availability.Window
+		wantKind apperror.Kind
+	}{
+		{
+			name: "one recruiter",
+			req:  availability.Request{RecruiterIDs: []string{rita}, From: monday(0, 0), To: monday(24, 0), Duration: 30 * time.Minute},
+			want: []availability.Window{window(9, 0, 10, 0), window(11, 0, 17, 0)},
+		},
+		{
+			name: "common free time of both",
+			req:  availability.Request{RecruiterIDs: []string{rita, ralf}, From: monday(0, 0), To: monday(24, 0), Duration: 30 * time.Minute},
+			want: []availability.Window{window(12, 0, 14, 0), window(15, 0, 17, 0)},
+		},
+		{
+			name: "duplicates are ignored",
+			req:  availability.Request{RecruiterIDs: []string{ralf, rita, ralf}, From: monday(0, 0), To: monday(24, 0), Duration: 30 * time.Minute},
+			want: []availability.Window{window(12, 0, 14, 0), window(15, 0, 17, 0)},
+		},
+		{
+			name: "clipped to the range",
+			req:  availability.Request{RecruiterIDs: []string{rita, ralf}, From: monday(13, 30), To: monday(16, 0), Duration: 30 * time.Minute},
+			want: []availability.Window{window(13, 30, 14, 0), window(15, 0, 16, 0)},
+		},
+		{
+			name: "too short windows are left out",
+			req:  availability.Request{RecruiterIDs: []string{rita, ralf}, From: monday(13, 30), To: monday(16, 0), Duration: time.Hour},
+			want: []availability.Window{window(15, 0, 16, 0)},
+		},
+		{
+			name: "limit",
+			req:  availability.Request{RecruiterIDs: []string{rita, ralf}, From: monday(0, 0), To: monday(24, 0), Duration: 30 * time.Minute, Limit: 1},
+			want: []availability.Window{window(12, 0, 14, 0)},
+		},
+		{
+			name: "no common free time",
+			req:  availability.Request{RecruiterIDs: []string{rita, ralf}, From: monday(0, 0), To: monday(24, 0), Duration: 3 * time.Hour},
+			want: nil,
+		},
+		{
+			name:     "no recruiters",
+			req:      availability.Request{From: monday(0, 0), To: monday(24, 0), Duration: time.Hour},
+			wantKind: apperror.KindValidation,
+		},
+		{
+			name:     "range ends before it starts",
+			req:      availability.Request{RecruiterIDs: []string{rita}, From: monday(12, 0), To: monday(9, 0), Duration: time.Hour},
+			wantKind: apperror.KindValidation,
+		},
+		{
+			name:     "unknown recruiter",
+			req:      availability.Request{RecruiterIDs: []string{rita, "unknown"}, From: monday(0, 0), To: monday(24, 0), Duration: time.Hour},
+			wantKind: apperror.KindUnprocessable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := f.service.FindAvailability(f.ctx, tt.req)
+			if tt.wantKind != 0 {
+				if apperror.KindOf(err) != tt.wantKind {
+					t.Fatalf("FindAvailability() error = %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindAvailability() error = %v", err)
+			}
+			equalWindows(t, windows(res), tt.want)
+		})
+	}
+}
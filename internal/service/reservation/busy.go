@@ -0,0 +1,283 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/ical"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+const (
+	entityBusy = "busy"
+
+	defaultFeedTimeout = 30 * time.Second
+	defaultBusyHorizon = 180 * 24 * time.Hour
+
+	// MaxCalendarSize bounds the size of the imported calendars
+	MaxCalendarSize = 10 << 20
+)
+
+func (s *Service) ListBusy(ctx context.Context, recruiterID string, from, to time.Time) (res []busy.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListBusy").With(zap.String("recruiter_id", recruiterID))
+
+	data, err := s.busyRepository.List(ctx, busy.Filter{RecruiterIDs: []string{recruiterID}, From: from, To: to})
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = busy.ParseFromEntities(data)
+
+	return
+}
+
+// ImportBusy replaces the uploaded busy times of the recruiter with those of the calendar
+func (s *Service) ImportBusy(ctx context.Context, recruiterID string, src io.Reader) (res busy.ImportResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ImportBusy").With(zap.String("recruiter_id", recruiterID))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if _, err = s.recruiterRepository.Get(ctx, recruiterID); err != nil {
+			return repositoryError(err, entityRecruiter, recruiterID)
+		}
+
+		if res, err = s.importBusy(ctx, recruiterID, busy.SourceUpload, src); err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionUpdate, entityBusy, recruiterID, nil, res)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to import", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) GetBusyFeed(ctx context.Context, recruiterID string) (res busy.FeedResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetBusyFeed").With(zap.String("recruiter_id", recruiterID))
+
+	data, err := s.busyFeedRepository.Get(ctx, recruiterID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = apperror.Wrap(apperror.KindNotFound, err, "recruiter %s has no calendar feed", recruiterID)
+			return
+		}
+		logger.Error("failed to get by id", zap.Error(err))
+		return
+	}
+	res = busy.ParseFromFeed(data)
+
+	return
+}
+
+// SetBusyFeed subscribes to the external calendar of the recruiter, it is
+// imported right away and synchronized periodically from then on
+func (s *Service) SetBusyFeed(ctx context.Context, recruiterID string, req busy.FeedRequest) (res busy.FeedResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("SetBusyFeed").With(zap.String("recruiter_id", recruiterID))
+
+	if _, err = s.recruiterRepository.Get(ctx, recruiterID); err != nil {
+		err = repositoryError(err, entityRecruiter, recruiterID)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get recruiter", zap.Error(err))
+		}
+		return
+	}
+
+	body, err := s.fetchCalendar(ctx, req.URL)
+	if err != nil {
+		err = apperror.Wrap(apperror.KindUnprocessable, err, "failed to fetch calendar feed: %v", err)
+		return
+	}
+	defer body.Close()
+
+	now := time.Now().UTC()
+	data := busy.Feed{
+		RecruiterID: recruiterID,
+		URL:         req.URL,
+		SyncedAt:    &now,
+		CreatedAt:   now,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		imported, err := s.importBusy(ctx, recruiterID, busy.SourceFeed, body)
+		if err != nil {
+			return
+		}
+
+		if err = s.busyFeedRepository.Save(ctx, data); err != nil {
+			return
+		}
+		res = busy.ParseFromFeed(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityBusy, recruiterID, nil, imported)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to import", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// DeleteBusyFeed unsubscribes from the external calendar of the recruiter and
+// drops the busy times imported from it
+func (s *Service) DeleteBusyFeed(ctx context.Context, recruiterID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteBusyFeed").With(zap.String("recruiter_id", recruiterID))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.busyFeedRepository.Delete(ctx, recruiterID); err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				return apperror.Wrap(apperror.KindNotFound, err, "recruiter %s has no calendar feed", recruiterID)
+			}
+			return
+		}
+
+		if err = s.busyRepository.Replace(ctx, recruiterID, busy.SourceFeed, nil); err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityBusy, recruiterID, busy.ImportResponse{RecruiterID: recruiterID, Source: busy.SourceFeed}, nil)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// SyncBusyFeeds imports the external calendars of all recruiters again, a
+// feed that fails keeps its busy times and records the error
+func (s *Service) SyncBusyFeeds(ctx context.Context) {
+	logger := log.LoggerFromContext(ctx).Named("SyncBusyFeeds")
+
+	// the feeds of every organization are listed, each is synced in its own
+	ctx = organization.ContextWithSystem(ctx)
+
+	feeds, err := s.busyFeedRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select feeds", zap.Error(err))
+		return
+	}
+
+	for _, data := range feeds {
+		feedCtx := organization.ContextWithTenant(ctx, data.TenantID)
+		if err = s.syncBusyFeed(feedCtx, data); err != nil {
+			logger.Warn("failed to sync feed", zap.String("recruiter_id", data.RecruiterID), zap.Error(err))
+
+			data.LastError = err.Error()
+			if err = s.busyFeedRepository.Save(feedCtx, data); err != nil {
+				logger.Error("failed to save feed", zap.String("recruiter_id", data.RecruiterID), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Service) syncBusyFeed(ctx context.Context, data busy.Feed) (err error) {
+	body, err := s.fetchCalendar(ctx, data.URL)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if _, err = s.importBusy(ctx, data.RecruiterID, busy.SourceFeed, body); err != nil {
+			return
+		}
+
+		now := time.Now().UTC()
+		data.SyncedAt = &now
+		data.LastError = ""
+
+		return s.busyFeedRepository.Save(ctx, data)
+	})
+}
+
+// StartBusySync runs SyncBusyFeeds every interval until the context is done
+func (s *Service) StartBusySync(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.SyncBusyFeeds(ctx)
+			}
+		}
+	}()
+}
+
+// importBusy replaces the busy times of the recruiter from the source with
+// those of the calendar, expanded from a day ago up to the horizon
+func (s *Service) importBusy(ctx context.Context, recruiterID, source string, src io.Reader) (res busy.ImportResponse, err error) {
+	// read one byte past the limit to tell a larger calendar from one that fits
+	limited := &io.LimitedReader{R: src, N: MaxCalendarSize + 1}
+	cal, err := ical.Decode(limited)
+	if limited.N == 0 {
+		return res, apperror.Unprocessable("calendar: larger than %d bytes", MaxCalendarSize)
+	}
+	if err != nil {
+		return res, apperror.From(apperror.KindUnprocessable, err)
+	}
+
+	now := time.Now().UTC()
+	periods, err := cal.Busy(now.Add(-24*time.Hour), now.Add(s.busyHorizon))
+	if err != nil {
+		return res, apperror.From(apperror.KindUnprocessable, err)
+	}
+
+	blocks := make([]busy.Block, 0, len(periods))
+	for _, period := range periods {
+		blocks = append(blocks, busy.Block{StartsAt: period.Start, EndsAt: period.End})
+	}
+
+	if err = s.busyRepository.Replace(ctx, recruiterID, source, blocks); err != nil {
+		return
+	}
+	res = busy.ImportResponse{
+		RecruiterID: recruiterID,
+		Source:      source,
+		Count:       len(blocks),
+	}
+
+	return
+}
+
+func (s *Service) fetchCalendar(ctx context.Context, url string) (body io.ReadCloser, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		res.Body.Close()
+		return nil, fmt.Errorf("calendar feed responded with status %d", res.StatusCode)
+	}
+
+	return res.Body, nil
+}
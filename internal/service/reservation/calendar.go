@@ -0,0 +1,266 @@
+package reservation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/calendar"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/ical"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"strings"
+	"time"
+)
+
+const (
+	calendarProdID = "-//reservation-system//interviews//EN"
+	calendarDomain = "reservation-system"
+
+	// feedHistory is how long past interviews stay in the calendar feeds
+	feedHistory = 90 * 24 * time.Hour
+)
+
+// IssueFeedToken issues a new secret token for the calendar feed of the
+// candidate or recruiter, the previous token stops working
+func (s *Service) IssueFeedToken(ctx context.Context, ownerType, ownerID string) (res calendar.FeedTokenResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("IssueFeedToken").With(zap.String("owner_type", ownerType), zap.String("owner_id", ownerID))
+
+	if err = s.checkOwner(ctx, ownerType, ownerID); err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get owner", zap.Error(err))
+		}
+		return
+	}
+
+	src := make([]byte, 32)
+	if _, err = rand.Read(src); err != nil {
+		logger.Error("failed to generate token", zap.Error(err))
+		return
+	}
+	token := hex.EncodeToString(src)
+
+	data := calendar.FeedToken{
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		TokenHash: calendar.HashToken(token),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err = s.feedTokenRepository.Save(ctx, data); err != nil {
+		logger.Error("failed to save", zap.Error(err))
+		return
+	}
+
+	res = calendar.FeedTokenResponse{
+		Token: token,
+		URL:   fmt.Sprintf("/%ss/%s/calendar.ics?token=%s", ownerType, ownerID, token),
+	}
+
+	return
+}
+
+// CalendarFeed returns the interviews of the candidate or recruiter as a
+// calendar, cancelled interviews stay in it so that subscribed clients remove them
+func (s *Service) CalendarFeed(ctx context.Context, ownerType, ownerID, token string) (res ical.Calendar, err error) {
+	logger := log.LoggerFromContext(ctx).Named("CalendarFeed").With(zap.String("owner_type", ownerType), zap.String("owner_id", ownerID))
+
+	// the token is looked up before the organization of the feed is known
+	feedToken, err := s.feedTokenRepository.Get(organization.ContextWithSystem(ctx), ownerType, ownerID)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to get feed token", zap.Error(err))
+		return
+	}
+	if err != nil || !feedToken.Verify(token) {
+		err = apperror.Forbidden("invalid calendar feed token")
+		return
+	}
+	// the token alone authenticates the feed, which calendar clients fetch
+	// without credentials
+	ctx = organization.ContextWithTenant(ctx, feedToken.TenantID)
+
+	filter := interview.Filter{From: time.Now().Add(-feedHistory)}
+	switch ownerType {
+	case calendar.OwnerCandidate:
+		filter.CandidateID = ownerID
+	case calendar.OwnerRecruiter:
+		filter.RecruiterID = ownerID
+	}
+
+	data, err := s.interviewRepository.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+
+	res = ical.Calendar{
+		ProdID: calendarProdID,
+		Method: ical.MethodPublish,
+		Name:   "Interviews",
+	}
+	participants := make(map[string]ical.Attendee)
+	for _, entity := range data {
+		var event ical.Event
+		if event, err = s.calendarEvent(ctx, entity, participants); err != nil {
+			logger.Error("failed to get participants", zap.Error(err))
+			return
+		}
+		res.Events = append(res.Events, event)
+	}
+
+	return
+}
+
+// InterviewInvite returns the interview as an invitation to attach to
+// messages, or as its cancellation once the interview is cancelled
+func (s *Service) InterviewInvite(ctx context.Context, id string) (res ical.Calendar, err error) {
+	logger := log.LoggerFromContext(ctx).Named("InterviewInvite").With(zap.String("id", id))
+
+	data, err := s.interviewRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, entityInterview, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	event, err := s.calendarEvent(ctx, data, make(map[string]ical.Attendee))
+	if err != nil {
+		logger.Error("failed to get participants", zap.Error(err))
+		return
+	}
+
+	res = ical.Calendar{
+		ProdID: calendarProdID,
+		Method: ical.MethodRequest,
+		Events: []ical.Event{event},
+	}
+	if *data.Status == interview.StatusCancelled {
+		res.Method = ical.MethodCancel
+	}
+
+	return
+}
+
+// calendarEvent maps the interview to an event, the UID is derived from the
+// interview and the sequence from its version so that every change supersedes
+// the previous one. Participants are looked up once per call through the cache.
+func (s *Service) calendarEvent(ctx context.Context, data interview.Entity, participants map[string]ical.Attendee) (event ical.Event, err error) {
+	candidate, err := s.participant(ctx, calendar.OwnerCandidate, *data.CandidateID, participants)
+	if err != nil {
+		return
+	}
+	candidate.Status = partStat(data, interview.ParticipantCandidate, *data.CandidateID)
+
+	recruiterIDs := data.RecruiterIDs()
+	attendees := []ical.Attendee{candidate}
+	names := make([]string, 0, len(recruiterIDs))
+	for _, recruiterID := range recruiterIDs {
+		var recruiter ical.Attendee
+		if recruiter, err = s.participant(ctx, calendar.OwnerRecruiter, recruiterID, participants); err != nil {
+			return
+		}
+		recruiter.Status = partStat(data, interview.ParticipantRecruiter, recruiterID)
+		attendees = append(attendees, recruiter)
+		names = append(names, recruiter.Name)
+	}
+	// the lead recruiter organizes the interview
+	organizer := attendees[1]
+
+	event = ical.Event{
+		UID:         data.ID + "@" + calendarDomain,
+		Sequence:    data.Version - 1,
+		Stamp:       time.Now(),
+		Start:       *data.StartsAt,
+		End:         *data.EndsAt,
+		Summary:     *data.Title,
+		Location:    *data.Location,
+		Description: fmt.Sprintf("Interview of %s with %s", candidate.Name, strings.Join(names, ", ")),
+		Status:      ical.StatusConfirmed,
+		Organizer:   &organizer,
+		Attendees:   attendees,
+	}
+	if event.Sequence < 0 {
+		event.Sequence = 0
+	}
+	if event.Summary == "" {
+		event.Summary = "Interview"
+	}
+	if data.MeetingURL != nil && *data.MeetingURL != "" {
+		event.URL = *data.MeetingURL
+		event.Description += "\nJoin the video meeting: " + *data.MeetingURL
+		if event.Location == "" {
+			event.Location = *data.MeetingURL
+		}
+	}
+	if *data.Status == interview.StatusCancelled {
+		event.Status = ical.StatusCancelled
+	}
+
+	return
+}
+
+// partStat maps the response of the participant to the participation status of the calendar
+func partStat(data interview.Entity, participantType, id string) string {
+	participant, _ := data.Participant(participantType, id)
+	switch participant.Status {
+	case interview.ResponseAccepted:
+		return ical.PartStatAccepted
+	case interview.ResponseTentative:
+		return ical.PartStatTentative
+	case interview.ResponseDeclined:
+		return ical.PartStatDeclined
+	}
+	return ical.PartStatNeedsAction
+}
+
+// participant returns the candidate or recruiter as a calendar user, a
+// deleted participant is returned without an address
+func (s *Service) participant(ctx context.Context, ownerType, id string, participants map[string]ical.Attendee) (dest ical.Attendee, err error) {
+	if dest, ok := participants[ownerType+"/"+id]; ok {
+		return dest, nil
+	}
+
+	var fullName, email *string
+	switch ownerType {
+	case calendar.OwnerCandidate:
+		data, err := s.candidateRepository.Get(ctx, id)
+		if err != nil && !errors.Is(err, store.ErrorNotFound) {
+			return dest, err
+		}
+		fullName, email = data.FullName, data.Email
+	case calendar.OwnerRecruiter:
+		data, err := s.recruiterRepository.Get(ctx, id)
+		if err != nil && !errors.Is(err, store.ErrorNotFound) {
+			return dest, err
+		}
+		fullName, email = data.FullName, data.Email
+	}
+
+	if fullName != nil {
+		dest.Name = *fullName
+	}
+	if email != nil {
+		dest.Email = *email
+	}
+	participants[ownerType+"/"+id] = dest
+
+	return
+}
+
+func (s *Service) checkOwner(ctx context.Context, ownerType, ownerID string) (err error) {
+	switch ownerType {
+	case calendar.OwnerCandidate:
+		_, err = s.candidateRepository.Get(ctx, ownerID)
+		return repositoryError(err, entityCandidate, ownerID)
+	case calendar.OwnerRecruiter:
+		_, err = s.recruiterRepository.Get(ctx, ownerID)
+		return repositoryError(err, entityRecruiter, ownerID)
+	}
+	return apperror.Validation("unknown calendar owner %s", ownerType)
+}
@@ -2,18 +2,22 @@ package reservation
 
 import (
 	"context"
-	"errors"
 	"go.uber.org/zap"
-	"reservation-system/pkg/log"
-	"reservation-system/pkg/store"
-
+	"reservation-system/internal/domain/audit"
 	"reservation-system/internal/domain/candidate"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
 )
 
-func (s *Service) ListCandidates(ctx context.Context) (res []candidate.Response, err error) {
+func (s *Service) ListCandidates(ctx context.Context, filter candidate.Filter) (res []candidate.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("ListCandidates")
 
-	data, err := s.candidateRepository.List(ctx)
+	// the deleted candidates are the admin view of the organization
+	if filter.IncludeDeleted && !audit.IsAdmin(ctx) {
+		return nil, apperror.Forbidden("include_deleted: only the admin key lists deleted candidates")
+	}
+
+	data, err := s.candidateRepository.List(ctx, filter)
 	if err != nil {
 		logger.Error("failed to select", zap.Error(err))
 		return
@@ -27,17 +31,29 @@ func (s *Service) AddCandidate(ctx context.Context, req candidate.Request) (res
 	logger := log.LoggerFromContext(ctx).Named("AddCandidate")
 
 	data := candidate.Entity{
-		FullName: &req.FullName,
-		Email:    &req.Email,
-		Phone:    &req.Phone,
+		FullName:        &req.FullName,
+		Email:           &req.Email,
+		Phone:           &req.Phone,
+		Skills:          (*candidate.Strings)(&req.Skills),
+		ExperienceYears: &req.ExperienceYears,
+		Location:        &req.Location,
+		Links:           (*candidate.Strings)(&req.Links),
+		Version:         1,
 	}
 
-	data.ID, err = s.candidateRepository.Add(ctx, data)
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		data.ID, err = s.candidateRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = candidate.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionCreate, entityCandidate, data.ID, nil, res)
+	})
 	if err != nil {
 		logger.Error("failed to create", zap.Error(err))
 		return
 	}
-	res = candidate.ParseFromEntity(data)
 
 	return
 }
@@ -46,8 +62,11 @@ func (s *Service) GetCandidate(ctx context.Context, id string) (res candidate.Re
 	logger := log.LoggerFromContext(ctx).Named("GetCandidate").With(zap.String("id", id))
 
 	data, err := s.candidateRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to get by id", zap.Error(err))
+	if err != nil {
+		err = repositoryError(err, entityCandidate, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
 		return
 	}
 	res = candidate.ParseFromEntity(data)
@@ -55,30 +74,96 @@ func (s *Service) GetCandidate(ctx context.Context, id string) (res candidate.Re
 	return
 }
 
-func (s *Service) UpdateCandidate(ctx context.Context, id string, req candidate.Request) (err error) {
+func (s *Service) UpdateCandidate(ctx context.Context, id string, version int, req candidate.Request) (res candidate.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("UpdateCandidate").With(zap.String("id", id))
 
 	data := candidate.Entity{
-		FullName: &req.FullName,
-		Email:    &req.Email,
-		Phone:    &req.Phone,
+		FullName:        &req.FullName,
+		Email:           &req.Email,
+		Phone:           &req.Phone,
+		Skills:          (*candidate.Strings)(&req.Skills),
+		ExperienceYears: &req.ExperienceYears,
+		Location:        &req.Location,
+		Links:           (*candidate.Strings)(&req.Links),
+		Version:         version,
 	}
 
-	err = s.candidateRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to update by id", zap.Error(err))
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.candidateRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = s.candidateRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.ID = id
+		data.Version = current.Version + 1
+		res = candidate.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityCandidate, id, candidate.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityCandidate, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
 		return
 	}
 
 	return
 }
 
-func (s *Service) DeleteCandidate(ctx context.Context, id string) (err error) {
+func (s *Service) DeleteCandidate(ctx context.Context, id string, version int) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("DeleteCandidate").With(zap.String("id", id))
 
-	err = s.candidateRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to delete by id", zap.Error(err))
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.candidateRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = s.candidateRepository.Delete(ctx, id, version)
+		if err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityCandidate, id, candidate.ParseFromEntity(current), nil)
+	})
+	if err != nil {
+		err = repositoryError(err, entityCandidate, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) RestoreCandidate(ctx context.Context, id string) (res candidate.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RestoreCandidate").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		err = s.candidateRepository.Restore(ctx, id)
+		if err != nil {
+			return
+		}
+
+		current, err := s.candidateRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		res = candidate.ParseFromEntity(current)
+
+		return s.record(ctx, audit.ActionRestore, entityCandidate, id, nil, res)
+	})
+	if err != nil {
+		err = repositoryError(err, "deleted "+entityCandidate, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to restore by id", zap.Error(err))
+		}
 		return
 	}
 
@@ -0,0 +1,30 @@
+package reservation
+
+import (
+	"errors"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/store"
+)
+
+// repositoryError maps the errors of the repositories to the typed errors
+// of the service, errors it does not know about stay internal
+func repositoryError(err error, entity, id string) error {
+	switch {
+	case errors.Is(err, store.ErrorNotFound):
+		return apperror.Wrap(apperror.KindNotFound, err, "%s %s not found", entity, id)
+	case errors.Is(err, store.ErrorConflict):
+		return apperror.Wrap(apperror.KindPreconditionFailed, err, "%s %s was modified by another request", entity, id)
+	}
+
+	return err
+}
+
+// participantError maps the errors of looking up an entity referenced by a
+// request, a missing one makes the request unprocessable
+func participantError(err error, entity, id string) error {
+	if errors.Is(err, store.ErrorNotFound) {
+		return apperror.Unprocessable("%s %s not found", entity, id)
+	}
+
+	return err
+}
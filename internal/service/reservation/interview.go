@@ -0,0 +1,430 @@
+package reservation
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+func (s *Service) ListInterviews(ctx context.Context, filter interview.Filter) (res []interview.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListInterviews")
+
+	data, err := s.interviewRepository.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = interview.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) ScheduleInterview(ctx context.Context, req interview.Request) (res interview.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ScheduleInterview")
+
+	status := interview.StatusScheduled
+	data := interview.Entity{
+		CandidateID:  &req.CandidateID,
+		RecruiterID:  &req.RecruiterID,
+		Title:        &req.Title,
+		Location:     &req.Location,
+		StartsAt:     &req.StartsAt,
+		EndsAt:       &req.EndsAt,
+		Status:       &status,
+		Version:      1,
+		Participants: interview.NewParticipants(req.CandidateID, req.RecruiterIDs),
+		ResourceIDs:  req.ResourceIDs,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.checkParticipants(ctx, req.CandidateID, req.RecruiterIDs); err != nil {
+			return
+		}
+		if err = s.checkResources(ctx, req.ResourceIDs, len(req.RecruiterIDs)+1); err != nil {
+			return
+		}
+		if err = s.checkSchedules(ctx, "", req.CandidateID, req.RecruiterIDs, req.ResourceIDs, req.InterviewType, req.StartsAt, req.EndsAt); err != nil {
+			return
+		}
+
+		// the meeting is provisioned for the id the interview is stored under
+		if req.Remote {
+			data.ID = uuid.New().String()
+		}
+		meetingURL, err := s.meeting(ctx, data, req.Remote)
+		if err != nil {
+			return
+		}
+		data.MeetingURL = &meetingURL
+
+		data.ID, err = s.interviewRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = interview.ParseFromEntity(data)
+
+		if err = s.record(ctx, audit.ActionCreate, entityInterview, data.ID, nil, res); err != nil {
+			return
+		}
+
+		return s.notifyInterview(ctx, eventInterviewScheduled, data)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) GetInterview(ctx context.Context, id string) (res interview.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetInterview").With(zap.String("id", id))
+
+	data, err := s.interviewRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, entityInterview, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = interview.ParseFromEntity(data)
+
+	return
+}
+
+// RescheduleInterview moves the interview to another time or panel, the
+// candidate of an interview cannot be changed. The responses are kept unless
+// the interview moves to another time.
+func (s *Service) RescheduleInterview(ctx context.Context, id string, version int, req interview.Request) (res interview.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RescheduleInterview").With(zap.String("id", id))
+
+	data := interview.Entity{
+		RecruiterID: &req.RecruiterID,
+		Title:       &req.Title,
+		Location:    &req.Location,
+		StartsAt:    &req.StartsAt,
+		EndsAt:      &req.EndsAt,
+		Version:     version,
+		ResourceIDs: req.ResourceIDs,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.interviewRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		if *current.CandidateID != req.CandidateID {
+			return apperror.Validation("candidateId: cannot be changed")
+		}
+		if *current.Status != interview.StatusScheduled {
+			return apperror.Conflict("interview %s is %s", id, *current.Status)
+		}
+
+		if err = s.checkParticipants(ctx, req.CandidateID, req.RecruiterIDs); err != nil {
+			return
+		}
+		if err = s.checkResources(ctx, req.ResourceIDs, len(req.RecruiterIDs)+1); err != nil {
+			return
+		}
+		if err = s.checkSchedules(ctx, id, req.CandidateID, req.RecruiterIDs, req.ResourceIDs, req.InterviewType, req.StartsAt, req.EndsAt); err != nil {
+			return
+		}
+
+		data.ID = id
+		data.CandidateID = current.CandidateID
+		meetingURL, err := s.meeting(ctx, data, req.Remote)
+		if err != nil {
+			return
+		}
+		data.MeetingURL = &meetingURL
+
+		data.Participants = interview.NewParticipants(req.CandidateID, req.RecruiterIDs)
+		if current.StartsAt.Equal(req.StartsAt) && current.EndsAt.Equal(req.EndsAt) {
+			for i, participant := range data.Participants {
+				if previous, ok := current.Participant(participant.Type, participant.ID); ok {
+					data.Participants[i].Status = previous.Status
+					data.Participants[i].RespondedAt = previous.RespondedAt
+				}
+			}
+		}
+
+		err = s.interviewRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.Status = current.Status
+		data.Version = current.Version + 1
+		res = interview.ParseFromEntity(data)
+
+		if err = s.record(ctx, audit.ActionUpdate, entityInterview, id, interview.ParseFromEntity(current), res); err != nil {
+			return
+		}
+		if err = s.notifyInterview(ctx, eventInterviewRescheduled, data); err != nil {
+			return
+		}
+
+		// the recruiters leaving the panel no longer have the interview
+		var removed []string
+		for _, recruiterID := range current.RecruiterIDs() {
+			if _, ok := data.Participant(interview.ParticipantRecruiter, recruiterID); !ok {
+				removed = append(removed, recruiterID)
+			}
+		}
+		if len(removed) == 0 {
+			return
+		}
+		return s.notify(ctx, eventInterviewCancelled, current, false, removed)
+	})
+	if err != nil {
+		err = repositoryError(err, entityInterview, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) CancelInterview(ctx context.Context, id string, version int) (res interview.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("CancelInterview").With(zap.String("id", id))
+
+	status := interview.StatusCancelled
+	data := interview.Entity{
+		Status:  &status,
+		Version: version,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.interviewRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		if *current.Status == interview.StatusCancelled {
+			return apperror.Conflict("interview %s is already cancelled", id)
+		}
+
+		err = s.interviewRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		cancelled := current
+		cancelled.Status = &status
+		cancelled.Version = current.Version + 1
+		res = interview.ParseFromEntity(cancelled)
+
+		if err = s.record(ctx, audit.ActionCancel, entityInterview, id, interview.ParseFromEntity(current), res); err != nil {
+			return
+		}
+
+		return s.notifyInterview(ctx, eventInterviewCancelled, cancelled)
+	})
+	if err != nil {
+		err = repositoryError(err, entityInterview, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to cancel by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// RespondToInterview records the response of the candidate or a recruiter
+// of the panel to the invitation
+func (s *Service) RespondToInterview(ctx context.Context, id string, version int, req interview.ResponseRequest) (res interview.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RespondToInterview").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.interviewRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		if version != 0 && current.Version != version {
+			return store.ErrorConflict
+		}
+		if *current.Status != interview.StatusScheduled {
+			return apperror.Conflict("interview %s is %s", id, *current.Status)
+		}
+
+		participant, ok := current.Participant(req.ParticipantType, req.ParticipantID)
+		if !ok {
+			return apperror.Unprocessable("%s %s does not take part in interview %s", req.ParticipantType, req.ParticipantID, id)
+		}
+		now := time.Now().UTC()
+		participant.Status = req.Status
+		participant.RespondedAt = &now
+
+		if err = s.interviewRepository.Respond(ctx, id, participant); err != nil {
+			return
+		}
+		responded := current
+		responded.Participants = append([]interview.Participant{}, current.Participants...)
+		for i := range responded.Participants {
+			if responded.Participants[i].Type == participant.Type && responded.Participants[i].ID == participant.ID {
+				responded.Participants[i] = participant
+			}
+		}
+		responded.Version = current.Version + 1
+		res = interview.ParseFromEntity(responded)
+
+		return s.record(ctx, audit.ActionRespond, entityInterview, id, interview.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityInterview, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to respond by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// checkParticipants makes sure the candidate and the recruiters of an interview exist
+func (s *Service) checkParticipants(ctx context.Context, candidateID string, recruiterIDs []string) (err error) {
+	if _, err = s.candidateRepository.Get(ctx, candidateID); err != nil {
+		return participantError(err, entityCandidate, candidateID)
+	}
+
+	for _, recruiterID := range recruiterIDs {
+		if _, err = s.recruiterRepository.Get(ctx, recruiterID); err != nil {
+			return participantError(err, entityRecruiter, recruiterID)
+		}
+	}
+
+	return
+}
+
+// checkSchedules locks the schedules of the candidate, the recruiters and the
+// resources for the rest of the unit of work and fails when any of them has
+// another scheduled interview overlapping [from, to), the interview id is
+// ignored, when a recruiter cannot take the interview or when a recruiter is
+// busy in their external calendar
+func (s *Service) checkSchedules(ctx context.Context, id, candidateID string, recruiterIDs, resourceIDs []string, interviewType string, from, to time.Time) (err error) {
+	ids := append(append([]string{candidateID}, recruiterIDs...), resourceIDs...)
+	if err = s.interviewRepository.LockSchedules(ctx, ids...); err != nil {
+		return
+	}
+
+	filters := []interview.Filter{{CandidateID: candidateID, From: from, To: to, Status: interview.StatusScheduled}}
+	for _, recruiterID := range recruiterIDs {
+		filters = append(filters, interview.Filter{RecruiterID: recruiterID, From: from, To: to, Status: interview.StatusScheduled})
+	}
+	for _, resourceID := range resourceIDs {
+		filters = append(filters, interview.Filter{ResourceID: resourceID, From: from, To: to, Status: interview.StatusScheduled})
+	}
+	for _, filter := range filters {
+		overlapping, err := s.interviewRepository.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+		for _, item := range overlapping {
+			if item.ID == id {
+				continue
+			}
+			switch {
+			case filter.CandidateID != "":
+				return apperror.Conflict("candidate %s has interview %s at that time", candidateID, item.ID)
+			case filter.ResourceID != "":
+				return apperror.Conflict("resource %s is reserved for interview %s at that time", filter.ResourceID, item.ID)
+			}
+			return apperror.Conflict("recruiter %s has interview %s at that time", filter.RecruiterID, item.ID)
+		}
+	}
+
+	for _, recruiterID := range recruiterIDs {
+		if err = s.checkRecruiterLimits(ctx, id, recruiterID, interviewType, from, to); err != nil {
+			return
+		}
+	}
+
+	if s.busyRepository == nil {
+		return
+	}
+	blocks, err := s.busyRepository.List(ctx, busy.Filter{RecruiterIDs: recruiterIDs, From: from, To: to})
+	if err != nil {
+		return
+	}
+	if len(blocks) > 0 {
+		return apperror.Conflict("recruiter %s is busy at that time", blocks[0].RecruiterID)
+	}
+
+	return
+}
+
+// checkRecruiterLimits fails when the recruiter does not conduct the
+// interview type, when the interview is not within one of their working
+// hours windows, when they are out of office during the interview or have
+// reached their caps on its day or week, the interview with the given id is
+// being rescheduled and does not count
+func (s *Service) checkRecruiterLimits(ctx context.Context, id, recruiterID, interviewType string, from, to time.Time) (err error) {
+	data, err := s.recruiterRepository.Get(ctx, recruiterID)
+	if err != nil {
+		return participantError(err, entityRecruiter, recruiterID)
+	}
+
+	if interviewType != "" && (data.InterviewTypes == nil || !data.InterviewTypes.Has(interviewType)) {
+		return apperror.Conflict("recruiter %s does not conduct %s interviews", recruiterID, interviewType)
+	}
+
+	hours := s.recruiterHours(data)
+	if !withinWindows(hours.Windows(from, to), from, to) {
+		return apperror.Conflict("recruiter %s is not working at that time", recruiterID)
+	}
+
+	for _, absence := range data.Absences() {
+		if absence.StartsAt.Before(to) && absence.EndsAt.After(from) {
+			return apperror.Conflict("recruiter %s is out of office at that time", recruiterID)
+		}
+	}
+
+	maxPerDay, maxPerWeek := 0, 0
+	if data.MaxPerDay != nil {
+		maxPerDay = *data.MaxPerDay
+	}
+	if data.MaxPerWeek != nil {
+		maxPerWeek = *data.MaxPerWeek
+	}
+	if maxPerDay == 0 && maxPerWeek == 0 {
+		return
+	}
+
+	loc := hours.Location
+	day, week := availability.Day(from, loc), availability.Week(from, loc)
+	interviews, err := s.interviewRepository.List(ctx, interview.Filter{RecruiterID: recruiterID, From: week.StartsAt, To: week.EndsAt, Status: interview.StatusScheduled})
+	if err != nil {
+		return
+	}
+	days, weeks := capacityUsage(loc, interviews, id)
+
+	if maxPerDay > 0 && days.of(day) >= maxPerDay {
+		return apperror.Conflict("recruiter %s has reached the limit of %d interviews per day", recruiterID, maxPerDay)
+	}
+	if maxPerWeek > 0 && weeks.of(week) >= maxPerWeek {
+		return apperror.Conflict("recruiter %s has reached the limit of %d interviews per week", recruiterID, maxPerWeek)
+	}
+
+	return
+}
+
+// withinWindows reports whether one of the windows covers [from, to)
+func withinWindows(windows []availability.Window, from, to time.Time) bool {
+	for _, window := range windows {
+		if !window.StartsAt.After(from) && !window.EndsAt.Before(to) {
+			return true
+		}
+	}
+	return false
+}
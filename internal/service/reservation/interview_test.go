@@ -0,0 +1,544 @@
+package reservation
+
+import (
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/pkg/apperror"
+	"testing"
+	"time"
+)
+
+func (f fixture) candidate(t *testing.T) string {
+	t.Helper()
+
+	id, err := f.candidates.Add(f.ctx, candidate.Entity{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func (f fixture) schedule(t *testing.T, recruiterID string, startsAt time.Time) (interview.Response, error) {
+	t.Helper()
+
+	return f.service.ScheduleInterview(f.ctx, interview.Request{
+		CandidateID:  f.candidate(t),
+		RecruiterID:  recruiterID,
+		RecruiterIDs: []string{recruiterID},
+		Title:        "Interview",
+		StartsAt:     startsAt,
+		EndsAt:       startsAt.Add(time.Hour),
+	})
+}
+
+func TestScheduleInterviewCapacity(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name         string
+		profile      recruiter.Entity
+		booked       []time.Time
+		startsAt     time.Time
+		wantConflict bool
+	}{
+		{
+			name:     "below the daily cap",
+			profile:  recruiter.Entity{MaxPerDay: limit(2)},
+			booked:   []time.Time{monday(9, 0)},
+			startsAt: monday(11, 0),
+		},
+		{
+			name:         "daily cap reached",
+			profile:      recruiter.Entity{MaxPerDay: limit(1)},
+			booked:       []time.Time{monday(9, 0)},
+			startsAt:     monday(11, 0),
+			wantConflict: true,
+		},
+		{
+			name:     "other days do not count towards the daily cap",
+			profile:  recruiter.Entity{MaxPerDay: limit(1)},
+			booked:   []time.Time{monday(24+9, 0)},
+			startsAt: monday(11, 0),
+		},
+		{
+			name:         "weekly cap reached",
+			profile:      recruiter.Entity{MaxPerWeek: limit(2)},
+			booked:       []time.Time{monday(9, 0), monday(2*24+9, 0)},
+			startsAt:     monday(4*24+9, 0),
+			wantConflict: true,
+		},
+		{
+			name:     "weeks start on Monday",
+			profile:  recruiter.Entity{MaxPerWeek: limit(1)},
+			booked:   []time.Time{monday(-24+9, 0)},
+			startsAt: monday(9, 0),
+		},
+		{
+			name: "days of the time zone of the recruiter",
+			profile: recruiter.Entity{
+				MaxPerDay:    limit(1),
+				WorkingHours: &recruiter.Schedule{Days: []string{"MO", "TU"}, Start: "09:00", End: "17:00", TimeZone: "Asia/Tokyo"},
+			},
+			// 01:00 on Tuesday in Tokyo, the interview is at 10:00 on Monday
+			booked:   []time.Time{monday(16, 0)},
+			startsAt: monday(1, 0),
+		},
+		{
+			name: "out of office",
+			profile: recruiter.Entity{OutOfOffice: &recruiter.Absences{
+				{StartsAt: monday(0, 0), EndsAt: monday(12, 0), Reason: "vacation"},
+			}},
+			startsAt:     monday(11, 0),
+			wantConflict: true,
+		},
+		{
+			name: "back in the office",
+			profile: recruiter.Entity{OutOfOffice: &recruiter.Absences{
+				{StartsAt: monday(0, 0), EndsAt: monday(12, 0), Reason: "vacation"},
+			}},
+			startsAt: monday(12, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			recruiterID := f.recruiter(t, tt.profile)
+			for _, startsAt := range tt.booked {
+				f.interview(t, recruiterID, startsAt, startsAt.Add(time.Hour))
+			}
+
+			_, err := f.schedule(t, recruiterID, tt.startsAt)
+			switch {
+			case tt.wantConflict && apperror.KindOf(err) != apperror.KindConflict:
+				t.Errorf("ScheduleInterview() error = %v, want a conflict", err)
+			case !tt.wantConflict && err != nil:
+				t.Errorf("ScheduleInterview() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRescheduleInterviewCapacity(t *testing.T) {
+	f := newFixture(t)
+	maxPerDay := 1
+	recruiterID := f.recruiter(t, recruiter.Entity{MaxPerDay: &maxPerDay})
+
+	res, err := f.schedule(t, recruiterID, monday(9, 0))
+	if err != nil {
+		t.Fatalf("ScheduleInterview() error = %v", err)
+	}
+
+	// the interview being moved does not count towards the cap of its day
+	_, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, interview.Request{
+		CandidateID:  res.CandidateID,
+		RecruiterID:  recruiterID,
+		RecruiterIDs: []string{recruiterID},
+		Title:        "Interview",
+		StartsAt:     monday(14, 0),
+		EndsAt:       monday(15, 0),
+	})
+	if err != nil {
+		t.Fatalf("RescheduleInterview() error = %v", err)
+	}
+
+	if _, err = f.schedule(t, recruiterID, monday(11, 0)); apperror.KindOf(err) != apperror.KindConflict {
+		t.Errorf("ScheduleInterview() error = %v, want a conflict", err)
+	}
+}
+
+func TestScheduleInterviewRecruiterFit(t *testing.T) {
+	types := func(values ...string) *recruiter.Strings { return (*recruiter.Strings)(&values) }
+	tokyo := &recruiter.Schedule{Days: []string{"MO"}, Start: "09:00", End: "17:00", TimeZone: "Asia/Tokyo"}
+
+	tests := []struct {
+		name          string
+		profiles      []recruiter.Entity
+		interviewType string
+		startsAt      time.Time
+		wantConflict  bool
+	}{
+		{
+			name:     "within the working hours",
+			profiles: []recruiter.Entity{{}},
+			startsAt: monday(9, 0),
+		},
+		{
+			name:         "before the working hours",
+			profiles:     []recruiter.Entity{{}},
+			startsAt:     monday(8, 0),
+			wantConflict: true,
+		},
+		{
+			name:         "past the end of the working hours",
+			profiles:     []recruiter.Entity{{}},
+			startsAt:     monday(16, 30),
+			wantConflict: true,
+		},
+		{
+			name:         "on a day off",
+			profiles:     []recruiter.Entity{{}},
+			startsAt:     monday(5*24+10, 0),
+			wantConflict: true,
+		},
+		{
+			name:     "working hours of the recruiter",
+			profiles: []recruiter.Entity{{WorkingHours: tokyo}},
+			// 10:00 on Monday in Tokyo
+			startsAt: monday(1, 0),
+		},
+		{
+			name:         "outside the working hours of the recruiter",
+			profiles:     []recruiter.Entity{{WorkingHours: tokyo}},
+			startsAt:     monday(10, 0),
+			wantConflict: true,
+		},
+		{
+			name:         "outside the working hours of one of the panel",
+			profiles:     []recruiter.Entity{{}, {WorkingHours: tokyo}},
+			startsAt:     monday(10, 0),
+			wantConflict: true,
+		},
+		{
+			name:          "conducted interview type",
+			profiles:      []recruiter.Entity{{InterviewTypes: types("technical", "culture")}},
+			interviewType: "Technical",
+			startsAt:      monday(9, 0),
+		},
+		{
+			name:          "interview type not conducted",
+			profiles:      []recruiter.Entity{{InterviewTypes: types("culture")}},
+			interviewType: "technical",
+			startsAt:      monday(9, 0),
+			wantConflict:  true,
+		},
+		{
+			name:          "recruiter without interview types",
+			profiles:      []recruiter.Entity{{}},
+			interviewType: "technical",
+			startsAt:      monday(9, 0),
+			wantConflict:  true,
+		},
+		{
+			name:          "interview type not conducted by one of the panel",
+			profiles:      []recruiter.Entity{{InterviewTypes: types("technical")}, {InterviewTypes: types("culture")}},
+			interviewType: "technical",
+			startsAt:      monday(9, 0),
+			wantConflict:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			var recruiterIDs []string
+			for _, profile := range tt.profiles {
+				recruiterIDs = append(recruiterIDs, f.recruiter(t, profile))
+			}
+
+			_, err := f.service.ScheduleInterview(f.ctx, interview.Request{
+				CandidateID:   f.candidate(t),
+				RecruiterID:   recruiterIDs[0],
+				RecruiterIDs:  recruiterIDs,
+				InterviewType: tt.interviewType,
+				Title:         "Interview",
+				StartsAt:      tt.startsAt,
+				EndsAt:        tt.startsAt.Add(time.Hour),
+			})
+			switch {
+			case tt.wantConflict && apperror.KindOf(err) != apperror.KindConflict:
+				t.Errorf("ScheduleInterview() error = %v, want a conflict", err)
+			case !tt.wantConflict && err != nil:
+				t.Errorf("ScheduleInterview() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRescheduleInterviewRecruiterFit(t *testing.T) {
+	interviewTypes := recruiter.Strings{"technical"}
+
+	tests := []struct {
+		name          string
+		interviewType string
+		startsAt      time.Time
+		wantConflict  bool
+	}{
+		{
+			name:     "within the working hours",
+			startsAt: monday(14, 0),
+		},
+		{
+			name:         "outside the working hours",
+			startsAt:     monday(18, 0),
+			wantConflict: true,
+		},
+		{
+			name:          "interview type not conducted",
+			interviewType: "culture",
+			startsAt:      monday(14, 0),
+			wantConflict:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			recruiterID := f.recruiter(t, recruiter.Entity{InterviewTypes: &interviewTypes})
+			res, err := f.schedule(t, recruiterID, monday(9, 0))
+			if err != nil {
+				t.Fatalf("ScheduleInterview() error = %v", err)
+			}
+
+			_, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, interview.Request{
+				CandidateID:   res.CandidateID,
+				RecruiterID:   recruiterID,
+				RecruiterIDs:  []string{recruiterID},
+				InterviewType: tt.interviewType,
+				Title:         "Interview",
+				StartsAt:      tt.startsAt,
+				EndsAt:        tt.startsAt.Add(time.Hour),
+			})
+			switch {
+			case tt.wantConflict && apperror.KindOf(err) != apperror.KindConflict:
+				t.Errorf("RescheduleInterview() error = %v, want a conflict", err)
+			case !tt.wantConflict && err != nil:
+				t.Errorf("RescheduleInterview() error = %v", err)
+			}
+		})
+	}
+}
+
+// panel stores an interview of the candidate with the panel
+func (f fixture) panel(t *testing.T, candidateID string, recruiterIDs []string, startsAt time.Time, status string) string {
+	t.Helper()
+
+	endsAt := startsAt.Add(time.Hour)
+	id, err := f.interviews.Add(f.ctx, interview.Entity{
+		CandidateID:  &candidateID,
+		RecruiterID:  &recruiterIDs[0],
+		StartsAt:     &startsAt,
+		EndsAt:       &endsAt,
+		Status:       &status,
+		Participants: interview.NewParticipants(candidateID, recruiterIDs),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestScheduleInterviewPanelConflicts(t *testing.T) {
+	tests := []struct {
+		name         string
+		booked       func(f fixture, jane, rita, ralf string)
+		wantConflict bool
+	}{
+		{
+			name:   "free panel",
+			booked: func(f fixture, jane, rita, ralf string) {},
+		},
+		{
+			name: "candidate has another interview",
+			booked: func(f fixture, jane, rita, ralf string) {
+				f.panel(t, jane, []string{f.recruiter(t, recruiter.Entity{})}, monday(9, 30), interview.StatusScheduled)
+			},
+			wantConflict: true,
+		},
+		{
+			name: "lead has another interview",
+			booked: func(f fixture, jane, rita, ralf string) {
+				f.panel(t, f.candidate(t), []string{rita}, monday(9, 30), interview.StatusScheduled)
+			},
+			wantConflict: true,
+		},
+		{
+			name: "member of the panel has another interview",
+			booked: func(f fixture, jane, rita, ralf string) {
+				f.panel(t, f.candidate(t), []string{rita}, monday(11, 0), interview.StatusScheduled)
+				f.panel(t, f.candidate(t), []string{f.recruiter(t, recruiter.Entity{}), ralf}, monday(9, 30), interview.StatusScheduled)
+			},
+			wantConflict: true,
+		},
+		{
+			name: "member of the panel is busy in their calendar",
+			booked: func(f fixture, jane, rita, ralf string) {
+				if err := f.busy.Replace(f.ctx, ralf, busy.SourceUpload, []busy.Block{{StartsAt: monday(9, 45), EndsAt: monday(11, 0)}}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantConflict: true,
+		},
+		{
+			name: "back to back interviews",
+			booked: func(f fixture, jane, rita, ralf string) {
+				f.panel(t, jane, []string{rita, ralf}, monday(10, 0), interview.StatusScheduled)
+				f.panel(t, jane, []string{rita, ralf}, monday(8, 0), interview.StatusScheduled)
+			},
+		},
+		{
+			name: "cancelled interview",
+			booked: func(f fixture, jane, rita, ralf string) {
+				f.panel(t, jane, []string{rita, ralf}, monday(9, 0), interview.StatusCancelled)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			jane := f.candidate(t)
+			rita, ralf := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+			tt.booked(f, jane, rita, ralf)
+
+			_, err := f.service.ScheduleInterview(f.ctx, interview.Request{
+				CandidateID:  jane,
+				RecruiterID:  rita,
+				RecruiterIDs: []string{rita, ralf},
+				Title:        "Interview",
+				StartsAt:     monday(9, 0),
+				EndsAt:       monday(10, 0),
+			})
+			switch {
+			case tt.wantConflict && apperror.KindOf(err) != apperror.KindConflict:
+				t.Errorf("ScheduleInterview() error = %v, want a conflict", err)
+			case !tt.wantConflict && err != nil:
+				t.Errorf("ScheduleInterview() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRescheduleInterviewPanel(t *testing.T) {
+	f := newFixture(t)
+	jane := f.candidate(t)
+	rita, ralf, rosa := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+	f.panel(t, f.candidate(t), []string{rosa}, monday(13, 0), interview.StatusScheduled)
+
+	req := interview.Request{
+		CandidateID:  jane,
+		RecruiterID:  rita,
+		RecruiterIDs: []string{rita, ralf},
+		Title:        "Interview",
+		StartsAt:     monday(9, 0),
+		EndsAt:       monday(10, 0),
+	}
+	res, err := f.service.ScheduleInterview(f.ctx, req)
+	if err != nil {
+		t.Fatalf("ScheduleInterview() error = %v", err)
+	}
+	res, err = f.service.RespondToInterview(f.ctx, res.ID, res.Version, interview.ResponseRequest{ParticipantType: interview.ParticipantRecruiter, ParticipantID: ralf, Status: interview.ResponseAccepted})
+	if err != nil {
+		t.Fatalf("RespondToInterview() error = %v", err)
+	}
+
+	// the interview does not conflict with itself and keeps the responses at the same time
+	req.RecruiterIDs = []string{rita, ralf, rosa}
+	res, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req)
+	if err != nil {
+		t.Fatalf("RescheduleInterview() error = %v", err)
+	}
+	if participant := res.Participants[2]; participant.ID != ralf || participant.Status != interview.ResponseAccepted {
+		t.Errorf("RescheduleInterview() participant %+v, want %s accepted", participant, ralf)
+	}
+
+	// Rosa is not free in the afternoon
+	req.StartsAt, req.EndsAt = monday(13, 30), monday(14, 30)
+	if _, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req); apperror.KindOf(err) != apperror.KindConflict {
+		t.Errorf("RescheduleInterview() error = %v, want a conflict", err)
+	}
+
+	// the responses are asked for again at another time
+	req.RecruiterIDs = []string{rita, ralf}
+	res, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req)
+	if err != nil {
+		t.Fatalf("RescheduleInterview() error = %v", err)
+	}
+	for _, participant := range res.Participants {
+		if participant.Status != interview.ResponsePending {
+			t.Errorf("RescheduleInterview() participant %+v, want pending", participant)
+		}
+	}
+}
+
+func TestRespondToInterview(t *testing.T) {
+	f := newFixture(t)
+	jane := f.candidate(t)
+	rita, ralf := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+
+	res, err := f.service.ScheduleInterview(f.ctx, interview.Request{
+		CandidateID:  jane,
+		RecruiterID:  rita,
+		RecruiterIDs: []string{rita},
+		Title:        "Interview",
+		StartsAt:     monday(9, 0),
+		EndsAt:       monday(10, 0),
+	})
+	if err != nil {
+		t.Fatalf("ScheduleInterview() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		version  int
+		req      interview.ResponseRequest
+		wantKind apperror.Kind
+	}{
+		{name: "outdated version", version: res.Version + 1, req: interview.ResponseRequest{ParticipantType: interview.ParticipantCandidate, ParticipantID: jane, Status: interview.ResponseAccepted}, wantKind: apperror.KindPreconditionFailed},
+		{name: "recruiter not on the panel", req: interview.ResponseRequest{ParticipantType: interview.ParticipantRecruiter, ParticipantID: ralf, Status: interview.ResponseAccepted}, wantKind: apperror.KindUnprocessable},
+		{name: "candidate as a recruiter", req: interview.ResponseRequest{ParticipantType: interview.ParticipantRecruiter, ParticipantID: jane, Status: interview.ResponseAccepted}, wantKind: apperror.KindUnprocessable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.RespondToInterview(f.ctx, res.ID, tt.version, tt.req)
+			if apperror.KindOf(err) != tt.wantKind {
+				t.Errorf("RespondToInterview() error = %v, want kind %v", err, tt.wantKind)
+			}
+		})
+	}
+
+	responded, err := f.service.RespondToInterview(f.ctx, res.ID, res.Version, interview.ResponseRequest{ParticipantType: interview.ParticipantCandidate, ParticipantID: jane, Status: interview.ResponseTentative})
+	if err != nil {
+		t.Fatalf("RespondToInterview() error = %v", err)
+	}
+	if candidate := responded.Participants[0]; candidate.Status != interview.ResponseTentative || candidate.RespondedAt == nil || responded.Version != res.Version+1 {
+		t.Errorf("RespondToInterview() = %+v, want the candidate tentative at version %d", responded, res.Version+1)
+	}
+
+	if _, err = f.service.CancelInterview(f.ctx, res.ID, 0); err != nil {
+		t.Fatalf("CancelInterview() error = %v", err)
+	}
+	_, err = f.service.RespondToInterview(f.ctx, res.ID, 0, interview.ResponseRequest{ParticipantType: interview.ParticipantCandidate, ParticipantID: jane, Status: interview.ResponseAccepted})
+	if apperror.KindOf(err) != apperror.KindConflict {
+		t.Errorf("RespondToInterview() of a cancelled interview error = %v, want a conflict", err)
+	}
+}
+
+func TestFindAvailabilityCapacity(t *testing.T) {
+	f := newFixture(t)
+	maxPerDay, maxPerWeek := 1, 2
+	recruiterID := f.recruiter(t, recruiter.Entity{MaxPerDay: &maxPerDay, MaxPerWeek: &maxPerWeek})
+	f.interview(t, recruiterID, monday(9, 0), monday(10, 0))
+	f.interview(t, recruiterID, monday(2*24+9, 0), monday(2*24+10, 0))
+
+	res, err := f.service.FindAvailability(f.ctx, availability.Request{
+		RecruiterIDs: []string{recruiterID},
+		From:         monday(0, 0),
+		To:           monday(14*24, 0),
+		Duration:     time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("FindAvailability() error = %v", err)
+	}
+
+	// Monday and Wednesday are full, then the rest of the week is, the next week is free
+	var want []availability.Window
+	for day := 7; day < 12; day++ {
+		want = append(want, availability.Window{StartsAt: monday(day*24+9, 0), EndsAt: monday(day*24+17, 0)})
+	}
+	equalWindows(t, windows(res), want)
+}
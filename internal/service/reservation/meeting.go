@@ -0,0 +1,62 @@
+package reservation
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/pkg/apperror"
+	"strings"
+)
+
+// JitsiProvider is the built-in meeting provider, it derives the room of an
+// interview from its id so that no call to the conferencing service is needed
+// and a rescheduled interview keeps its link. The secret keeps the rooms
+// from being guessed from the interview ids.
+type JitsiProvider struct {
+	baseURL string
+	secret  []byte
+}
+
+// NewJitsiProvider returns a provider of rooms on the Jitsi server at baseURL, e.g. https://meet.jit.si,
+// the secret is required as without it anyone knowing an interview id could join its room
+func NewJitsiProvider(baseURL, secret string) (*JitsiProvider, error) {
+	if baseURL == "" {
+		return nil, errors.New("jitsi: base url cannot be blank")
+	}
+	if secret == "" {
+		return nil, errors.New("jitsi: secret cannot be blank")
+	}
+
+	return &JitsiProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+	}, nil
+}
+
+func (p *JitsiProvider) Provision(ctx context.Context, data interview.Entity) (url string, err error) {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(data.ID))
+
+	return p.baseURL + "/Interview-" + hex.EncodeToString(mac.Sum(nil)[:10]), nil
+}
+
+// meeting returns the link of the video meeting of a remote interview, an
+// interview on site has none
+func (s *Service) meeting(ctx context.Context, data interview.Entity, remote bool) (url string, err error) {
+	if !remote {
+		return
+	}
+	if s.meetingProvider == nil {
+		return "", apperror.Unprocessable("remote: video meetings are not available")
+	}
+
+	if url, err = s.meetingProvider.Provision(ctx, data); err != nil {
+		return "", fmt.Errorf("failed to provision meeting of interview %s: %w", data.ID, err)
+	}
+
+	return
+}
@@ -0,0 +1,188 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/pkg/apperror"
+	"strings"
+	"testing"
+	"time"
+)
+
+type failingProvider struct{}
+
+func (failingProvider) Provision(ctx context.Context, data interview.Entity) (string, error) {
+	return "", errors.New("conferencing service unavailable")
+}
+
+func TestNewJitsiProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		secret  string
+		wantErr bool
+	}{
+		{name: "valid", baseURL: "https://meet.jit.si", secret: "secret"},
+		{name: "no base url", secret: "secret", wantErr: true},
+		{name: "no secret", baseURL: "https://meet.jit.si", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewJitsiProvider(tt.baseURL, tt.secret); (err != nil) != tt.wantErr {
+				t.Errorf("NewJitsiProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJitsiProviderProvision(t *testing.T) {
+	provision := func(baseURL, secret, id string) string {
+		t.Helper()
+
+		provider, err := NewJitsiProvider(baseURL, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		url, err := provider.Provision(context.Background(), interview.Entity{ID: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return url
+	}
+
+	url := provision("https://meet.jit.si/", "secret", "interview-1")
+	if !strings.HasPrefix(url, "https://meet.jit.si/Interview-") {
+		t.Errorf("Provision() = %s, want a room on https://meet.jit.si", url)
+	}
+	if again := provision("https://meet.jit.si", "secret", "interview-1"); again != url {
+		t.Errorf("Provision() = %s, want the same room %s", again, url)
+	}
+	if other := provision("https://meet.jit.si", "secret", "interview-2"); other == url {
+		t.Errorf("Provision() of another interview = %s, want another room", other)
+	}
+	if other := provision("https://meet.jit.si", "other", "interview-1"); other == url {
+		t.Errorf("Provision() with another secret = %s, want another room", other)
+	}
+}
+
+func TestScheduleInterviewMeeting(t *testing.T) {
+	jitsi, err := NewJitsiProvider("https://meet.jit.si", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		provider interview.MeetingProvider
+		remote   bool
+		wantURL  bool
+		wantKind apperror.Kind
+		wantErr  bool
+	}{
+		{name: "on site", provider: jitsi},
+		{name: "remote", provider: jitsi, remote: true, wantURL: true},
+		{name: "remote without a provider", remote: true, wantKind: apperror.KindUnprocessable, wantErr: true},
+		{name: "provider failing", provider: failingProvider{}, remote: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if tt.provider != nil {
+				if err := WithMeetingProvider(tt.provider)(f.service); err != nil {
+					t.Fatal(err)
+				}
+			}
+			recruiterID := f.recruiter(t, recruiter.Entity{})
+
+			res, err := f.service.ScheduleInterview(f.ctx, interview.Request{
+				CandidateID:  f.candidate(t),
+				RecruiterID:  recruiterID,
+				RecruiterIDs: []string{recruiterID},
+				Title:        "Interview",
+				StartsAt:     monday(10, 0),
+				EndsAt:       monday(11, 0),
+				Remote:       tt.remote,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScheduleInterview() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantKind != 0 && apperror.KindOf(err) != tt.wantKind {
+				t.Errorf("ScheduleInterview() error = %v, want kind %v", err, tt.wantKind)
+			}
+
+			if tt.wantErr {
+				// no interview is left behind without its meeting
+				interviews, err := f.service.ListInterviews(f.ctx, interview.Filter{RecruiterID: recruiterID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(interviews) != 0 {
+					t.Errorf("ListInterviews() = %d interviews, want none", len(interviews))
+				}
+				return
+			}
+
+			if (res.MeetingURL != "") != tt.wantURL {
+				t.Errorf("ScheduleInterview() meeting url = %q, want one %v", res.MeetingURL, tt.wantURL)
+			}
+			stored, err := f.service.GetInterview(f.ctx, res.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.MeetingURL != res.MeetingURL {
+				t.Errorf("GetInterview() meeting url = %q, want %q", stored.MeetingURL, res.MeetingURL)
+			}
+		})
+	}
+}
+
+func TestRescheduleInterviewMeeting(t *testing.T) {
+	f := newFixture(t)
+	jitsi, err := NewJitsiProvider("https://meet.jit.si", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = WithMeetingProvider(jitsi)(f.service); err != nil {
+		t.Fatal(err)
+	}
+	recruiterID := f.recruiter(t, recruiter.Entity{})
+
+	req := interview.Request{
+		CandidateID:  f.candidate(t),
+		RecruiterID:  recruiterID,
+		RecruiterIDs: []string{recruiterID},
+		Title:        "Interview",
+		StartsAt:     monday(10, 0),
+		EndsAt:       monday(11, 0),
+		Remote:       true,
+	}
+	res, err := f.service.ScheduleInterview(f.ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := res.MeetingURL
+
+	// the rescheduled interview keeps its link
+	req.StartsAt, req.EndsAt = monday(13, 0), monday(14, 0)
+	if res, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req); err != nil {
+		t.Fatal(err)
+	}
+	if res.MeetingURL != url {
+		t.Errorf("RescheduleInterview() meeting url = %q, want %q", res.MeetingURL, url)
+	}
+
+	// and loses it when moved on site
+	req.Remote = false
+	req.StartsAt = req.StartsAt.Add(time.Hour)
+	req.EndsAt = req.EndsAt.Add(time.Hour)
+	if res, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req); err != nil {
+		t.Fatal(err)
+	}
+	if res.MeetingURL != "" {
+		t.Errorf("RescheduleInterview() meeting url = %q, want none", res.MeetingURL)
+	}
+}
@@ -0,0 +1,99 @@
+package reservation
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+func (s *Service) ListNotes(ctx context.Context, candidateID string) (res []candidate.NoteResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListNotes").With(zap.String("candidate_id", candidateID))
+
+	if _, err = s.candidateRepository.Get(ctx, candidateID); err != nil {
+		err = repositoryError(err, entityCandidate, candidateID)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get candidate", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.noteRepository.List(ctx, candidateID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = candidate.ParseFromNotes(data)
+
+	return
+}
+
+func (s *Service) AddNote(ctx context.Context, candidateID string, req candidate.NoteRequest) (res candidate.NoteResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddNote").With(zap.String("candidate_id", candidateID))
+
+	data := candidate.Note{
+		CandidateID: candidateID,
+		AuthorID:    req.AuthorID,
+		Text:        req.Text,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if _, err = s.candidateRepository.Get(ctx, candidateID); err != nil {
+			return repositoryError(err, entityCandidate, candidateID)
+		}
+		if _, err = s.recruiterRepository.Get(ctx, req.AuthorID); err != nil {
+			return participantError(err, entityRecruiter, req.AuthorID)
+		}
+
+		data.ID, err = s.noteRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = candidate.ParseFromNote(data)
+
+		return s.record(ctx, audit.ActionCreate, entityNote, data.ID, nil, res)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) DeleteNote(ctx context.Context, candidateID, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteNote").With(zap.String("candidate_id", candidateID), zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.noteRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		// a note is only reachable through its own candidate
+		if current.CandidateID != candidateID {
+			return store.ErrorNotFound
+		}
+
+		if err = s.noteRepository.Delete(ctx, id); err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityNote, id, candidate.ParseFromNote(current), nil)
+	})
+	if err != nil {
+		err = repositoryError(err, entityNote, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
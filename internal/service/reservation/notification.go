@@ -0,0 +1,105 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/pkg/store"
+	"strings"
+)
+
+const (
+	eventInterviewScheduled   = "interview.scheduled"
+	eventInterviewRescheduled = "interview.rescheduled"
+	eventInterviewCancelled   = "interview.cancelled"
+	eventRecruiterAssigned    = "recruiter.assigned"
+)
+
+// notifyInterview queues the notifications of the event type to the
+// candidate and the panel of the interview
+func (s *Service) notifyInterview(ctx context.Context, eventType string, data interview.Entity) (err error) {
+	return s.notify(ctx, eventType, data, true, data.RecruiterIDs())
+}
+
+// notify queues the notifications of the event type about the interview to
+// the recruiters and, when toCandidate is set, to the candidate, the
+// participants deleted since are left out
+func (s *Service) notify(ctx context.Context, eventType string, data interview.Entity, toCandidate bool, recruiterIDs []string) (err error) {
+	if s.notifier == nil {
+		return
+	}
+
+	var (
+		candidateName string
+		recipients    []notification.Recipient
+	)
+	if participant, err := s.candidateRepository.Get(ctx, *data.CandidateID); err == nil {
+		to := recipient(participant.FullName, participant.Email, participant.Phone)
+		candidateName = to.FullName
+		if toCandidate {
+			recipients = append(recipients, to)
+		}
+	} else if !errors.Is(err, store.ErrorNotFound) {
+		return err
+	}
+
+	// the panel is named in the notifications of every recipient
+	recruiterNames := make([]string, 0, len(data.Participants))
+	for _, recruiterID := range data.RecruiterIDs() {
+		participant, err := s.recruiterRepository.Get(ctx, recruiterID)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				continue
+			}
+			return err
+		}
+		recruiterNames = append(recruiterNames, recipient(participant.FullName, participant.Email, participant.Phone).FullName)
+	}
+	for _, recruiterID := range recruiterIDs {
+		participant, err := s.recruiterRepository.Get(ctx, recruiterID)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				continue
+			}
+			return err
+		}
+		recipients = append(recipients, recipient(participant.FullName, participant.Email, participant.Phone))
+	}
+
+	var meetingURL string
+	if data.MeetingURL != nil {
+		meetingURL = *data.MeetingURL
+	}
+
+	fields := map[string]any{
+		"interviewId": data.ID,
+		"title":       *data.Title,
+		"location":    *data.Location,
+		"meetingUrl":  meetingURL,
+		"startsAt":    *data.StartsAt,
+		"endsAt":      *data.EndsAt,
+		"candidate":   candidateName,
+		"recruiter":   strings.Join(recruiterNames, ", "),
+	}
+	for _, to := range recipients {
+		if err = s.notifier.Notify(ctx, eventType, to, fields); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func recipient(fullName, email *string, phone *int) (dest notification.Recipient) {
+	if fullName != nil {
+		dest.FullName = *fullName
+	}
+	if email != nil {
+		dest.Email = *email
+	}
+	if phone != nil {
+		dest.Phone = *phone
+	}
+	return
+}
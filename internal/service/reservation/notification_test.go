@@ -0,0 +1,164 @@
+package reservation
+
+import (
+	"context"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/scheduling"
+	"reservation-system/internal/domain/team"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// notifier records the notifications as "<event type> <recipient>"
+type notifier struct {
+	sent []string
+}
+
+func (n *notifier) Notify(ctx context.Context, eventType string, recipient notification.Recipient, data map[string]any) error {
+	n.sent = append(n.sent, eventType+" "+recipient.FullName)
+	return nil
+}
+
+// take returns the notifications sorted and forgets them
+func (n *notifier) take() string {
+	sent := n.sent
+	n.sent = nil
+	sort.Strings(sent)
+	return strings.Join(sent, ", ")
+}
+
+func newNotifyFixture(t *testing.T) (fixture, *notifier) {
+	t.Helper()
+
+	f := newTeamFixture(t)
+	n := &notifier{}
+	if err := WithNotifier(n)(f.service); err != nil {
+		t.Fatal(err)
+	}
+	return f, n
+}
+
+// namedCandidate adds a candidate with the name and an email address
+func (f fixture) namedCandidate(t *testing.T, name string) string {
+	t.Helper()
+
+	email := strings.ToLower(name) + "@example.com"
+	id, err := f.candidates.Add(f.ctx, candidate.Entity{FullName: &name, Email: &email})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// namedRecruiter adds a recruiter with the name and an email address
+func (f fixture) namedRecruiter(t *testing.T, name string) string {
+	t.Helper()
+
+	email := strings.ToLower(name) + "@example.com"
+	return f.recruiter(t, recruiter.Entity{FullName: &name, Email: &email})
+}
+
+func TestInterviewNotifications(t *testing.T) {
+	f, n := newNotifyFixture(t)
+	candidateID := f.namedCandidate(t, "Jane")
+	rita, ralf, rosa := f.namedRecruiter(t, "Rita"), f.namedRecruiter(t, "Ralf"), f.namedRecruiter(t, "Rosa")
+
+	req := interview.Request{
+		CandidateID:  candidateID,
+		RecruiterID:  rita,
+		RecruiterIDs: []string{rita, ralf},
+		Title:        "Interview",
+		StartsAt:     monday(9, 0),
+		EndsAt:       monday(10, 0),
+	}
+	res, err := f.service.ScheduleInterview(f.ctx, req)
+	if err != nil {
+		t.Fatalf("ScheduleInterview() error = %v", err)
+	}
+	if got, want := n.take(), "interview.scheduled Jane, interview.scheduled Ralf, interview.scheduled Rita"; got != want {
+		t.Errorf("ScheduleInterview() notified %q, want %q", got, want)
+	}
+
+	// Ralf leaves the panel and Rosa joins it
+	req.RecruiterIDs = []string{rita, rosa}
+	req.StartsAt, req.EndsAt = monday(11, 0), monday(12, 0)
+	res, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req)
+	if err != nil {
+		t.Fatalf("RescheduleInterview() error = %v", err)
+	}
+	if got, want := n.take(), "interview.cancelled Ralf, interview.rescheduled Jane, interview.rescheduled Rita, interview.rescheduled Rosa"; got != want {
+		t.Errorf("RescheduleInterview() notified %q, want %q", got, want)
+	}
+
+	if _, err = f.service.CancelInterview(f.ctx, res.ID, res.Version); err != nil {
+		t.Fatalf("CancelInterview() error = %v", err)
+	}
+	if got, want := n.take(), "interview.cancelled Jane, interview.cancelled Rita, interview.cancelled Rosa"; got != want {
+		t.Errorf("CancelInterview() notified %q, want %q", got, want)
+	}
+
+	// nothing is sent for a change that fails
+	if _, err = f.service.CancelInterview(f.ctx, res.ID, 0); err == nil {
+		t.Fatal("CancelInterview() of a cancelled interview succeeded")
+	}
+	if got := n.take(); got != "" {
+		t.Errorf("failed CancelInterview() notified %q", got)
+	}
+}
+
+func TestAssignmentNotifications(t *testing.T) {
+	t.Run("team booking", func(t *testing.T) {
+		f, n := newNotifyFixture(t)
+		teamID := f.team(t, team.StrategyRoundRobin, f.namedRecruiter(t, "Rita"))
+
+		_, err := f.service.BookTeamInterview(f.ctx, teamID, team.BookingRequest{
+			CandidateID: f.namedCandidate(t, "Jane"),
+			Title:       "Interview",
+			StartsAt:    monday(9, 0),
+			EndsAt:      monday(10, 0),
+		})
+		if err != nil {
+			t.Fatalf("BookTeamInterview() error = %v", err)
+		}
+		if got, want := n.take(), "interview.scheduled Jane, interview.scheduled Rita, recruiter.assigned Rita"; got != want {
+			t.Errorf("BookTeamInterview() notified %q, want %q", got, want)
+		}
+	})
+
+	t.Run("planning", func(t *testing.T) {
+		f, n := newNotifyFixture(t)
+
+		req := scheduling.Request{
+			Candidates:      []scheduling.CandidateRequest{{ID: f.namedCandidate(t, "Jane")}},
+			Recruiters:      []scheduling.RecruiterRequest{{ID: f.namedRecruiter(t, "Rita")}},
+			From:            monday(9, 0),
+			To:              monday(17, 0),
+			Title:           "Interview",
+			DurationMinutes: int(time.Hour / time.Minute),
+		}
+		if err := req.Bind(nil); err != nil {
+			t.Fatal(err)
+		}
+		res, err := f.service.PlanInterviews(f.ctx, req)
+		if err != nil || len(res.Interviews) != 1 {
+			t.Fatalf("PlanInterviews() = %+v, %v, want one interview", res, err)
+		}
+		if got, want := n.take(), "interview.scheduled Jane, interview.scheduled Rita, recruiter.assigned Rita"; got != want {
+			t.Errorf("PlanInterviews() notified %q, want %q", got, want)
+		}
+
+		// a dry run only previews the assignment
+		req.DryRun = true
+		if _, err = f.service.PlanInterviews(f.ctx, req); err != nil {
+			t.Fatalf("PlanInterviews() error = %v", err)
+		}
+		if got := n.take(); got != "" {
+			t.Errorf("dry run notified %q", got)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+// Purge permanently removes candidates and recruiters deleted more than
+// retention ago, across all organizations
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("Purge")
+
+	ctx = organization.ContextWithSystem(ctx)
+
+	before := time.Now().Add(-retention)
+
+	// recruiters go first as they reference candidates
+	var (
+		recruiters []recruiter.Entity
+		candidates []candidate.Entity
+	)
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		recruiters, err = s.recruiterRepository.Purge(ctx, before)
+		if err != nil {
+			return
+		}
+		for _, entity := range recruiters {
+			ctx := organization.ContextWithTenant(ctx, entity.TenantID)
+			if err = s.purgeRecruiter(ctx, entity.ID); err != nil {
+				return
+			}
+			if err = s.record(ctx, audit.ActionPurge, entityRecruiter, entity.ID, nil, nil); err != nil {
+				return
+			}
+		}
+
+		return
+	})
+	if err != nil {
+		logger.Error("failed to purge recruiters", zap.Error(err))
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		candidates, err = s.candidateRepository.Purge(ctx, before)
+		if err != nil {
+			return
+		}
+		for _, entity := range candidates {
+			ctx := organization.ContextWithTenant(ctx, entity.TenantID)
+			if err = s.purgeCandidate(ctx, entity.ID); err != nil {
+				return
+			}
+			if err = s.record(ctx, audit.ActionPurge, entityCandidate, entity.ID, nil, nil); err != nil {
+				return
+			}
+		}
+
+		return
+	})
+	if err != nil {
+		logger.Error("failed to purge candidates", zap.Error(err))
+		return
+	}
+
+	// the attachments of the purged candidates are gone with them, only their
+	// content is left in the blob store
+	if s.blobStore != nil {
+		for _, entity := range candidates {
+			if err = s.blobStore.DeleteAll(ctx, candidate.AttachmentPrefix(entity.ID)); err != nil {
+				logger.Warn("failed to delete attachments", zap.String("candidate_id", entity.ID), zap.Error(err))
+			}
+		}
+		err = nil
+	}
+
+	if len(recruiters) > 0 || len(candidates) > 0 {
+		logger.Info("purged deleted entities", zap.Int("recruiters", len(recruiters)), zap.Int("candidates", len(candidates)))
+	}
+
+	return
+}
+
+// purgeRecruiter removes what references the purged recruiter, the
+// recruiter only leaves the panels of their interviews
+func (s *Service) purgeRecruiter(ctx context.Context, id string) (err error) {
+	if err = s.interviewRepository.Purge(ctx, interview.ParticipantRecruiter, id); err != nil {
+		return
+	}
+
+	if s.busyRepository != nil {
+		for _, source := range []string{busy.SourceUpload, busy.SourceFeed} {
+			if err = s.busyRepository.Replace(ctx, id, source, nil); err != nil {
+				return
+			}
+		}
+	}
+	if s.busyFeedRepository != nil {
+		if err = s.busyFeedRepository.Delete(ctx, id); err != nil && !errors.Is(err, store.ErrorNotFound) {
+			return
+		}
+	}
+
+	if s.teamRepository != nil && s.teamMemberRepository != nil {
+		teams, err := s.teamRepository.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, data := range teams {
+			if err = s.teamMemberRepository.Delete(ctx, data.ID, id); err != nil && !errors.Is(err, store.ErrorNotFound) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// purgeCandidate removes the interviews, notes and attachments of the purged
+// candidate, the content of the attachments is deleted once the transaction
+// is committed
+func (s *Service) purgeCandidate(ctx context.Context, id string) (err error) {
+	if err = s.interviewRepository.Purge(ctx, interview.ParticipantCandidate, id); err != nil {
+		return
+	}
+
+	if s.noteRepository != nil {
+		notes, err := s.noteRepository.List(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, note := range notes {
+			if err = s.noteRepository.Delete(ctx, note.ID); err != nil && !errors.Is(err, store.ErrorNotFound) {
+				return err
+			}
+		}
+	}
+
+	if s.attachmentRepository != nil {
+		attachments, err := s.attachmentRepository.List(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			if err = s.attachmentRepository.Delete(ctx, attachment.ID); err != nil && !errors.Is(err, store.ErrorNotFound) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// StartPurge runs Purge every interval until the context is done, a
+// non-positive interval disables the purge
+func (s *Service) StartPurge(ctx context.Context, interval, retention time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ctx = audit.ContextWithActor(ctx, "purge")
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Purge(ctx, retention)
+			}
+		}
+	}()
+}
@@ -0,0 +1,160 @@
+package reservation
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/repository"
+	"testing"
+	"time"
+)
+
+// stores returns the repositories the purge runs on, the Postgres store is
+// used when POSTGRES_DSN names a migrated database
+func stores(t *testing.T) map[string]*repository.Repository {
+	t.Helper()
+
+	dest := make(map[string]*repository.Repository)
+
+	memory, err := repository.New(repository.WithMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dest["memory"] = memory
+
+	if dsn := os.Getenv("POSTGRES_DSN"); dsn != "" {
+		postgres, err := repository.New(repository.WithPostgresStore(dsn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(postgres.Close)
+		dest["postgres"] = postgres
+	}
+
+	return dest
+}
+
+func TestPurge(t *testing.T) {
+	for name, repositories := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(
+				WithRecruiterRepository(repositories.Recruiter),
+				WithCandidateRepository(repositories.Candidate),
+				WithInterviewRepository(repositories.Interview),
+				WithNoteRepository(repositories.CandidateNote),
+				WithAttachmentRepository(repositories.CandidateAttachment),
+				WithBusyRepository(repositories.Busy),
+				WithBusyFeedRepository(repositories.BusyFeed),
+				WithTeamRepository(repositories.Team),
+				WithTeamMemberRepository(repositories.TeamMember),
+				WithUnitOfWork(repositories.UnitOfWork),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := organization.ContextWithTenant(context.Background(), organization.DefaultID)
+
+			person := func(name string) (string, string, int) {
+				return name, fmt.Sprintf("%s.%s@example.com", name, uuid.NewString()), 4915112345678
+			}
+			addCandidate := func(name string) string {
+				fullName, email, phone := person(name)
+				id, err := repositories.Candidate.Add(ctx, candidate.Entity{FullName: &fullName, Email: &email, Phone: &phone})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return id
+			}
+			addRecruiter := func(name string) string {
+				fullName, email, phone := person(name)
+				id, err := repositories.Recruiter.Add(ctx, recruiter.Entity{FullName: &fullName, Email: &email, Phone: &phone})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return id
+			}
+			addInterview := func(candidateID string, recruiterIDs ...string) string {
+				title, location, status := "Interview", "", interview.StatusScheduled
+				startsAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+				endsAt := startsAt.Add(time.Hour)
+				id, err := repositories.Interview.Add(ctx, interview.Entity{
+					CandidateID:  &candidateID,
+					RecruiterID:  &recruiterIDs[0],
+					Title:        &title,
+					Location:     &location,
+					StartsAt:     &startsAt,
+					EndsAt:       &endsAt,
+					Status:       &status,
+					Participants: interview.NewParticipants(candidateID, recruiterIDs),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return id
+			}
+
+			carl, cora := addCandidate("Carl"), addCandidate("Cora")
+			rita, ralf := addRecruiter("Rita"), addRecruiter("Ralf")
+			panel, alone, other := addInterview(carl, rita, ralf), addInterview(carl, rita), addInterview(cora, ralf)
+
+			if _, err = repositories.CandidateNote.Add(ctx, candidate.Note{CandidateID: carl, AuthorID: ralf, Text: "Strong", CreatedAt: time.Now().UTC()}); err != nil {
+				t.Fatal(err)
+			}
+			if err = repositories.CandidateAttachment.Add(ctx, candidate.Attachment{ID: uuid.NewString(), CandidateID: carl, FileName: "cv.pdf", ContentType: "application/pdf", UploadedAt: time.Now().UTC()}); err != nil {
+				t.Fatal(err)
+			}
+
+			// the purged recruiter only leaves the panels
+			if err = repositories.Recruiter.Delete(ctx, rita, 0); err != nil {
+				t.Fatal(err)
+			}
+			if err = s.Purge(ctx, -time.Minute); err != nil {
+				t.Fatalf("Purge() error = %v", err)
+			}
+
+			for id, want := range map[string][]string{panel: {ralf}, alone: nil, other: {ralf}} {
+				data, err := repositories.Interview.Get(ctx, id)
+				if err != nil {
+					t.Fatalf("interview %s after purging the recruiter: %v", id, err)
+				}
+				lead := ""
+				if len(want) > 0 {
+					lead = want[0]
+				}
+				res := interview.ParseFromEntity(data)
+				if fmt.Sprint(res.RecruiterIDs) != fmt.Sprint(want) || res.RecruiterID != lead || res.CandidateID == "" {
+					t.Errorf("interview %s has the panel %v led by %q, want %v", id, res.RecruiterIDs, res.RecruiterID, want)
+				}
+			}
+
+			// the purged candidate takes their interviews, notes and attachments along
+			if err = repositories.Candidate.Delete(ctx, carl, 0); err != nil {
+				t.Fatal(err)
+			}
+			if err = s.Purge(ctx, -time.Minute); err != nil {
+				t.Fatalf("Purge() error = %v", err)
+			}
+
+			interviews, err := repositories.Interview.List(ctx, interview.Filter{CandidateID: carl})
+			if err != nil || len(interviews) != 0 {
+				t.Errorf("interviews of the purged candidate = %v, %v, want none", interviews, err)
+			}
+			if _, err = repositories.Interview.Get(ctx, other); err != nil {
+				t.Errorf("interview of another candidate: %v", err)
+			}
+			notes, err := repositories.CandidateNote.List(ctx, carl)
+			if err != nil || len(notes) != 0 {
+				t.Errorf("notes of the purged candidate = %v, %v, want none", notes, err)
+			}
+			attachments, err := repositories.CandidateAttachment.List(ctx, carl)
+			if err != nil || len(attachments) != 0 {
+				t.Errorf("attachments of the purged candidate = %v, %v, want none", attachments, err)
+			}
+		})
+	}
+}
@@ -2,17 +2,22 @@ package reservation
 
 import (
 	"context"
-	"errors"
 	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
 	"reservation-system/internal/domain/recruiter"
+	"reservation-system/pkg/apperror"
 	"reservation-system/pkg/log"
-	"reservation-system/pkg/store"
 )
 
-func (s *Service) ListRecruiters(ctx context.Context) (res []recruiter.Response, err error) {
+func (s *Service) ListRecruiters(ctx context.Context, filter recruiter.Filter) (res []recruiter.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("ListRecruiters")
 
-	data, err := s.recruiterRepository.List(ctx)
+	// the deleted recruiters are the admin view of the organization
+	if filter.IncludeDeleted && !audit.IsAdmin(ctx) {
+		return nil, apperror.Forbidden("include_deleted: only the admin key lists deleted recruiters")
+	}
+
+	data, err := s.recruiterRepository.List(ctx, filter)
 	if err != nil {
 		logger.Error("failed to select", zap.Error(err))
 		return
@@ -26,17 +31,35 @@ func (s *Service) AddRecruiter(ctx context.Context, req recruiter.Request) (res
 	logger := log.LoggerFromContext(ctx).Named("AddRecruiter")
 
 	data := recruiter.Entity{
-		FullName: &req.FullName,
-		Email:    &req.Email,
-		Phone:    &req.Phone,
+		FullName:       &req.FullName,
+		Email:          &req.Email,
+		Phone:          &req.Phone,
+		Title:          &req.Title,
+		Team:           &req.Team,
+		InterviewTypes: (*recruiter.Strings)(&req.InterviewTypes),
+		WorkingHours:   &recruiter.Schedule{},
+		MaxPerDay:      &req.MaxInterviewsPerDay,
+		MaxPerWeek:     &req.MaxInterviewsPerWeek,
+		OutOfOffice:    (*recruiter.Absences)(&req.OutOfOffice),
+		Version:        1,
+	}
+	if req.WorkingHours != nil {
+		data.WorkingHours = req.WorkingHours
 	}
 
-	data.ID, err = s.recruiterRepository.Add(ctx, data)
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		data.ID, err = s.recruiterRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = recruiter.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionCreate, entityRecruiter, data.ID, nil, res)
+	})
 	if err != nil {
 		logger.Error("failed to create", zap.Error(err))
 		return
 	}
-	res = recruiter.ParseFromEntity(data)
 
 	return
 }
@@ -45,8 +68,11 @@ func (s *Service) GetRecruiter(ctx context.Context, id string) (res recruiter.Re
 	logger := log.LoggerFromContext(ctx).Named("GetRecruiter").With(zap.String("id", id))
 
 	data, err := s.recruiterRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to get by id", zap.Error(err))
+	if err != nil {
+		err = repositoryError(err, entityRecruiter, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
 		return
 	}
 	res = recruiter.ParseFromEntity(data)
@@ -54,30 +80,102 @@ func (s *Service) GetRecruiter(ctx context.Context, id string) (res recruiter.Re
 	return
 }
 
-func (s *Service) UpdateRecruiter(ctx context.Context, id string, req recruiter.Request) (err error) {
+func (s *Service) UpdateRecruiter(ctx context.Context, id string, version int, req recruiter.Request) (res recruiter.Response, err error) {
 	logger := log.LoggerFromContext(ctx).Named("UpdateRecruiter").With(zap.String("id", id))
 
 	data := recruiter.Entity{
-		FullName: &req.FullName,
-		Email:    &req.Email,
-		Phone:    &req.Phone,
+		FullName:       &req.FullName,
+		Email:          &req.Email,
+		Phone:          &req.Phone,
+		Title:          &req.Title,
+		Team:           &req.Team,
+		InterviewTypes: (*recruiter.Strings)(&req.InterviewTypes),
+		WorkingHours:   &recruiter.Schedule{},
+		MaxPerDay:      &req.MaxInterviewsPerDay,
+		MaxPerWeek:     &req.MaxInterviewsPerWeek,
+		OutOfOffice:    (*recruiter.Absences)(&req.OutOfOffice),
+		Version:        version,
+	}
+	if req.WorkingHours != nil {
+		data.WorkingHours = req.WorkingHours
 	}
 
-	err = s.recruiterRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to update by id", zap.Error(err))
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.recruiterRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = s.recruiterRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.ID = id
+		data.Version = current.Version + 1
+		res = recruiter.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityRecruiter, id, recruiter.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityRecruiter, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
 		return
 	}
 
 	return
 }
 
-func (s *Service) DeleteRecruiter(ctx context.Context, id string) (err error) {
+func (s *Service) DeleteRecruiter(ctx context.Context, id string, version int) (err error) {
 	logger := log.LoggerFromContext(ctx).Named("DeleteRecruiter").With(zap.String("id", id))
 
-	err = s.recruiterRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		logger.Error("failed to delete by id", zap.Error(err))
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.recruiterRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = s.recruiterRepository.Delete(ctx, id, version)
+		if err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityRecruiter, id, recruiter.ParseFromEntity(current), nil)
+	})
+	if err != nil {
+		err = repositoryError(err, entityRecruiter, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) RestoreRecruiter(ctx context.Context, id string) (res recruiter.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RestoreRecruiter").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		err = s.recruiterRepository.Restore(ctx, id)
+		if err != nil {
+			return
+		}
+
+		current, err := s.recruiterRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		res = recruiter.ParseFromEntity(current)
+
+		return s.record(ctx, audit.ActionRestore, entityRecruiter, id, nil, res)
+	})
+	if err != nil {
+		err = repositoryError(err, "deleted "+entityRecruiter, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to restore by id", zap.Error(err))
+		}
 		return
 	}
 
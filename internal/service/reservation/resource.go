@@ -0,0 +1,167 @@
+package reservation
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"time"
+)
+
+func (s *Service) ListResources(ctx context.Context, filter resource.Filter) (res []resource.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListResources")
+
+	data, err := s.resourceRepository.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = resource.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddResource(ctx context.Context, req resource.Request) (res resource.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddResource")
+
+	equipment := resource.Tags(req.Equipment)
+	data := resource.Entity{
+		Name:      &req.Name,
+		Kind:      &req.Kind,
+		Capacity:  &req.Capacity,
+		Location:  &req.Location,
+		Equipment: &equipment,
+		Version:   1,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		data.ID, err = s.resourceRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = resource.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionCreate, entityResource, data.ID, nil, res)
+	})
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+func (s *Service) GetResource(ctx context.Context, id string) (res resource.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetResource").With(zap.String("id", id))
+
+	data, err := s.resourceRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, entityResource, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = resource.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) UpdateResource(ctx context.Context, id string, version int, req resource.Request) (res resource.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateResource").With(zap.String("id", id))
+
+	equipment := resource.Tags(req.Equipment)
+	data := resource.Entity{
+		Name:      &req.Name,
+		Kind:      &req.Kind,
+		Capacity:  &req.Capacity,
+		Location:  &req.Location,
+		Equipment: &equipment,
+		Version:   version,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.resourceRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = s.resourceRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.ID = id
+		data.Version = current.Version + 1
+		res = resource.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityResource, id, resource.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityResource, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// DeleteResource removes the resource, a resource still reserved for an
+// upcoming interview cannot be deleted
+func (s *Service) DeleteResource(ctx context.Context, id string, version int) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteResource").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.resourceRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		if err = s.interviewRepository.LockSchedules(ctx, id); err != nil {
+			return
+		}
+		upcoming, err := s.interviewRepository.List(ctx, interview.Filter{ResourceID: id, From: time.Now(), Status: interview.StatusScheduled})
+		if err != nil {
+			return
+		}
+		if len(upcoming) > 0 {
+			return apperror.Conflict("resource %s is reserved for interview %s", id, upcoming[0].ID)
+		}
+
+		err = s.resourceRepository.Delete(ctx, id, version)
+		if err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityResource, id, resource.ParseFromEntity(current), nil)
+	})
+	if err != nil {
+		err = repositoryError(err, entityResource, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// checkResources makes sure the resources of an interview exist and that
+// the rooms among them seat all the participants
+func (s *Service) checkResources(ctx context.Context, resourceIDs []string, participants int) (err error) {
+	for _, id := range resourceIDs {
+		data, err := s.resourceRepository.Get(ctx, id)
+		if err != nil {
+			return participantError(err, entityResource, id)
+		}
+		if *data.Kind == resource.KindRoom && *data.Capacity > 0 && *data.Capacity < participants {
+			return apperror.Unprocessable("room %s seats %d people, the interview has %d participants", id, *data.Capacity, participants)
+		}
+	}
+
+	return
+}
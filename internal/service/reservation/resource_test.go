@@ -0,0 +1,176 @@
+package reservation
+
+import (
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/pkg/apperror"
+	"testing"
+	"time"
+)
+
+func newResourceFixture(t *testing.T) fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	if err := WithResourceRepository(memory.NewResourceRepository())(f.service); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f fixture) resource(t *testing.T, req resource.Request) string {
+	t.Helper()
+
+	if err := req.Bind(nil); err != nil {
+		t.Fatal(err)
+	}
+	res, err := f.service.AddResource(f.ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.ID
+}
+
+// reserve schedules an interview of a new candidate and recruiter reserving the resources
+func (f fixture) reserve(t *testing.T, startsAt time.Time, resourceIDs ...string) (interview.Response, error) {
+	t.Helper()
+
+	recruiterID := f.recruiter(t, recruiter.Entity{})
+	return f.service.ScheduleInterview(f.ctx, interview.Request{
+		CandidateID:  f.candidate(t),
+		RecruiterID:  recruiterID,
+		RecruiterIDs: []string{recruiterID},
+		ResourceIDs:  resourceIDs,
+		Title:        "Interview",
+		StartsAt:     startsAt,
+		EndsAt:       startsAt.Add(time.Hour),
+	})
+}
+
+func TestScheduleInterviewResources(t *testing.T) {
+	tests := []struct {
+		name     string
+		reserved []time.Time
+		cancel   bool
+		startsAt time.Time
+		wantKind apperror.Kind
+	}{
+		{name: "free", startsAt: monday(9, 0)},
+		{name: "double booked", reserved: []time.Time{monday(9, 30)}, startsAt: monday(9, 0), wantKind: apperror.KindConflict},
+		{name: "back to back", reserved: []time.Time{monday(9, 0), monday(11, 0)}, startsAt: monday(10, 0)},
+		{name: "freed by a cancellation", reserved: []time.Time{monday(9, 0)}, cancel: true, startsAt: monday(9, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newResourceFixture(t)
+			room := f.resource(t, resource.Request{Name: "Room 1"})
+			projector := f.resource(t, resource.Request{Name: "Projector", Kind: resource.KindEquipment})
+
+			for _, startsAt := range tt.reserved {
+				res, err := f.reserve(t, startsAt, room, projector)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tt.cancel {
+					if _, err = f.service.CancelInterview(f.ctx, res.ID, res.Version); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			// either of the resources conflicts on its own
+			for _, resourceID := range []string{room, projector} {
+				_, err := f.reserve(t, tt.startsAt, resourceID)
+				if tt.wantKind == 0 && err != nil || tt.wantKind != 0 && apperror.KindOf(err) != tt.wantKind {
+					t.Errorf("ScheduleInterview() with %s error = %v, want kind %v", resourceID, err, tt.wantKind)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleInterviewResourceChecks(t *testing.T) {
+	f := newResourceFixture(t)
+	small := f.resource(t, resource.Request{Name: "Phone booth", Capacity: 1})
+	large := f.resource(t, resource.Request{Name: "Room 1", Capacity: 2})
+	laptop := f.resource(t, resource.Request{Name: "Laptop", Kind: resource.KindEquipment, Capacity: 1})
+
+	tests := []struct {
+		name        string
+		resourceIDs []string
+		wantKind    apperror.Kind
+	}{
+		{name: "room seats the participants", resourceIDs: []string{large}},
+		{name: "room too small", resourceIDs: []string{small}, wantKind: apperror.KindUnprocessable},
+		{name: "capacity of equipment", resourceIDs: []string{laptop}},
+		{name: "unknown resource", resourceIDs: []string{"00000000-0000-0000-0000-000000000000"}, wantKind: apperror.KindUnprocessable},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.reserve(t, monday(9+i, 0), tt.resourceIDs...)
+			if tt.wantKind == 0 && err != nil || tt.wantKind != 0 && apperror.KindOf(err) != tt.wantKind {
+				t.Errorf("ScheduleInterview() error = %v, want kind %v", err, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestRescheduleInterviewResources(t *testing.T) {
+	f := newResourceFixture(t)
+	room, other := f.resource(t, resource.Request{Name: "Room 1"}), f.resource(t, resource.Request{Name: "Room 2"})
+	if _, err := f.reserve(t, monday(11, 0), other); err != nil {
+		t.Fatal(err)
+	}
+	res, err := f.reserve(t, monday(9, 0), room)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := interview.Request{
+		CandidateID:  res.CandidateID,
+		RecruiterID:  res.RecruiterID,
+		RecruiterIDs: res.RecruiterIDs,
+		ResourceIDs:  []string{room},
+		Title:        "Interview",
+		StartsAt:     monday(9, 30),
+		EndsAt:       monday(10, 30),
+	}
+
+	// the interview does not conflict with its own reservation
+	if res, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req); err != nil {
+		t.Fatalf("RescheduleInterview() error = %v", err)
+	}
+
+	req.ResourceIDs = []string{room, other}
+	req.StartsAt, req.EndsAt = monday(10, 30), monday(11, 30)
+	if _, err = f.service.RescheduleInterview(f.ctx, res.ID, res.Version, req); apperror.KindOf(err) != apperror.KindConflict {
+		t.Errorf("RescheduleInterview() error = %v, want a conflict", err)
+	}
+}
+
+func TestDeleteResourceReserved(t *testing.T) {
+	f := newResourceFixture(t)
+	room := f.resource(t, resource.Request{Name: "Room 1"})
+	res, err := f.reserve(t, monday(9, 0), room)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = f.service.DeleteResource(f.ctx, room, 0); apperror.KindOf(err) != apperror.KindConflict {
+		t.Fatalf("DeleteResource() error = %v, want a conflict", err)
+	}
+
+	if _, err = f.service.CancelInterview(f.ctx, res.ID, res.Version); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.service.DeleteResource(f.ctx, room, 0); err != nil {
+		t.Fatalf("DeleteResource() error = %v", err)
+	}
+	if _, err = f.service.GetResource(f.ctx, room); apperror.KindOf(err) != apperror.KindNotFound {
+		t.Errorf("GetResource() error = %v, want not found", err)
+	}
+}
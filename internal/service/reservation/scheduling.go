@@ -0,0 +1,193 @@
+package reservation
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/scheduling"
+	solver "reservation-system/internal/service/scheduling"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"time"
+)
+
+// PlanInterviews assigns each candidate an interview with one of the
+// recruiters having the skills, within the working hours, free times and
+// preferred windows, the assignment is only previewed on a dry run and
+// scheduled as a whole otherwise
+func (s *Service) PlanInterviews(ctx context.Context, req scheduling.Request) (res scheduling.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("PlanInterviews")
+
+	if req.To.Sub(req.From) > maxAvailabilityRange {
+		err = apperror.Validation("to: at most %d days after from", int(maxAvailabilityRange/(24*time.Hour)))
+		return
+	}
+
+	problem, err := s.schedulingProblem(ctx, req)
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to collect schedules", zap.Error(err))
+		}
+		return
+	}
+
+	res = scheduling.Response{
+		DryRun:      req.DryRun,
+		Assignments: []scheduling.Assignment{},
+		Unassigned:  []scheduling.Unassigned{},
+		Interviews:  []interview.Response{},
+	}
+	assignments, unassigned := solver.Solve(problem)
+	res.Assignments = append(res.Assignments, assignments...)
+	res.Unassigned = append(res.Unassigned, unassigned...)
+	if req.DryRun || len(assignments) == 0 {
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		for _, assignment := range assignments {
+			recruiterIDs := []string{assignment.RecruiterID}
+			if err = s.checkSchedules(ctx, "", assignment.CandidateID, recruiterIDs, nil, "", assignment.StartsAt, assignment.EndsAt); err != nil {
+				return
+			}
+
+			candidateID, recruiterID := assignment.CandidateID, assignment.RecruiterID
+			startsAt, endsAt := assignment.StartsAt, assignment.EndsAt
+			status := interview.StatusScheduled
+			data := interview.Entity{
+				CandidateID:  &candidateID,
+				RecruiterID:  &recruiterID,
+				Title:        &req.Title,
+				Location:     &req.Location,
+				StartsAt:     &startsAt,
+				EndsAt:       &endsAt,
+				Status:       &status,
+				Version:      1,
+				Participants: interview.NewParticipants(candidateID, recruiterIDs),
+			}
+
+			if req.Remote {
+				data.ID = uuid.New().String()
+			}
+			var meetingURL string
+			if meetingURL, err = s.meeting(ctx, data, req.Remote); err != nil {
+				return
+			}
+			data.MeetingURL = &meetingURL
+
+			data.ID, err = s.interviewRepository.Add(ctx, data)
+			if err != nil {
+				return
+			}
+			created := interview.ParseFromEntity(data)
+			res.Interviews = append(res.Interviews, created)
+
+			if err = s.record(ctx, audit.ActionCreate, entityInterview, data.ID, nil, created); err != nil {
+				return
+			}
+			if err = s.notifyInterview(ctx, eventInterviewScheduled, data); err != nil {
+				return
+			}
+			if err = s.notify(ctx, eventRecruiterAssigned, data, false, recruiterIDs); err != nil {
+				return
+			}
+		}
+
+		return
+	})
+	if err != nil {
+		res = scheduling.Response{}
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// schedulingProblem collects the free times of the participants of the request
+func (s *Service) schedulingProblem(ctx context.Context, req scheduling.Request) (problem solver.Problem, err error) {
+	problem = solver.Problem{
+		Duration:  time.Duration(req.DurationMinutes) * time.Minute,
+		Break:     time.Duration(req.BreakMinutes) * time.Minute,
+		Step:      time.Duration(req.StepMinutes) * time.Minute,
+		MaxPerDay: req.MaxPerRecruiterPerDay,
+		Location:  s.workingHours.Location,
+	}
+	// the interviews of the weeks around the range count towards the caps and the breaks
+	from, to := req.From.Add(-7*24*time.Hour), req.To.Add(7*24*time.Hour)
+
+	for _, item := range req.Candidates {
+		if _, err = s.candidateRepository.Get(ctx, item.ID); err != nil {
+			return problem, participantError(err, entityCandidate, item.ID)
+		}
+
+		var interviews []interview.Entity
+		interviews, err = s.interviewRepository.List(ctx, interview.Filter{CandidateID: item.ID, From: req.From, To: req.To, Status: interview.StatusScheduled})
+		if err != nil {
+			return
+		}
+
+		free := []availability.Window{{StartsAt: req.From, EndsAt: req.To}}
+		if len(item.PreferredWindows) > 0 {
+			preferred := make([]availability.Window, 0, len(item.PreferredWindows))
+			for _, window := range item.PreferredWindows {
+				preferred = append(preferred, availability.Window{StartsAt: window.StartsAt, EndsAt: window.EndsAt})
+			}
+			free = availability.Intersect(free, preferred)
+		}
+
+		problem.Candidates = append(problem.Candidates, solver.Candidate{
+			ID:     item.ID,
+			Skills: item.Skills,
+			Free:   availability.Subtract(free, interviewWindows(interviews)),
+		})
+	}
+
+	for _, item := range req.Recruiters {
+		var data recruiter.Entity
+		if data, err = s.recruiterRepository.Get(ctx, item.ID); err != nil {
+			return problem, participantError(err, entityRecruiter, item.ID)
+		}
+
+		// the interview types of the profile stand in for the skills left out
+		entry := solver.Recruiter{ID: item.ID, Skills: item.Skills, Location: s.recruiterHours(data).Location}
+		if len(entry.Skills) == 0 && data.InterviewTypes != nil {
+			entry.Skills = *data.InterviewTypes
+		}
+		if data.MaxPerDay != nil {
+			entry.MaxPerDay = *data.MaxPerDay
+		}
+		if data.MaxPerWeek != nil {
+			entry.MaxPerWeek = *data.MaxPerWeek
+		}
+
+		if entry.Free, err = s.freeWindows(ctx, []string{item.ID}, req.From, req.To); err != nil {
+			return
+		}
+
+		var interviews []interview.Entity
+		interviews, err = s.interviewRepository.List(ctx, interview.Filter{RecruiterID: item.ID, From: from, To: to, Status: interview.StatusScheduled})
+		if err != nil {
+			return
+		}
+		entry.Booked = interviewWindows(interviews)
+
+		problem.Recruiters = append(problem.Recruiters, entry)
+	}
+
+	return
+}
+
+func interviewWindows(data []interview.Entity) (dest []availability.Window) {
+	dest = make([]availability.Window, 0, len(data))
+	for _, item := range data {
+		dest = append(dest, availability.Window{StartsAt: *item.StartsAt, EndsAt: *item.EndsAt})
+	}
+	return
+}
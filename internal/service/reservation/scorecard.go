@@ -0,0 +1,484 @@
+package reservation
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"strings"
+	"time"
+)
+
+func (s *Service) ListScorecardTemplates(ctx context.Context) (res []scorecard.TemplateResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListScorecardTemplates")
+
+	data, err := s.templateRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = scorecard.ParseFromTemplates(data)
+
+	return
+}
+
+func (s *Service) AddScorecardTemplate(ctx context.Context, req scorecard.TemplateRequest) (res scorecard.TemplateResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddScorecardTemplate")
+
+	criteria := scorecard.Criteria(req.Criteria)
+	data := scorecard.Template{
+		Name:     &req.Name,
+		Criteria: &criteria,
+		Version:  1,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		data.ID, err = s.templateRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = scorecard.ParseFromTemplate(data)
+
+		return s.record(ctx, audit.ActionCreate, entityScorecardTemplate, data.ID, nil, res)
+	})
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+
+	return
+}
+
+func (s *Service) GetScorecardTemplate(ctx context.Context, id string) (res scorecard.TemplateResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetScorecardTemplate").With(zap.String("id", id))
+
+	data, err := s.templateRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, entityScorecardTemplate, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = scorecard.ParseFromTemplate(data)
+
+	return
+}
+
+// UpdateScorecardTemplate changes the criteria of the template, the
+// scorecards submitted with it keep the criteria they were rated on
+func (s *Service) UpdateScorecardTemplate(ctx context.Context, id string, version int, req scorecard.TemplateRequest) (res scorecard.TemplateResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateScorecardTemplate").With(zap.String("id", id))
+
+	criteria := scorecard.Criteria(req.Criteria)
+	data := scorecard.Template{
+		Name:     &req.Name,
+		Criteria: &criteria,
+		Version:  version,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.templateRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = s.templateRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.ID = id
+		data.Version = current.Version + 1
+		res = scorecard.ParseFromTemplate(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityScorecardTemplate, id, scorecard.ParseFromTemplate(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityScorecardTemplate, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// DeleteScorecardTemplate removes the template, a template scorecards were
+// submitted with cannot be deleted
+func (s *Service) DeleteScorecardTemplate(ctx context.Context, id string, version int) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteScorecardTemplate").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.templateRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		submitted, err := s.scorecardRepository.List(ctx, scorecard.Filter{TemplateID: id})
+		if err != nil {
+			return
+		}
+		if len(submitted) > 0 {
+			return apperror.Conflict("scorecard template %s is used by %d scorecards", id, len(submitted))
+		}
+
+		err = s.templateRepository.Delete(ctx, id, version)
+		if err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityScorecardTemplate, id, scorecard.ParseFromTemplate(current), nil)
+	})
+	if err != nil {
+		err = repositoryError(err, entityScorecardTemplate, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// ListScorecards returns the scorecards submitted on the interview that the
+// recruiter of the request may read, only the panel and the admin key read them
+func (s *Service) ListScorecards(ctx context.Context, interviewID string) (res []scorecard.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListScorecards").With(zap.String("id", interviewID))
+
+	recruiterID, err := scorecardReader(ctx)
+	if err != nil {
+		return
+	}
+
+	current, err := s.interviewRepository.Get(ctx, interviewID)
+	if err != nil {
+		err = repositoryError(err, entityInterview, interviewID)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	if recruiterID != "" && !current.HasRecruiter(recruiterID) {
+		err = apperror.Forbidden("recruiter %s is not on the panel of interview %s", recruiterID, interviewID)
+		return
+	}
+
+	data, _, err := s.visibleScorecards(ctx, []interview.Entity{current}, recruiterID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = scorecard.ParseFromEntities(data)
+
+	return
+}
+
+// SubmitScorecard records the feedback of a recruiter of the panel once the
+// interview has started, a recruiter submits one scorecard per interview
+func (s *Service) SubmitScorecard(ctx context.Context, interviewID string, req scorecard.Request) (res scorecard.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("SubmitScorecard").With(zap.String("id", interviewID))
+
+	if req.RecruiterID, err = scorecardAuthor(ctx, req.RecruiterID); err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	data := scorecard.Submission{
+		InterviewID:    &interviewID,
+		RecruiterID:    &req.RecruiterID,
+		TemplateID:     &req.TemplateID,
+		Recommendation: &req.Recommendation,
+		Comment:        &req.Comment,
+		SubmittedAt:    &now,
+		Version:        1,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.checkScorecard(ctx, interviewID, &data, req); err != nil {
+			return
+		}
+
+		submitted, err := s.scorecardRepository.List(ctx, scorecard.Filter{InterviewIDs: []string{interviewID}, RecruiterID: req.RecruiterID})
+		if err != nil {
+			return
+		}
+		if len(submitted) > 0 {
+			return apperror.Conflict("recruiter %s already submitted scorecard %s for interview %s", req.RecruiterID, submitted[0].ID, interviewID)
+		}
+
+		data.ID, err = s.scorecardRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = scorecard.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionCreate, entityScorecard, data.ID, nil, res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityInterview, interviewID)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// UpdateScorecard changes the feedback of the recruiter who submitted it
+func (s *Service) UpdateScorecard(ctx context.Context, interviewID, id string, version int, req scorecard.Request) (res scorecard.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateScorecard").With(zap.String("id", id))
+
+	if req.RecruiterID, err = scorecardAuthor(ctx, req.RecruiterID); err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	data := scorecard.Submission{
+		InterviewID:    &interviewID,
+		RecruiterID:    &req.RecruiterID,
+		TemplateID:     &req.TemplateID,
+		Recommendation: &req.Recommendation,
+		Comment:        &req.Comment,
+		SubmittedAt:    &now,
+		Version:        version,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.scorecardRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		if *current.InterviewID != interviewID {
+			return store.ErrorNotFound
+		}
+		if *current.RecruiterID != req.RecruiterID {
+			return apperror.Forbidden("scorecard %s was submitted by recruiter %s", id, *current.RecruiterID)
+		}
+
+		if err = s.checkScorecard(ctx, interviewID, &data, req); err != nil {
+			return
+		}
+
+		err = s.scorecardRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.ID = id
+		data.Version = current.Version + 1
+		res = scorecard.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityScorecard, id, scorecard.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityScorecard, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// SummarizeScorecards aggregates the scorecards submitted on the interviews
+// of the candidate that the recruiter of the request sat on the panel of, the
+// admin key reads those of every interview
+func (s *Service) SummarizeScorecards(ctx context.Context, candidateID string) (res scorecard.SummaryResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("SummarizeScorecards").With(zap.String("id", candidateID))
+
+	recruiterID, err := scorecardReader(ctx)
+	if err != nil {
+		return
+	}
+
+	if _, err = s.candidateRepository.Get(ctx, candidateID); err != nil {
+		err = repositoryError(err, entityCandidate, candidateID)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	interviews, err := s.interviewRepository.List(ctx, interview.Filter{CandidateID: candidateID})
+	if err != nil {
+		logger.Error("failed to select interviews", zap.Error(err))
+		return
+	}
+	if recruiterID != "" {
+		panel := make([]interview.Entity, 0, len(interviews))
+		for _, item := range interviews {
+			if item.HasRecruiter(recruiterID) {
+				panel = append(panel, item)
+			}
+		}
+		if len(panel) == 0 {
+			err = apperror.Forbidden("recruiter %s is not on the panel of an interview of candidate %s", recruiterID, candidateID)
+			return
+		}
+		interviews = panel
+	}
+
+	data, hidden, err := s.visibleScorecards(ctx, interviews, recruiterID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = scorecard.Summarize(candidateID, data, hidden)
+
+	return
+}
+
+// checkScorecard makes sure the recruiter is on the panel of the interview,
+// which has started and was not cancelled, and rates the criteria of the
+// template into data
+func (s *Service) checkScorecard(ctx context.Context, interviewID string, data *scorecard.Submission, req scorecard.Request) (err error) {
+	current, err := s.interviewRepository.Get(ctx, interviewID)
+	if err != nil {
+		return
+	}
+	if *current.Status != interview.StatusScheduled {
+		return apperror.Conflict("interview %s is %s", interviewID, *current.Status)
+	}
+	if current.StartsAt.After(time.Now()) {
+		return apperror.Conflict("interview %s has not started yet", interviewID)
+	}
+	if !current.HasRecruiter(req.RecruiterID) {
+		return apperror.Unprocessable("recruiter %s is not on the panel of interview %s", req.RecruiterID, interviewID)
+	}
+
+	template, err := s.templateRepository.Get(ctx, req.TemplateID)
+	if err != nil {
+		return participantError(err, entityScorecardTemplate, req.TemplateID)
+	}
+
+	ratings, err := rate(template, req.Ratings)
+	if err != nil {
+		return
+	}
+	data.Ratings = &ratings
+
+	return
+}
+
+// rate checks the ratings against the criteria of the template, every
+// criterion is rated from 1 to its scale
+func rate(template scorecard.Template, req []scorecard.RatingRequest) (dest scorecard.Ratings, err error) {
+	var criteria scorecard.Criteria
+	if template.Criteria != nil {
+		criteria = *template.Criteria
+	}
+
+	for _, rating := range req {
+		if _, ok := criteria.Find(rating.Criterion); !ok {
+			return nil, apperror.Unprocessable("ratings: %s is not a criterion of scorecard template %s", rating.Criterion, template.ID)
+		}
+	}
+
+	dest = make(scorecard.Ratings, 0, len(criteria))
+	for _, criterion := range criteria {
+		var (
+			rating scorecard.RatingRequest
+			ok     bool
+		)
+		for _, item := range req {
+			if ok = strings.EqualFold(item.Criterion, criterion.Name); ok {
+				rating = item
+				break
+			}
+		}
+		if !ok {
+			return nil, apperror.Unprocessable("ratings: %s is not rated", criterion.Name)
+		}
+		if rating.Score < 1 || rating.Score > criterion.Scale {
+			return nil, apperror.Unprocessable("ratings: score of %s must be between 1 and %d", criterion.Name, criterion.Scale)
+		}
+		dest = append(dest, scorecard.Rating{
+			Criterion: criterion.Name,
+			Score:     rating.Score,
+			Scale:     criterion.Scale,
+			Comment:   rating.Comment,
+		})
+	}
+
+	return
+}
+
+// scorecardReader returns the recruiter the key of the request is issued to,
+// the admin key reads every scorecard and is returned as no recruiter
+func scorecardReader(ctx context.Context) (recruiterID string, err error) {
+	if audit.IsAdmin(ctx) {
+		return "", nil
+	}
+	if recruiterID = audit.RecruiterFromContext(ctx); recruiterID == "" {
+		err = apperror.Forbidden("scorecards: only the keys of the recruiters of the panel and the admin key read scorecards")
+	}
+
+	return
+}
+
+// scorecardAuthor returns the recruiter the key of the request is issued to,
+// the admin key submits on behalf of the recruiter named in the request
+func scorecardAuthor(ctx context.Context, recruiterID string) (string, error) {
+	if audit.IsAdmin(ctx) {
+		if recruiterID == "" {
+			return "", apperror.Validation("recruiterId: cannot be blank")
+		}
+		return recruiterID, nil
+	}
+
+	author := audit.RecruiterFromContext(ctx)
+	switch {
+	case author == "":
+		return "", apperror.Forbidden("scorecards: only the keys of the recruiters of the panel submit scorecards")
+	case recruiterID != "" && recruiterID != author:
+		return "", apperror.Forbidden("recruiterId: the key acts as recruiter %s", author)
+	}
+
+	return author, nil
+}
+
+// visibleScorecards returns the scorecards submitted on the interviews that
+// the recruiter may read along with the number of the hidden ones. A
+// recruiter of the panel of an interview only reads the scorecards of the
+// others once they have submitted their own.
+func (s *Service) visibleScorecards(ctx context.Context, interviews []interview.Entity, recruiterID string) (dest []scorecard.Submission, hidden int, err error) {
+	ids := make([]string, 0, len(interviews))
+	for _, item := range interviews {
+		ids = append(ids, item.ID)
+	}
+
+	data, err := s.scorecardRepository.List(ctx, scorecard.Filter{InterviewIDs: ids})
+	if err != nil {
+		return
+	}
+
+	submitted := make(map[string]bool)
+	for _, item := range data {
+		if *item.RecruiterID == recruiterID {
+			submitted[*item.InterviewID] = true
+		}
+	}
+	withheld := make(map[string]bool)
+	for _, item := range interviews {
+		if item.HasRecruiter(recruiterID) && !submitted[item.ID] {
+			withheld[item.ID] = true
+		}
+	}
+
+	dest = make([]scorecard.Submission, 0, len(data))
+	for _, item := range data {
+		if withheld[*item.InterviewID] && *item.RecruiterID != recruiterID {
+			hidden++
+			continue
+		}
+		dest = append(dest, item)
+	}
+
+	return
+}
@@ -0,0 +1,219 @@
+package reservation
+
+import (
+	"context"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/pkg/apperror"
+	"testing"
+	"time"
+)
+
+func newScorecardFixture(t *testing.T) fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	for _, cfg := range []Configuration{
+		WithScorecardTemplateRepository(memory.NewScorecardTemplateRepository()),
+		WithScorecardRepository(memory.NewScorecardRepository()),
+	} {
+		if err := cfg(f.service); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func (f fixture) template(t *testing.T) string {
+	t.Helper()
+
+	req := scorecard.TemplateRequest{Name: "Backend", Criteria: []scorecard.Criterion{{Name: "Design"}, {Name: "Coding", Scale: 10}}}
+	if err := req.Bind(nil); err != nil {
+		t.Fatal(err)
+	}
+	res, err := f.service.AddScorecardTemplate(f.ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.ID
+}
+
+// as returns the context of the requests bearing the key of the recruiter
+func (f fixture) as(recruiterID string) context.Context {
+	return audit.ContextWithRecruiter(audit.ContextWithPrincipal(f.ctx, "key:"+recruiterID[:8]), recruiterID)
+}
+
+func scorecardRequest(templateID, recommendation string) scorecard.Request {
+	return scorecard.Request{
+		TemplateID:     templateID,
+		Recommendation: recommendation,
+		Ratings:        []scorecard.RatingRequest{{Criterion: "design", Score: 4}, {Criterion: "Coding", Score: 7}},
+	}
+}
+
+func TestSubmitScorecard(t *testing.T) {
+	f := newScorecardFixture(t)
+	templateID := f.template(t)
+	jane, rita := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+	started := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Minute)
+	candidateID := f.candidate(t)
+	past := f.panel(t, candidateID, []string{jane}, started, interview.StatusScheduled)
+	upcoming := f.panel(t, candidateID, []string{jane}, started.Add(72*time.Hour), interview.StatusScheduled)
+	cancelled := f.panel(t, candidateID, []string{jane}, started.Add(-24*time.Hour), interview.StatusCancelled)
+	admin := audit.ContextWithPrincipal(f.ctx, audit.PrincipalAdmin)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		interviewID string
+		change      func(req *scorecard.Request)
+		wantKind    apperror.Kind
+	}{
+		{name: "panel recruiter", ctx: f.as(jane), interviewID: past},
+		{name: "interview not started", ctx: f.as(jane), interviewID: upcoming, wantKind: apperror.KindConflict},
+		{name: "cancelled interview", ctx: f.as(jane), interviewID: cancelled, wantKind: apperror.KindConflict},
+		{name: "unknown interview", ctx: f.as(jane), interviewID: "00000000-0000-0000-0000-000000000000", wantKind: apperror.KindNotFound},
+		{name: "recruiter off the panel", ctx: f.as(rita), interviewID: past, wantKind: apperror.KindUnprocessable},
+		{name: "unknown template", ctx: f.as(jane), interviewID: past, change: func(req *scorecard.Request) { req.TemplateID = "00000000-0000-0000-0000-000000000000" }, wantKind: apperror.KindUnprocessable},
+		{name: "unknown criterion", ctx: f.as(jane), interviewID: past, change: func(req *scorecard.Request) {
+			req.Ratings = append(req.Ratings, scorecard.RatingRequest{Criterion: "Culture", Score: 3})
+		}, wantKind: apperror.KindUnprocessable},
+		{name: "criterion not rated", ctx: f.as(jane), interviewID: past, change: func(req *scorecard.Request) { req.Ratings = req.Ratings[:1] }, wantKind: apperror.KindUnprocessable},
+		{name: "score above the scale", ctx: f.as(jane), interviewID: past, change: func(req *scorecard.Request) { req.Ratings[0].Score = 6 }, wantKind: apperror.KindUnprocessable},
+		{name: "score below the scale", ctx: f.as(jane), interviewID: past, change: func(req *scorecard.Request) { req.Ratings[1].Score = 0 }, wantKind: apperror.KindUnprocessable},
+		{name: "admin without a recruiter", ctx: admin, interviewID: past, wantKind: apperror.KindValidation},
+		{name: "key naming another recruiter", ctx: f.as(rita), interviewID: past, change: func(req *scorecard.Request) { req.RecruiterID = jane }, wantKind: apperror.KindForbidden},
+		{name: "request without a recruiter key", ctx: f.ctx, interviewID: past, wantKind: apperror.KindForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := scorecardRequest(templateID, scorecard.RecommendationHire)
+			if tt.change != nil {
+				tt.change(&req)
+			}
+
+			res, err := f.service.SubmitScorecard(tt.ctx, tt.interviewID, req)
+			if tt.wantKind != apperror.KindInternal {
+				if apperror.KindOf(err) != tt.wantKind {
+					t.Errorf("SubmitScorecard() error = %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SubmitScorecard() error = %v", err)
+			}
+			want := scorecard.Ratings{{Criterion: "Design", Score: 4, Scale: 5}, {Criterion: "Coding", Score: 7, Scale: 10}}
+			if res.RecruiterID != jane || len(res.Ratings) != len(want) || res.Ratings[0] != want[0] || res.Ratings[1] != want[1] {
+				t.Errorf("SubmitScorecard() = %+v, want the ratings %+v of %s", res, want, jane)
+			}
+
+			// a recruiter submits once per interview
+			if _, err = f.service.SubmitScorecard(tt.ctx, tt.interviewID, req); apperror.KindOf(err) != apperror.KindConflict {
+				t.Errorf("SubmitScorecard() again error = %v, want a conflict", err)
+			}
+		})
+	}
+}
+
+func TestUpdateScorecard(t *testing.T) {
+	f := newScorecardFixture(t)
+	templateID := f.template(t)
+	jane, rita := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+	started := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Minute)
+	interviewID := f.panel(t, f.candidate(t), []string{jane, rita}, started, interview.StatusScheduled)
+
+	res, err := f.service.SubmitScorecard(f.as(jane), interviewID, scorecardRequest(templateID, scorecard.RecommendationHire))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := scorecardRequest(templateID, scorecard.RecommendationNoHire)
+	if _, err = f.service.UpdateScorecard(f.as(rita), interviewID, res.ID, res.Version, req); apperror.KindOf(err) != apperror.KindForbidden {
+		t.Errorf("UpdateScorecard() by another recruiter error = %v, want forbidden", err)
+	}
+	if _, err = f.service.UpdateScorecard(f.as(jane), "00000000-0000-0000-0000-000000000000", res.ID, res.Version, req); apperror.KindOf(err) != apperror.KindNotFound {
+		t.Errorf("UpdateScorecard() of another interview error = %v, want not found", err)
+	}
+
+	updated, err := f.service.UpdateScorecard(f.as(jane), interviewID, res.ID, res.Version, req)
+	if err != nil {
+		t.Fatalf("UpdateScorecard() error = %v", err)
+	}
+	if updated.Recommendation != scorecard.RecommendationNoHire || updated.Version != res.Version+1 {
+		t.Errorf("UpdateScorecard() = %+v, want no hire at version %d", updated, res.Version+1)
+	}
+	if _, err = f.service.UpdateScorecard(f.as(jane), interviewID, res.ID, res.Version, req); apperror.KindOf(err) != apperror.KindPreconditionFailed {
+		t.Errorf("UpdateScorecard() of an outdated version error = %v, want a failed precondition", err)
+	}
+}
+
+func TestScorecardVisibility(t *testing.T) {
+	f := newScorecardFixture(t)
+	templateID := f.template(t)
+	jane, rita, ralf := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+	started := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Minute)
+	candidateID := f.candidate(t)
+	interviewID := f.panel(t, candidateID, []string{jane, rita}, started, interview.StatusScheduled)
+
+	if _, err := f.service.SubmitScorecard(f.as(jane), interviewID, scorecardRequest(templateID, scorecard.RecommendationHire)); err != nil {
+		t.Fatal(err)
+	}
+
+	visible := func(ctx context.Context, wantScorecards, wantHidden int) {
+		t.Helper()
+
+		res, err := f.service.ListScorecards(ctx, interviewID)
+		if err != nil {
+			t.Fatalf("ListScorecards() error = %v", err)
+		}
+		summary, err := f.service.SummarizeScorecards(ctx, candidateID)
+		if err != nil {
+			t.Fatalf("SummarizeScorecards() error = %v", err)
+		}
+		if len(res) != wantScorecards || summary.Scorecards != wantScorecards || summary.Hidden != wantHidden {
+			t.Errorf("ListScorecards() = %d and SummarizeScorecards() = %d scorecards with %d hidden, want %d with %d hidden",
+				len(res), summary.Scorecards, summary.Hidden, wantScorecards, wantHidden)
+		}
+	}
+
+	// the feedback of the others is hidden until the recruiter submits their own
+	visible(f.as(jane), 1, 0)
+	visible(f.as(rita), 0, 1)
+	if _, err := f.service.SubmitScorecard(f.as(rita), interviewID, scorecardRequest(templateID, scorecard.RecommendationNoHire)); err != nil {
+		t.Fatal(err)
+	}
+	visible(f.as(rita), 2, 0)
+	visible(audit.ContextWithPrincipal(f.ctx, audit.PrincipalAdmin), 2, 0)
+
+	if _, err := f.service.ListScorecards(f.as(ralf), interviewID); apperror.KindOf(err) != apperror.KindForbidden {
+		t.Errorf("ListScorecards() off the panel error = %v, want forbidden", err)
+	}
+	if _, err := f.service.SummarizeScorecards(f.as(ralf), candidateID); apperror.KindOf(err) != apperror.KindForbidden {
+		t.Errorf("SummarizeScorecards() off the panel error = %v, want forbidden", err)
+	}
+	if _, err := f.service.ListScorecards(f.ctx, interviewID); apperror.KindOf(err) != apperror.KindForbidden {
+		t.Errorf("ListScorecards() without a recruiter key error = %v, want forbidden", err)
+	}
+}
+
+func TestDeleteScorecardTemplate(t *testing.T) {
+	f := newScorecardFixture(t)
+	unused, used := f.template(t), f.template(t)
+	jane := f.recruiter(t, recruiter.Entity{})
+	started := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Minute)
+	interviewID := f.panel(t, f.candidate(t), []string{jane}, started, interview.StatusScheduled)
+	if _, err := f.service.SubmitScorecard(f.as(jane), interviewID, scorecardRequest(used, scorecard.RecommendationHire)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.service.DeleteScorecardTemplate(f.ctx, used, 1); apperror.KindOf(err) != apperror.KindConflict {
+		t.Errorf("DeleteScorecardTemplate() of a used template error = %v, want a conflict", err)
+	}
+	if err := f.service.DeleteScorecardTemplate(f.ctx, unused, 1); err != nil {
+		t.Errorf("DeleteScorecardTemplate() error = %v", err)
+	}
+}
@@ -1,23 +1,76 @@
 package reservation
 
 import (
+	"net/http"
+	"reservation-system/internal/domain/application"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/busy"
+	"reservation-system/internal/domain/calendar"
 	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/notification"
+	"reservation-system/internal/domain/outbox"
 	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/resource"
+	"reservation-system/internal/domain/scorecard"
+	"reservation-system/internal/domain/team"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/pkg/blob"
+	"reservation-system/pkg/egress"
+	"reservation-system/pkg/store"
+	"time"
 )
 
 type Configuration func(s *Service) error
 
 // Service is an implementation of the Service
 type Service struct {
-	candidateRepository candidate.Repository
-	recruiterRepository recruiter.Repository
+	candidateRepository   candidate.Repository
+	recruiterRepository   recruiter.Repository
+	interviewRepository   interview.Repository
+	feedTokenRepository   calendar.FeedTokenRepository
+	resourceRepository    resource.Repository
+	vacancyRepository     vacancy.Repository
+	applicationRepository application.Repository
+	templateRepository    scorecard.TemplateRepository
+	scorecardRepository   scorecard.Repository
+	teamRepository        team.Repository
+	teamMemberRepository  team.MemberRepository
+	noteRepository        candidate.NoteRepository
+	attachmentRepository  candidate.AttachmentRepository
+	blobStore             blob.Store
+	meetingProvider       interview.MeetingProvider
+	busyRepository        busy.Repository
+	busyFeedRepository    busy.FeedRepository
+	auditRepository       audit.Repository
+	eventPublisher        event.Publisher
+	outboxRepository      outbox.Repository
+	notifier              notification.Notifier
+	unitOfWork            store.UnitOfWork
+	client                *http.Client
+
+	busyHorizon       time.Duration
+	workingHours      availability.WorkingHours
+	maxAttachmentSize int64
 }
 
 // New takes a variable amount of Configuration functions and returns a new Service
 // Each Configuration will be called in the order they are passed in
 func New(configs ...Configuration) (s *Service, err error) {
 	// Add the service
-	s = &Service{}
+	s = &Service{
+		client:            egress.NewPolicy().Client(defaultFeedTimeout),
+		busyHorizon:       defaultBusyHorizon,
+		maxAttachmentSize: defaultMaxAttachmentSize,
+		workingHours: availability.WorkingHours{
+			Days:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+			Start:    9 * time.Hour,
+			End:      17 * time.Hour,
+			Location: time.UTC,
+		},
+	}
 
 	// Apply all Configurations passed in
 	for _, cfg := range configs {
@@ -46,3 +99,231 @@ func WithRecruiterRepository(recruiterRepository recruiter.Repository) Configura
 		return nil
 	}
 }
+
+func WithInterviewRepository(interviewRepository interview.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.interviewRepository = interviewRepository
+		return nil
+	}
+}
+
+func WithFeedTokenRepository(feedTokenRepository calendar.FeedTokenRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.feedTokenRepository = feedTokenRepository
+		return nil
+	}
+}
+
+func WithResourceRepository(resourceRepository resource.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.resourceRepository = resourceRepository
+		return nil
+	}
+}
+
+func WithVacancyRepository(vacancyRepository vacancy.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.vacancyRepository = vacancyRepository
+		return nil
+	}
+}
+
+func WithApplicationRepository(applicationRepository application.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.applicationRepository = applicationRepository
+		return nil
+	}
+}
+
+func WithScorecardTemplateRepository(templateRepository scorecard.TemplateRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.templateRepository = templateRepository
+		return nil
+	}
+}
+
+func WithScorecardRepository(scorecardRepository scorecard.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.scorecardRepository = scorecardRepository
+		return nil
+	}
+}
+
+func WithTeamRepository(teamRepository team.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.teamRepository = teamRepository
+		return nil
+	}
+}
+
+func WithTeamMemberRepository(teamMemberRepository team.MemberRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.teamMemberRepository = teamMemberRepository
+		return nil
+	}
+}
+
+func WithNoteRepository(noteRepository candidate.NoteRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.noteRepository = noteRepository
+		return nil
+	}
+}
+
+func WithAttachmentRepository(attachmentRepository candidate.AttachmentRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.attachmentRepository = attachmentRepository
+		return nil
+	}
+}
+
+// WithBlobStore sets the store keeping the content of the candidate attachments
+func WithBlobStore(blobStore blob.Store) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.blobStore = blobStore
+		return nil
+	}
+}
+
+// WithMaxAttachmentSize sets the largest candidate attachment accepted, in bytes
+func WithMaxAttachmentSize(size int64) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if size > 0 {
+			s.maxAttachmentSize = size
+		}
+		return nil
+	}
+}
+
+// WithMeetingProvider lets the interviews be remote, with a video meeting provisioned by provider
+func WithMeetingProvider(provider interview.MeetingProvider) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.meetingProvider = provider
+		return nil
+	}
+}
+
+func WithBusyRepository(busyRepository busy.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.busyRepository = busyRepository
+		return nil
+	}
+}
+
+func WithBusyFeedRepository(busyFeedRepository busy.FeedRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.busyFeedRepository = busyFeedRepository
+		return nil
+	}
+}
+
+// WithHTTPClient sets the client fetching the external calendars of recruiters
+func WithHTTPClient(client *http.Client) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.client = client
+		return nil
+	}
+}
+
+// WithBusyHorizon sets how far ahead the recurring busy times of external calendars are expanded
+func WithBusyHorizon(horizon time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if horizon > 0 {
+			s.busyHorizon = horizon
+		}
+		return nil
+	}
+}
+
+// WithWorkingHours sets the working hours of the recruiters availability is computed within
+func WithWorkingHours(workingHours availability.WorkingHours) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.workingHours = workingHours
+		return nil
+	}
+}
+
+func WithAuditRepository(auditRepository audit.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.auditRepository = auditRepository
+		return nil
+	}
+}
+
+func WithEventPublisher(eventPublisher event.Publisher) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.eventPublisher = eventPublisher
+		return nil
+	}
+}
+
+func WithOutboxRepository(outboxRepository outbox.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.outboxRepository = outboxRepository
+		return nil
+	}
+}
+
+// WithNotifier applies a given notifier to the Service, the participants of
+// the interviews are notified of their changes when there is one
+func WithNotifier(notifier notification.Notifier) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.notifier = notifier
+		return nil
+	}
+}
+
+func WithUnitOfWork(unitOfWork store.UnitOfWork) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.unitOfWork = unitOfWork
+		return nil
+	}
+}
@@ -0,0 +1,414 @@
+package reservation
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/team"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"sort"
+	"time"
+)
+
+func (s *Service) ListTeams(ctx context.Context) (res []team.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListTeams")
+
+	data, err := s.teamRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = team.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddTeam(ctx context.Context, req team.Request) (res team.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddTeam")
+
+	data := team.Entity{
+		Name:     &req.Name,
+		Strategy: &req.Strategy,
+		Version:  1,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		data.ID, err = s.teamRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = team.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionCreate, entityTeam, data.ID, nil, res)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) GetTeam(ctx context.Context, id string) (res team.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetTeam").With(zap.String("id", id))
+
+	data, err := s.teamRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, entityTeam, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = team.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) UpdateTeam(ctx context.Context, id string, version int, req team.Request) (res team.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateTeam").With(zap.String("id", id))
+
+	data := team.Entity{
+		Name:     &req.Name,
+		Strategy: &req.Strategy,
+		Version:  version,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.teamRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = s.teamRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.ID = id
+		data.Version = current.Version + 1
+		res = team.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityTeam, id, team.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityTeam, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// DeleteTeam removes the team and its memberships, the recruiters and the
+// interviews booked against the team are kept
+func (s *Service) DeleteTeam(ctx context.Context, id string, version int) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteTeam").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.teamRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		members, err := s.teamMemberRepository.List(ctx, id)
+		if err != nil {
+			return
+		}
+		for _, member := range members {
+			if err = s.teamMemberRepository.Delete(ctx, id, member.RecruiterID); err != nil {
+				return
+			}
+		}
+
+		err = s.teamRepository.Delete(ctx, id, version)
+		if err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityTeam, id, team.ParseFromEntity(current), nil)
+	})
+	if err != nil {
+		err = repositoryError(err, entityTeam, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) ListTeamMembers(ctx context.Context, id string) (res []team.MemberResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListTeamMembers").With(zap.String("id", id))
+
+	if _, err = s.teamRepository.Get(ctx, id); err != nil {
+		err = repositoryError(err, entityTeam, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+
+	data, err := s.teamMemberRepository.List(ctx, id)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = team.ParseFromMembers(data)
+
+	return
+}
+
+// AddTeamMember makes the recruiter a member of the team, the member takes
+// the last turn of the round robin
+func (s *Service) AddTeamMember(ctx context.Context, id, recruiterID string) (res []team.MemberResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddTeamMember").With(zap.String("id", id), zap.String("recruiter_id", recruiterID))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if _, err = s.teamRepository.Get(ctx, id); err != nil {
+			return repositoryError(err, entityTeam, id)
+		}
+		if _, err = s.recruiterRepository.Get(ctx, recruiterID); err != nil {
+			return participantError(err, entityRecruiter, recruiterID)
+		}
+
+		err = s.teamMemberRepository.Add(ctx, team.Member{TeamID: id, RecruiterID: recruiterID})
+		if err != nil {
+			if errors.Is(err, store.ErrorConflict) {
+				return apperror.Conflict("recruiter %s is already a member of team %s", recruiterID, id)
+			}
+			return
+		}
+
+		members, err := s.teamMemberRepository.List(ctx, id)
+		if err != nil {
+			return
+		}
+		res = team.ParseFromMembers(members)
+
+		// the memberships are recorded under the team they belong to
+		for _, member := range res {
+			if member.RecruiterID == recruiterID {
+				return s.record(ctx, audit.ActionCreate, entityTeamMember, id, nil, member)
+			}
+		}
+
+		return
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to add member", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) RemoveTeamMember(ctx context.Context, id, recruiterID string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("RemoveTeamMember").With(zap.String("id", id), zap.String("recruiter_id", recruiterID))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if _, err = s.teamRepository.Get(ctx, id); err != nil {
+			return repositoryError(err, entityTeam, id)
+		}
+
+		members, err := s.teamMemberRepository.List(ctx, id)
+		if err != nil {
+			return
+		}
+		for _, member := range members {
+			if member.RecruiterID != recruiterID {
+				continue
+			}
+			if err = s.teamMemberRepository.Delete(ctx, id, recruiterID); err != nil {
+				return
+			}
+			return s.record(ctx, audit.ActionDelete, entityTeamMember, id, team.ParseFromMember(member), nil)
+		}
+
+		return apperror.NotFound("recruiter %s is not a member of team %s", recruiterID, id)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to remove member", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// BookTeamInterview schedules an interview of the candidate with a member of
+// the team who is within their working hours and free for the whole interview,
+// the strategy of the team decides which of the available members it gets
+func (s *Service) BookTeamInterview(ctx context.Context, id string, req team.BookingRequest) (res interview.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("BookTeamInterview").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		// the bookings of a team take their turns one at a time, the team is
+		// read under the lock so that the last assigned member is current
+		if err = s.interviewRepository.LockSchedules(ctx, id); err != nil {
+			return
+		}
+		data, err := s.teamRepository.Get(ctx, id)
+		if err != nil {
+			return repositoryError(err, entityTeam, id)
+		}
+
+		strategy := req.Strategy
+		if strategy == "" && data.Strategy != nil {
+			strategy = *data.Strategy
+		}
+		lastAssignedID := ""
+		if data.LastAssignedID != nil {
+			lastAssignedID = *data.LastAssignedID
+		}
+
+		members, err := s.teamMemberRepository.List(ctx, id)
+		if err != nil {
+			return
+		}
+		candidates, err := s.teamCandidates(ctx, team.Turns(members, lastAssignedID), req.InterviewType)
+		if err != nil {
+			return
+		}
+		if len(candidates) == 0 {
+			if req.InterviewType != "" {
+				return apperror.Unprocessable("team %s has no members conducting %s interviews", id, req.InterviewType)
+			}
+			return apperror.Unprocessable("team %s has no members", id)
+		}
+
+		if _, err = s.candidateRepository.Get(ctx, req.CandidateID); err != nil {
+			return participantError(err, entityCandidate, req.CandidateID)
+		}
+		// the conflicts of the candidate and the resources rule out every member
+		if err = s.checkSchedules(ctx, "", req.CandidateID, nil, req.ResourceIDs, req.InterviewType, req.StartsAt, req.EndsAt); err != nil {
+			return
+		}
+
+		if strategy == team.StrategyLeastLoaded {
+			if candidates, err = s.leastLoaded(ctx, candidates, req.StartsAt); err != nil {
+				return
+			}
+		}
+
+		recruiterID, err := s.firstAvailable(ctx, candidates, req.StartsAt, req.EndsAt)
+		if err != nil {
+			return
+		}
+		if recruiterID == "" {
+			return apperror.Conflict("no recruiter of team %s is available at that time", id)
+		}
+
+		res, err = s.ScheduleInterview(ctx, interview.Request{
+			CandidateID:   req.CandidateID,
+			RecruiterID:   recruiterID,
+			RecruiterIDs:  []string{recruiterID},
+			ResourceIDs:   req.ResourceIDs,
+			InterviewType: req.InterviewType,
+			Title:         req.Title,
+			Location:      req.Location,
+			StartsAt:      req.StartsAt,
+			EndsAt:        req.EndsAt,
+			Remote:        req.Remote,
+		})
+		if err != nil {
+			return
+		}
+		if err = s.teamRepository.Advance(ctx, id, recruiterID); err != nil {
+			return
+		}
+
+		booked, err := s.interviewRepository.Get(ctx, res.ID)
+		if err != nil {
+			return
+		}
+		return s.notify(ctx, eventRecruiterAssigned, booked, false, []string{recruiterID})
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to book", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// teamCandidates leaves out of the recruiters the deleted ones and the ones
+// not conducting the interview type, the order is kept
+func (s *Service) teamCandidates(ctx context.Context, recruiterIDs []string, interviewType string) (dest []string, err error) {
+	for _, recruiterID := range recruiterIDs {
+		data, err := s.recruiterRepository.Get(ctx, recruiterID)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if interviewType != "" && (data.InterviewTypes == nil || !data.InterviewTypes.Has(interviewType)) {
+			continue
+		}
+		dest = append(dest, recruiterID)
+	}
+
+	return
+}
+
+// leastLoaded orders the recruiters by the count of their scheduled
+// interviews in the week of at, the ties keep their order
+func (s *Service) leastLoaded(ctx context.Context, recruiterIDs []string, at time.Time) (dest []string, err error) {
+	loads := make(map[string]int, len(recruiterIDs))
+	for _, recruiterID := range recruiterIDs {
+		data, err := s.recruiterRepository.Get(ctx, recruiterID)
+		if err != nil {
+			return nil, participantError(err, entityRecruiter, recruiterID)
+		}
+		week := availability.Week(at, s.recruiterHours(data).Location)
+
+		interviews, err := s.interviewRepository.List(ctx, interview.Filter{
+			RecruiterID: recruiterID,
+			From:        week.StartsAt,
+			To:          week.EndsAt,
+			Status:      interview.StatusScheduled,
+		})
+		if err != nil {
+			return nil, err
+		}
+		loads[recruiterID] = len(interviews)
+	}
+
+	dest = append([]string{}, recruiterIDs...)
+	sort.SliceStable(dest, func(i, j int) bool {
+		return loads[dest[i]] < loads[dest[j]]
+	})
+
+	return
+}
+
+// firstAvailable returns the first of the recruiters having a free window
+// covering [from, to), it is empty when none of them has one
+func (s *Service) firstAvailable(ctx context.Context, recruiterIDs []string, from, to time.Time) (recruiterID string, err error) {
+	for _, id := range recruiterIDs {
+		free, err := s.freeWindows(ctx, []string{id}, from, to)
+		if err != nil {
+			return "", err
+		}
+		if withinWindows(free, from, to) {
+			return id, nil
+		}
+	}
+
+	return
+}
@@ -0,0 +1,166 @@
+package reservation
+
+import (
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/internal/domain/team"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/pkg/apperror"
+	"testing"
+	"time"
+)
+
+// team adds a team of the strategy with the recruiters as members in the
+// order they are given
+func (f fixture) team(t *testing.T, strategy string, recruiterIDs ...string) string {
+	t.Helper()
+
+	res, err := f.service.AddTeam(f.ctx, team.Request{Name: "Platform", Strategy: strategy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, recruiterID := range recruiterIDs {
+		if _, err = f.service.AddTeamMember(f.ctx, res.ID, recruiterID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.ID
+}
+
+func (f fixture) book(t *testing.T, teamID string, req team.BookingRequest) (string, error) {
+	t.Helper()
+
+	req.CandidateID = f.candidate(t)
+	req.Title = "Interview"
+	if req.EndsAt.IsZero() {
+		req.EndsAt = req.StartsAt.Add(time.Hour)
+	}
+
+	res, err := f.service.BookTeamInterview(f.ctx, teamID, req)
+	return res.RecruiterID, err
+}
+
+func newTeamFixture(t *testing.T) fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	for _, cfg := range []Configuration{
+		WithTeamRepository(memory.NewTeamRepository()),
+		WithTeamMemberRepository(memory.NewTeamMemberRepository()),
+	} {
+		if err := cfg(f.service); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return f
+}
+
+func TestBookTeamInterviewRoundRobin(t *testing.T) {
+	f := newTeamFixture(t)
+	rita, ralf, rosa := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+	teamID := f.team(t, team.StrategyRoundRobin, rita, ralf, rosa)
+
+	// Ralf is busy at 11:00, his turn passes to Rosa and he is next again
+	f.interview(t, ralf, monday(11, 0), monday(12, 0))
+
+	tests := []struct {
+		startsAt time.Time
+		want     string
+	}{
+		{startsAt: monday(9, 0), want: rita},
+		{startsAt: monday(10, 0), want: ralf},
+		{startsAt: monday(11, 0), want: rosa},
+		{startsAt: monday(13, 0), want: rita},
+		{startsAt: monday(14, 0), want: ralf},
+	}
+
+	for _, tt := range tests {
+		got, err := f.book(t, teamID, team.BookingRequest{StartsAt: tt.startsAt})
+		if err != nil {
+			t.Fatalf("BookTeamInterview() at %s error = %v", tt.startsAt.Format("15:04"), err)
+		}
+		if got != tt.want {
+			t.Errorf("BookTeamInterview() at %s = %s, want %s", tt.startsAt.Format("15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestBookTeamInterviewLeastLoaded(t *testing.T) {
+	f := newTeamFixture(t)
+	rita, ralf, rosa := f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{}), f.recruiter(t, recruiter.Entity{})
+	teamID := f.team(t, team.StrategyLeastLoaded, rita, ralf, rosa)
+
+	// Rita has two interviews this week, Ralf one and Rosa one last week
+	f.interview(t, rita, monday(9, 0), monday(10, 0))
+	f.interview(t, rita, monday(10, 0), monday(11, 0))
+	f.interview(t, ralf, monday(9, 0), monday(10, 0))
+	f.interview(t, rosa, monday(9, 0).AddDate(0, 0, -7), monday(10, 0).AddDate(0, 0, -7))
+
+	tests := []struct {
+		name     string
+		startsAt time.Time
+		want     string
+	}{
+		{name: "fewest interviews in the week", startsAt: monday(13, 0), want: rosa},
+		{name: "ties follow the turns", startsAt: monday(14, 0), want: ralf},
+		{name: "busy least loaded is passed over", startsAt: monday(13, 0), want: rita},
+		{name: "the only one free", startsAt: monday(13, 0), want: ralf},
+		{name: "everybody busy", startsAt: monday(13, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := f.book(t, teamID, team.BookingRequest{StartsAt: tt.startsAt})
+			if tt.want == "" {
+				if apperror.KindOf(err) != apperror.KindConflict {
+					t.Fatalf("BookTeamInterview() error = %v, want conflict", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BookTeamInterview() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BookTeamInterview() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookTeamInterviewMembers(t *testing.T) {
+	f := newTeamFixture(t)
+	technical := recruiter.Strings{"technical"}
+	rita := f.recruiter(t, recruiter.Entity{})
+	ralf := f.recruiter(t, recruiter.Entity{InterviewTypes: &technical})
+
+	tests := []struct {
+		name     string
+		members  []string
+		req      team.BookingRequest
+		want     string
+		wantKind apperror.Kind
+	}{
+		{name: "interview type", members: []string{rita, ralf}, req: team.BookingRequest{InterviewType: "technical"}, want: ralf},
+		{name: "nobody of the interview type", members: []string{rita}, req: team.BookingRequest{InterviewType: "technical"}, wantKind: apperror.KindUnprocessable},
+		{name: "no members", wantKind: apperror.KindUnprocessable},
+		{name: "strategy of the booking", members: []string{rita, ralf}, req: team.BookingRequest{Strategy: team.StrategyLeastLoaded}, want: rita},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.StartsAt = monday(9, 0).AddDate(0, 0, 7)
+			got, err := f.book(t, f.team(t, team.StrategyRoundRobin, tt.members...), tt.req)
+			if tt.wantKind != apperror.KindInternal {
+				if apperror.KindOf(err) != tt.wantKind {
+					t.Fatalf("BookTeamInterview() error = %v, want kind %v", err, tt.wantKind)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BookTeamInterview() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BookTeamInterview() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package reservation
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"reservation-system/internal/domain/candidate"
+	"reservation-system/internal/domain/interview"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/recruiter"
+	"reservation-system/pkg/apperror"
+	"testing"
+)
+
+const otherTenantID = "00000000-0000-0000-0000-000000000002"
+
+func TestTenantIsolation(t *testing.T) {
+	for name, repositories := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(
+				WithRecruiterRepository(repositories.Recruiter),
+				WithCandidateRepository(repositories.Candidate),
+				WithInterviewRepository(repositories.Interview),
+				WithUnitOfWork(repositories.UnitOfWork),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			owner := organization.ContextWithTenant(context.Background(), tenantID)
+			other := organization.ContextWithTenant(context.Background(), otherTenantID)
+			email := func() string { return fmt.Sprintf("%s@example.com", uuid.NewString()) }
+
+			addedCandidate, err := s.AddCandidate(owner, candidate.Request{FullName: "Carla", Email: email(), Phone: 4915112345678})
+			if err != nil {
+				t.Fatal(err)
+			}
+			addedRecruiter, err := s.AddRecruiter(owner, recruiter.Request{FullName: "Rita", Email: email(), Phone: 4915112345678})
+			if err != nil {
+				t.Fatal(err)
+			}
+			request := interview.Request{
+				CandidateID:  addedCandidate.ID,
+				RecruiterID:  addedRecruiter.ID,
+				RecruiterIDs: []string{addedRecruiter.ID},
+				Title:        "Interview",
+				StartsAt:     monday(9, 0),
+				EndsAt:       monday(10, 0),
+			}
+			scheduled, err := s.ScheduleInterview(owner, request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			notFound := func(operation string, err error) {
+				t.Helper()
+				if apperror.KindOf(err) != apperror.KindNotFound {
+					t.Errorf("%s error = %v, want not found", operation, err)
+				}
+			}
+
+			_, err = s.GetCandidate(other, addedCandidate.ID)
+			notFound("GetCandidate()", err)
+			_, err = s.UpdateCandidate(other, addedCandidate.ID, addedCandidate.Version, candidate.Request{FullName: "Carla", Email: email()})
+			notFound("UpdateCandidate()", err)
+			notFound("DeleteCandidate()", s.DeleteCandidate(other, addedCandidate.ID, addedCandidate.Version))
+			candidates, err := s.ListCandidates(other, candidate.Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range candidates {
+				if item.ID == addedCandidate.ID {
+					t.Errorf("ListCandidates() returns candidate %s of another organization", item.ID)
+				}
+			}
+
+			_, err = s.GetRecruiter(other, addedRecruiter.ID)
+			notFound("GetRecruiter()", err)
+			_, err = s.UpdateRecruiter(other, addedRecruiter.ID, addedRecruiter.Version, recruiter.Request{FullName: "Rita", Email: email()})
+			notFound("UpdateRecruiter()", err)
+			notFound("DeleteRecruiter()", s.DeleteRecruiter(other, addedRecruiter.ID, addedRecruiter.Version))
+			recruiters, err := s.ListRecruiters(other, recruiter.Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range recruiters {
+				if item.ID == addedRecruiter.ID {
+					t.Errorf("ListRecruiters() returns recruiter %s of another organization", item.ID)
+				}
+			}
+
+			_, err = s.GetInterview(other, scheduled.ID)
+			notFound("GetInterview()", err)
+			_, err = s.RescheduleInterview(other, scheduled.ID, scheduled.Version, request)
+			notFound("RescheduleInterview()", err)
+			_, err = s.CancelInterview(other, scheduled.ID, scheduled.Version)
+			notFound("CancelInterview()", err)
+			interviews, err := s.ListInterviews(other, interview.Filter{CandidateID: addedCandidate.ID})
+			if err != nil || len(interviews) != 0 {
+				t.Errorf("ListInterviews() = %v, %v, want none", interviews, err)
+			}
+
+			// nothing was changed for the organization owning the data
+			if res, err := s.GetCandidate(owner, addedCandidate.ID); err != nil || res.Version != addedCandidate.Version {
+				t.Errorf("GetCandidate() = %+v, %v, want version %d", res, err, addedCandidate.Version)
+			}
+			if res, err := s.GetRecruiter(owner, addedRecruiter.ID); err != nil || res.Version != addedRecruiter.Version {
+				t.Errorf("GetRecruiter() = %+v, %v, want version %d", res, err, addedRecruiter.Version)
+			}
+			if res, err := s.GetInterview(owner, scheduled.ID); err != nil || res.Version != scheduled.Version || res.Status != interview.StatusScheduled {
+				t.Errorf("GetInterview() = %+v, %v, want it scheduled at version %d", res, err, scheduled.Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package reservation
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/application"
+	"reservation-system/internal/domain/audit"
+	"reservation-system/internal/domain/vacancy"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+)
+
+func (s *Service) ListVacancies(ctx context.Context, filter vacancy.Filter) (res []vacancy.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListVacancies")
+
+	data, err := s.vacancyRepository.List(ctx, filter)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = vacancy.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddVacancy(ctx context.Context, req vacancy.Request) (res vacancy.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddVacancy")
+
+	stages := vacancy.Stages(req.Stages)
+	data := vacancy.Entity{
+		Title:      &req.Title,
+		Department: &req.Department,
+		OwnerID:    &req.OwnerID,
+		Status:     &req.Status,
+		Stages:     &stages,
+		Version:    1,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if _, err = s.recruiterRepository.Get(ctx, req.OwnerID); err != nil {
+			return participantError(err, entityRecruiter, req.OwnerID)
+		}
+
+		data.ID, err = s.vacancyRepository.Add(ctx, data)
+		if err != nil {
+			return
+		}
+		res = vacancy.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionCreate, entityVacancy, data.ID, nil, res)
+	})
+	if err != nil {
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to create", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+func (s *Service) GetVacancy(ctx context.Context, id string) (res vacancy.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetVacancy").With(zap.String("id", id))
+
+	data, err := s.vacancyRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, entityVacancy, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = vacancy.ParseFromEntity(data)
+
+	return
+}
+
+// UpdateVacancy changes the vacancy and its pipeline, a stage cannot be
+// removed from the pipeline while active applications are at it
+func (s *Service) UpdateVacancy(ctx context.Context, id string, version int, req vacancy.Request) (res vacancy.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateVacancy").With(zap.String("id", id))
+
+	stages := vacancy.Stages(req.Stages)
+	data := vacancy.Entity{
+		Title:      &req.Title,
+		Department: &req.Department,
+		OwnerID:    &req.OwnerID,
+		Status:     &req.Status,
+		Stages:     &stages,
+		Version:    version,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.vacancyRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+		if _, err = s.recruiterRepository.Get(ctx, req.OwnerID); err != nil {
+			return participantError(err, entityRecruiter, req.OwnerID)
+		}
+
+		active, err := s.applicationRepository.List(ctx, application.Filter{VacancyID: id, Status: application.StatusActive})
+		if err != nil {
+			return
+		}
+		for _, item := range active {
+			if stages.Index(*item.Stage) < 0 {
+				return apperror.Conflict("application %s is at stage %s of vacancy %s", item.ID, *item.Stage, id)
+			}
+		}
+
+		err = s.vacancyRepository.Update(ctx, id, data)
+		if err != nil {
+			return
+		}
+		data.ID = id
+		data.Version = current.Version + 1
+		res = vacancy.ParseFromEntity(data)
+
+		return s.record(ctx, audit.ActionUpdate, entityVacancy, id, vacancy.ParseFromEntity(current), res)
+	})
+	if err != nil {
+		err = repositoryError(err, entityVacancy, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// DeleteVacancy removes the vacancy, a vacancy having applications cannot be
+// deleted and is closed instead so that their history is kept
+func (s *Service) DeleteVacancy(ctx context.Context, id string, version int) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteVacancy").With(zap.String("id", id))
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.vacancyRepository.Get(ctx, id)
+		if err != nil {
+			return
+		}
+
+		applications, err := s.applicationRepository.List(ctx, application.Filter{VacancyID: id})
+		if err != nil {
+			return
+		}
+		if len(applications) > 0 {
+			return apperror.Conflict("vacancy %s has %d applications, close it instead", id, len(applications))
+		}
+
+		err = s.vacancyRepository.Delete(ctx, id, version)
+		if err != nil {
+			return
+		}
+
+		return s.record(ctx, audit.ActionDelete, entityVacancy, id, vacancy.ParseFromEntity(current), nil)
+	})
+	if err != nil {
+		err = repositoryError(err, entityVacancy, id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
@@ -0,0 +1,307 @@
+package scheduling
+
+import (
+	"reservation-system/internal/domain/availability"
+	"reservation-system/internal/domain/scheduling"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultBudget bounds the search steps of Solve
+const defaultBudget = 200_000
+
+const (
+	ReasonNoSkills    = "no recruiter has the required skills"
+	ReasonNoFreeTime  = "no common free time with a matching recruiter"
+	ReasonConflicting = "every free time is taken by other assignments"
+)
+
+// Problem is a batch of candidates to interview once each by one of the
+// recruiters, the free times are computed by the caller
+type Problem struct {
+	Candidates []Candidate
+	Recruiters []Recruiter
+	Duration   time.Duration
+	// Break is the least time between two interviews of a recruiter
+	Break time.Duration
+	// Step is the granularity of the start times tried from the start of every free window
+	Step time.Duration
+	// MaxPerDay caps the interviews of a recruiter on a day of Location, 0 is no cap
+	MaxPerDay int
+	Location  *time.Location
+	// Budget bounds the search steps, the best assignment found within it is returned
+	Budget int
+}
+
+type Candidate struct {
+	ID     string
+	Skills []string
+	// Free are the windows the candidate can be interviewed in
+	Free []availability.Window
+}
+
+type Recruiter struct {
+	ID     string
+	Skills []string
+	// Free are the windows the recruiter can interview in
+	Free []availability.Window
+	// Booked are the interviews the recruiter already has
+	Booked []availability.Window
+	// MaxPerDay and MaxPerWeek cap the interviews of the recruiter on a day
+	// and a week of Location, on top of the cap of the problem, 0 is no cap
+	MaxPerDay  int
+	MaxPerWeek int
+	// Location is the time zone of the days of the recruiter, the one of the problem when nil
+	Location *time.Location
+}
+
+// option is a possible interview of a candidate
+type option struct {
+	recruiter int
+	start     time.Time
+}
+
+type solver struct {
+	problem  Problem
+	order    []int
+	options  [][]option
+	planned  [][]availability.Window
+	perDay   []map[string]int
+	perWeek  []map[string]int
+	current  []int
+	best     []int
+	bestSize int
+	steps    int
+}
+
+// Solve assigns as many candidates as it can, deterministically: the
+// candidates with the fewest options are placed first, each at the earliest
+// start with the first recruiter in the given order, and the search
+// backtracks within the budget to place more of them
+func Solve(problem Problem) (assignments []scheduling.Assignment, unassigned []scheduling.Unassigned) {
+	if problem.Step <= 0 {
+		problem.Step = problem.Duration
+	}
+	if problem.Budget <= 0 {
+		problem.Budget = defaultBudget
+	}
+	if problem.Location == nil {
+		problem.Location = time.UTC
+	}
+
+	s := solver{
+		problem: problem,
+		options: make([][]option, len(problem.Candidates)),
+		planned: make([][]availability.Window, len(problem.Recruiters)),
+		perDay:  make([]map[string]int, len(problem.Recruiters)),
+		perWeek: make([]map[string]int, len(problem.Recruiters)),
+		current: make([]int, len(problem.Candidates)),
+		best:    make([]int, len(problem.Candidates)),
+	}
+
+	for r, recruiter := range problem.Recruiters {
+		s.perDay[r], s.perWeek[r] = make(map[string]int), make(map[string]int)
+		for _, booked := range recruiter.Booked {
+			s.perDay[r][s.day(r, booked.StartsAt)]++
+			s.perWeek[r][s.week(r, booked.StartsAt)]++
+		}
+	}
+
+	reasons := make([]string, len(problem.Candidates))
+	for c := range problem.Candidates {
+		s.options[c], reasons[c] = s.candidateOptions(c)
+		s.current[c], s.best[c] = -1, -1
+		if len(s.options[c]) > 0 {
+			s.order = append(s.order, c)
+		}
+	}
+	sort.SliceStable(s.order, func(i, j int) bool {
+		return len(s.options[s.order[i]]) < len(s.options[s.order[j]])
+	})
+
+	s.search(0, 0)
+
+	for c, candidate := range problem.Candidates {
+		if s.best[c] < 0 {
+			reason := reasons[c]
+			if reason == "" {
+				reason = ReasonConflicting
+			}
+			unassigned = append(unassigned, scheduling.Unassigned{CandidateID: candidate.ID, Reason: reason})
+			continue
+		}
+
+		choice := s.options[c][s.best[c]]
+		assignments = append(assignments, scheduling.Assignment{
+			CandidateID: candidate.ID,
+			RecruiterID: problem.Recruiters[choice.recruiter].ID,
+			StartsAt:    choice.start,
+			EndsAt:      choice.start.Add(problem.Duration),
+		})
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		if !assignments[i].StartsAt.Equal(assignments[j].StartsAt) {
+			return assignments[i].StartsAt.Before(assignments[j].StartsAt)
+		}
+		return assignments[i].RecruiterID < assignments[j].RecruiterID
+	})
+
+	return
+}
+
+// search places the candidates from the i-th in order on, size of them are placed already
+func (s *solver) search(i, size int) {
+	s.steps++
+
+	if size > s.bestSize {
+		s.bestSize = size
+		copy(s.best, s.current)
+	}
+	if i == len(s.order) || s.steps > s.problem.Budget {
+		return
+	}
+	// even placing all the remaining candidates would not beat the best assignment
+	if size+len(s.order)-i <= s.bestSize {
+		return
+	}
+
+	c := s.order[i]
+	for k, choice := range s.options[c] {
+		if s.steps > s.problem.Budget {
+			return
+		}
+		if !s.fits(choice) {
+			continue
+		}
+
+		s.place(choice, 1)
+		s.current[c] = k
+		s.search(i+1, size+1)
+		s.current[c] = -1
+		s.place(choice, -1)
+
+		if s.bestSize == len(s.order) {
+			return
+		}
+	}
+
+	// leave the candidate out
+	s.search(i+1, size)
+}
+
+// candidateOptions returns the starts at which a recruiter having the skills
+// and the candidate are both free, by start and then by recruiter
+func (s *solver) candidateOptions(c int) (dest []option, reason string) {
+	candidate := s.problem.Candidates[c]
+
+	matching := false
+	for r, recruiter := range s.problem.Recruiters {
+		if !hasSkills(recruiter.Skills, candidate.Skills) {
+			continue
+		}
+		matching = true
+
+		for _, window := range availability.Intersect(recruiter.Free, candidate.Free) {
+			for start := window.StartsAt; !start.Add(s.problem.Duration).After(window.EndsAt); start = start.Add(s.problem.Step) {
+				if s.rested(recruiter.Booked, start) {
+					dest = append(dest, option{recruiter: r, start: start})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(dest, func(i, j int) bool {
+		if !dest[i].start.Equal(dest[j].start) {
+			return dest[i].start.Before(dest[j].start)
+		}
+		return dest[i].recruiter < dest[j].recruiter
+	})
+
+	switch {
+	case !matching:
+		reason = ReasonNoSkills
+	case len(dest) == 0:
+		reason = ReasonNoFreeTime
+	}
+
+	return
+}
+
+// fits reports whether the recruiter has the break around the option and is
+// below the caps with the other assignments of the search
+func (s *solver) fits(choice option) bool {
+	r := choice.recruiter
+	if !s.rested(s.planned[r], choice.start) {
+		return false
+	}
+	if limit := lowest(s.problem.MaxPerDay, s.problem.Recruiters[r].MaxPerDay); limit > 0 && s.perDay[r][s.day(r, choice.start)] >= limit {
+		return false
+	}
+	if limit := s.problem.Recruiters[r].MaxPerWeek; limit > 0 && s.perWeek[r][s.week(r, choice.start)] >= limit {
+		return false
+	}
+	return true
+}
+
+func (s *solver) place(choice option, delta int) {
+	r := choice.recruiter
+	if delta > 0 {
+		s.planned[r] = append(s.planned[r], availability.Window{StartsAt: choice.start, EndsAt: choice.start.Add(s.problem.Duration)})
+	} else {
+		s.planned[r] = s.planned[r][:len(s.planned[r])-1]
+	}
+	s.perDay[r][s.day(r, choice.start)] += delta
+	s.perWeek[r][s.week(r, choice.start)] += delta
+}
+
+// rested reports whether an interview at start keeps the break to the interviews
+func (s *solver) rested(interviews []availability.Window, start time.Time) bool {
+	from := start.Add(-s.problem.Break)
+	to := start.Add(s.problem.Duration + s.problem.Break)
+	for _, other := range interviews {
+		if other.StartsAt.Before(to) && other.EndsAt.After(from) {
+			return false
+		}
+	}
+	return true
+}
+
+// day returns the date of t for the r-th recruiter
+func (s *solver) day(r int, t time.Time) string {
+	return availability.Day(t, s.location(r)).StartsAt.Format("2006-01-02")
+}
+
+// week returns the date of the Monday of the week of t for the r-th recruiter
+func (s *solver) week(r int, t time.Time) string {
+	return availability.Week(t, s.location(r)).StartsAt.Format("2006-01-02")
+}
+
+func (s *solver) location(r int) *time.Location {
+	if loc := s.problem.Recruiters[r].Location; loc != nil {
+		return loc
+	}
+	return s.problem.Location
+}
+
+// lowest returns the lowest of the caps, 0 being no cap
+func lowest(a, b int) int {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// hasSkills reports whether the skills cover the required ones, ignoring case
+func hasSkills(skills, required []string) bool {
+	for _, want := range required {
+		found := false
+		for _, skill := range skills {
+			found = found || strings.EqualFold(strings.TrimSpace(skill), strings.TrimSpace(want))
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+package scheduling
+
+import (
+	"fmt"
+	"reservation-system/internal/domain/availability"
+	"testing"
+	"time"
+)
+
+// at returns 5 January 2026, a Monday, at the hour in UTC
+func at(hour int) time.Time {
+	return time.Date(2026, 1, 5, hour, 0, 0, 0, time.UTC)
+}
+
+func free(start, end int) []availability.Window {
+	return []availability.Window{{StartsAt: at(start), EndsAt: at(end)}}
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		problem    Problem
+		assigned   []string
+		unassigned []string
+	}{
+		{
+			name: "one after the other",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}, {ID: "b", Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 11)}},
+				Duration:   time.Hour,
+			},
+			assigned: []string{"a r 09:00", "b r 10:00"},
+		},
+		{
+			name: "the candidate with the fewest options goes first",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 11)}, {ID: "b", Free: free(9, 10)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 11)}},
+				Duration:   time.Hour,
+			},
+			assigned: []string{"b r 09:00", "a r 10:00"},
+		},
+		{
+			name: "backtracks to place everyone",
+			problem: Problem{
+				Candidates: []Candidate{
+					{ID: "a", Free: free(9, 11)},
+					{ID: "b", Free: free(9, 11)},
+					{ID: "c", Free: free(10, 11)},
+				},
+				Recruiters: []Recruiter{{ID: "r1", Free: free(9, 11)}, {ID: "r2", Free: free(9, 10)}},
+				Duration:   time.Hour,
+			},
+			assigned: []string{"a r1 09:00", "b r2 09:00", "c r1 10:00"},
+		},
+		{
+			name: "first recruiter on ties",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r2", Free: free(9, 17)}, {ID: "r1", Free: free(9, 17)}},
+				Duration:   time.Hour,
+			},
+			assigned: []string{"a r2 09:00"},
+		},
+		{
+			name: "skills are required",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Skills: []string{" Go "}, Free: free(9, 17)}, {ID: "b", Skills: []string{"rust"}, Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r1", Free: free(9, 17)}, {ID: "r2", Skills: []string{"go", "sql"}, Free: free(9, 17)}},
+				Duration:   time.Hour,
+			},
+			assigned:   []string{"a r2 09:00"},
+			unassigned: []string{"b " + ReasonNoSkills},
+		},
+		{
+			name: "no common free time",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(14, 17)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 12)}},
+				Duration:   time.Hour,
+			},
+			unassigned: []string{"a " + ReasonNoFreeTime},
+		},
+		{
+			name: "too short a window",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 10)}},
+				Duration:   2 * time.Hour,
+			},
+			unassigned: []string{"a " + ReasonNoFreeTime},
+		},
+		{
+			name: "break between interviews",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}, {ID: "b", Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 12)}},
+				Duration:   time.Hour,
+				Break:      30 * time.Minute,
+				Step:       30 * time.Minute,
+			},
+			assigned: []string{"a r 09:00", "b r 10:30"},
+		},
+		{
+			name: "break around booked interviews",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 12), Booked: free(9, 10)}},
+				Duration:   time.Hour,
+				Break:      30 * time.Minute,
+				Step:       30 * time.Minute,
+			},
+			assigned: []string{"a r 10:30"},
+		},
+		{
+			name: "daily cap of the problem",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}, {ID: "b", Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 17)}},
+				Duration:   time.Hour,
+				MaxPerDay:  1,
+			},
+			assigned:   []string{"a r 09:00"},
+			unassigned: []string{"b " + ReasonConflicting},
+		},
+		{
+			name: "daily cap of the recruiter counts booked interviews",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}, {ID: "b", Free: free(9, 17)}},
+				Recruiters: []Recruiter{
+					{ID: "r1", Free: free(9, 17), Booked: free(16, 17), MaxPerDay: 2},
+					{ID: "r2", Free: free(13, 14)},
+				},
+				Duration: time.Hour,
+			},
+			assigned: []string{"a r1 09:00", "b r2 13:00"},
+		},
+		{
+			name: "weekly cap of the recruiter",
+			problem: Problem{
+				Candidates: []Candidate{{ID: "a", Free: free(9, 17)}, {ID: "b", Free: free(9, 17)}},
+				Recruiters: []Recruiter{{ID: "r", Free: free(9, 17), MaxPerWeek: 1}},
+				Duration:   time.Hour,
+			},
+			assigned:   []string{"a r 09:00"},
+			unassigned: []string{"b " + ReasonConflicting},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignments, unassigned := Solve(tt.problem)
+
+			var gotAssigned, gotUnassigned []string
+			for _, a := range assignments {
+				if a.EndsAt.Sub(a.StartsAt) != tt.problem.Duration {
+					t.Errorf("assignment %+v does not last %v", a, tt.problem.Duration)
+				}
+				gotAssigned = append(gotAssigned, fmt.Sprintf("%s %s %s", a.CandidateID, a.RecruiterID, a.StartsAt.Format("15:04")))
+			}
+			for _, u := range unassigned {
+				gotUnassigned = append(gotUnassigned, u.CandidateID+" "+u.Reason)
+			}
+
+			if fmt.Sprint(gotAssigned) != fmt.Sprint(tt.assigned) {
+				t.Errorf("assignments = %q, want %q", gotAssigned, tt.assigned)
+			}
+			if fmt.Sprint(gotUnassigned) != fmt.Sprint(tt.unassigned) {
+				t.Errorf("unassigned = %q, want %q", gotUnassigned, tt.unassigned)
+			}
+		})
+	}
+}
+
+func TestSolveBudget(t *testing.T) {
+	// more candidates than slots make the search exhaust any budget
+	var candidates []Candidate
+	for i := 0; i < 12; i++ {
+		candidates = append(candidates, Candidate{ID: fmt.Sprint(i), Free: free(9, 17)})
+	}
+	problem := Problem{
+		Candidates: candidates,
+		Recruiters: []Recruiter{{ID: "r", Free: free(9, 17)}},
+		Duration:   time.Hour,
+		Budget:     1000,
+	}
+
+	assignments, unassigned := Solve(problem)
+	if len(assignments) != 8 || len(unassigned) != 4 {
+		t.Fatalf("Solve() placed %d and left out %d, want 8 and 4", len(assignments), len(unassigned))
+	}
+
+	again, _ := Solve(problem)
+	if fmt.Sprint(again) != fmt.Sprint(assignments) {
+		t.Errorf("Solve() is not deterministic: %v then %v", assignments, again)
+	}
+}
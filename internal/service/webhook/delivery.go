@@ -0,0 +1,254 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	domainEvent "reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/webhook"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// Sign returns the signature of the body sent at timestamp, receivers verify
+// it by computing the HMAC-SHA256 of "timestamp.body" with the shared secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Service) ListDeliveries(ctx context.Context, subscriptionID string) (res []webhook.DeliveryResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListDeliveries").With(zap.String("subscription_id", subscriptionID))
+
+	if _, err = s.GetSubscription(ctx, subscriptionID); err != nil {
+		return
+	}
+
+	data, err := s.deliveryRepository.List(ctx, subscriptionID)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = webhook.ParseFromDeliveries(data)
+
+	return
+}
+
+// RetryDelivery puts a delivery back to the queue with a fresh attempt budget,
+// typically one that went to the dead state
+func (s *Service) RetryDelivery(ctx context.Context, subscriptionID, id string) (res webhook.DeliveryResponse, err error) {
+	logger := log.LoggerFromContext(ctx).Named("RetryDelivery").With(zap.String("id", id))
+
+	if _, err = s.GetSubscription(ctx, subscriptionID); err != nil {
+		return
+	}
+
+	data, err := s.deliveryRepository.Get(ctx, id)
+	if err == nil && data.SubscriptionID != subscriptionID {
+		err = apperror.NotFound("delivery %s not found", id)
+		return
+	}
+	if err != nil {
+		err = repositoryError(err, "delivery", id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	if data.Status == webhook.StatusDelivered {
+		err = apperror.Conflict("delivery %s is already delivered", id)
+		return
+	}
+
+	data.Status = webhook.StatusPending
+	data.Attempts = 0
+	data.NextAttemptAt = time.Now().UTC()
+
+	if err = s.deliveryRepository.Update(ctx, id, data); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+		return
+	}
+	res = webhook.ParseFromDelivery(data)
+
+	return
+}
+
+// Start runs the dispatcher sending the queued deliveries until ctx is done
+func (s *Service) Start(ctx context.Context) {
+	go s.dispatch(ctx)
+}
+
+// Send queues a delivery for every active subscription matching the event, it
+// is an outbox.Sink so that the deliveries are queued before the event is
+// marked published and a failure leaves the event to be relayed again
+func (s *Service) Send(ctx context.Context, data domainEvent.Event) (err error) {
+	// only the subscriptions of the organization the event happened in are notified
+	if data.TenantID == "" {
+		return nil
+	}
+	ctx = organization.ContextWithTenant(ctx, data.TenantID)
+
+	subscriptions, err := s.subscriptionRepository.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to select subscriptions: %w", err)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %s: %w", data.Type, err)
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.Match(data.Type) {
+			continue
+		}
+
+		delivery := webhook.Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        strconv.FormatUint(data.ID, 10),
+			EventType:      data.Type,
+			Payload:        payload,
+			Status:         webhook.StatusPending,
+			NextAttemptAt:  time.Now().UTC(),
+			CreatedAt:      time.Now().UTC(),
+		}
+		if _, err = s.deliveryRepository.Add(ctx, delivery); err != nil {
+			return fmt.Errorf("failed to create delivery for subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	return
+}
+
+func (s *Service) dispatch(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Dispatch(ctx)
+		}
+	}
+}
+
+// Dispatch sends the deliveries that are due, the claim lease outlives the
+// request timeout so that a delivery is not sent twice concurrently
+func (s *Service) Dispatch(ctx context.Context) {
+	logger := log.LoggerFromContext(ctx).Named("DispatchWebhooks")
+
+	// the deliveries of every organization are claimed
+	ctx = organization.ContextWithSystem(ctx)
+
+	deliveries, err := s.deliveryRepository.Claim(ctx, time.Now().UTC(), 2*s.client.Timeout+time.Minute, defaultBatchSize)
+	if err != nil {
+		logger.Error("failed to claim deliveries", zap.Error(err))
+		return
+	}
+
+	for _, delivery := range deliveries {
+		s.deliver(ctx, delivery)
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, data webhook.Delivery) {
+	logger := log.LoggerFromContext(ctx).Named("DeliverWebhook").With(zap.String("id", data.ID))
+
+	subscription, err := s.subscriptionRepository.Get(ctx, data.SubscriptionID)
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		logger.Error("failed to get subscription", zap.Error(err))
+		return
+	}
+
+	if err == nil {
+		data.Attempts++
+		data.ResponseCode, err = s.send(ctx, subscription, data)
+	}
+
+	switch {
+	case errors.Is(err, store.ErrorNotFound):
+		data.Status = webhook.StatusDead
+		data.LastError = "subscription was deleted"
+	case err == nil:
+		now := time.Now().UTC()
+		data.Status = webhook.StatusDelivered
+		data.DeliveredAt = &now
+		data.LastError = ""
+	case data.Attempts >= s.maxAttempts:
+		data.Status = webhook.StatusDead
+		data.LastError = err.Error()
+		logger.Warn("delivery is dead", zap.Int("attempts", data.Attempts), zap.Error(err))
+	default:
+		data.LastError = err.Error()
+		data.NextAttemptAt = time.Now().UTC().Add(s.delay(data.Attempts))
+	}
+
+	if err = s.deliveryRepository.Update(ctx, data.ID, data); err != nil {
+		logger.Error("failed to update by id", zap.Error(err))
+	}
+}
+
+// delay doubles the backoff with every failed attempt up to a day
+func (s *Service) delay(attempts int) time.Duration {
+	delay := s.backoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+func (s *Service) send(ctx context.Context, subscription webhook.Subscription, data webhook.Delivery) (code int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *subscription.URL, bytes.NewReader(data.Payload))
+	if err != nil {
+		return
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(*subscription.Secret, timestamp, data.Payload))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(EventHeader, data.EventType)
+	req.Header.Set(DeliveryHeader, data.ID)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	code = res.StatusCode
+	if code < 200 || code > 299 {
+		err = fmt.Errorf("unexpected status code %d", code)
+	}
+
+	return
+}
@@ -0,0 +1,263 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	domainEvent "reservation-system/internal/domain/event"
+	"reservation-system/internal/domain/organization"
+	"reservation-system/internal/domain/webhook"
+	"reservation-system/internal/repository/memory"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/egress"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const tenantID = "00000000-0000-0000-0000-000000000001"
+
+// receiver records the requests of the deliveries and answers them with the
+// status codes in order, the last one is repeated
+type receiver struct {
+	codes []int
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.requests = append(rc.requests, r)
+	rc.bodies = append(rc.bodies, body)
+
+	code := rc.codes[len(rc.codes)-1]
+	if n := len(rc.requests); n <= len(rc.codes) {
+		code = rc.codes[n-1]
+	}
+	w.WriteHeader(code)
+}
+
+// newService returns a service allowed to deliver to the test server with a
+// backoff short enough for the failed deliveries to be due right away
+func newService(t *testing.T, server *httptest.Server, maxAttempts int) *Service {
+	t.Helper()
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := egress.NewPolicy(target.Hostname())
+
+	s, err := New(
+		WithSubscriptionRepository(memory.NewWebhookSubscriptionRepository()),
+		WithDeliveryRepository(memory.NewWebhookDeliveryRepository()),
+		WithPolicy(policy),
+		WithHTTPClient(policy.Client(time.Second)),
+		WithRetries(maxAttempts, time.Nanosecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func subscribe(t *testing.T, s *Service, target string, eventTypes ...string) webhook.Response {
+	t.Helper()
+
+	ctx := organization.ContextWithTenant(context.Background(), tenantID)
+	res, err := s.AddSubscription(ctx, webhook.Request{URL: target, EventTypes: eventTypes, Secret: "secret"})
+	if err != nil {
+		t.Fatalf("AddSubscription() error = %v", err)
+	}
+	return res
+}
+
+func deliveries(t *testing.T, s *Service, subscriptionID string) []webhook.DeliveryResponse {
+	t.Helper()
+
+	ctx := organization.ContextWithTenant(context.Background(), tenantID)
+	res, err := s.ListDeliveries(ctx, subscriptionID)
+	if err != nil {
+		t.Fatalf("ListDeliveries() error = %v", err)
+	}
+	return res
+}
+
+func TestDeliverySignature(t *testing.T) {
+	rc := &receiver{codes: []int{http.StatusNoContent}}
+	server := httptest.NewServer(rc)
+	defer server.Close()
+
+	s := newService(t, server, 3)
+	subscription := subscribe(t, s, server.URL+"/hooks", "candidate.create")
+
+	ctx := context.Background()
+	for _, data := range []domainEvent.Event{
+		{ID: 1, TenantID: tenantID, Type: "candidate.create", EntityType: "candidate", EntityID: "c1", Action: "create"},
+		{ID: 2, TenantID: tenantID, Type: "candidate.delete", EntityType: "candidate", EntityID: "c1", Action: "delete"},
+		{ID: 3, TenantID: "00000000-0000-0000-0000-000000000002", Type: "candidate.create"},
+		{ID: 4, Type: "candidate.create"},
+	} {
+		if err := s.Send(ctx, data); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	s.Dispatch(ctx)
+
+	if len(rc.requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(rc.requests))
+	}
+	req, body := rc.requests[0], rc.bodies[0]
+
+	if req.URL.Path != "/hooks" || req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("request = %s %s, want a JSON POST to /hooks", req.Method, req.URL)
+	}
+	if got := req.Header.Get(EventHeader); got != "candidate.create" {
+		t.Errorf("%s = %q, want candidate.create", EventHeader, got)
+	}
+
+	timestamp, err := strconv.ParseInt(req.Header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("%s is not a unix time: %v", TimestampHeader, err)
+	}
+	if got, want := req.Header.Get(SignatureHeader), Sign("secret", timestamp, body); got != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+
+	var payload domainEvent.Event
+	if err = json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("body is not an event: %v", err)
+	}
+	if payload.ID != 1 || payload.EntityID != "c1" {
+		t.Errorf("payload = %+v, want the event 1 of c1", payload)
+	}
+
+	res := deliveries(t, s, subscription.ID)
+	if len(res) != 1 || res[0].Status != webhook.StatusDelivered || res[0].Attempts != 1 || res[0].EventID != "1" {
+		t.Errorf("deliveries = %+v, want one delivered in one attempt", res)
+	}
+	if got := req.Header.Get(DeliveryHeader); len(res) == 1 && got != res[0].ID {
+		t.Errorf("%s = %q, want %q", DeliveryHeader, got, res[0].ID)
+	}
+}
+
+func TestDeliveryRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		codes        []int
+		maxAttempts  int
+		wantStatus   string
+		wantAttempts int
+		wantCode     int
+	}{
+		{
+			name:         "delivered after failures",
+			codes:        []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			maxAttempts:  5,
+			wantStatus:   webhook.StatusDelivered,
+			wantAttempts: 3,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "dead once the attempts are used up",
+			codes:        []int{http.StatusInternalServerError},
+			maxAttempts:  3,
+			wantStatus:   webhook.StatusDead,
+			wantAttempts: 3,
+			wantCode:     http.StatusInternalServerError,
+		},
+		{
+			name:         "redirects are failures",
+			codes:        []int{http.StatusNotModified},
+			maxAttempts:  2,
+			wantStatus:   webhook.StatusDead,
+			wantAttempts: 2,
+			wantCode:     http.StatusNotModified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := &receiver{codes: tt.codes}
+			server := httptest.NewServer(rc)
+			defer server.Close()
+
+			s := newService(t, server, tt.maxAttempts)
+			subscription := subscribe(t, s, server.URL)
+
+			ctx := context.Background()
+			if err := s.Send(ctx, domainEvent.Event{ID: 1, TenantID: tenantID, Type: "candidate.create"}); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			// dispatch more often than needed, a settled delivery is not sent again
+			for i := 0; i < tt.maxAttempts+2; i++ {
+				time.Sleep(time.Millisecond)
+				s.Dispatch(ctx)
+			}
+
+			if len(rc.requests) != tt.wantAttempts {
+				t.Errorf("receiver got %d requests, want %d", len(rc.requests), tt.wantAttempts)
+			}
+			res := deliveries(t, s, subscription.ID)
+			if len(res) != 1 {
+				t.Fatalf("got %d deliveries, want 1", len(res))
+			}
+			if res[0].Status != tt.wantStatus || res[0].Attempts != tt.wantAttempts || res[0].ResponseCode != tt.wantCode {
+				t.Errorf("delivery = %+v, want %s after %d attempts with %d", res[0], tt.wantStatus, tt.wantAttempts, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestDeliveryDelay(t *testing.T) {
+	s := &Service{backoff: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 4, want: 80 * time.Second},
+		{attempts: 30, want: maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := s.delay(tt.attempts); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSubscriptionURLPolicy(t *testing.T) {
+	s, err := New(WithSubscriptionRepository(memory.NewWebhookSubscriptionRepository()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := organization.ContextWithTenant(context.Background(), tenantID)
+
+	for _, target := range []string{
+		"http://127.0.0.1/hooks",
+		"http://[::1]:8080/hooks",
+		"http://10.0.0.1/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"ftp://example.com/hooks",
+	} {
+		t.Run(target, func(t *testing.T) {
+			_, err := s.AddSubscription(ctx, webhook.Request{URL: target})
+			if apperror.KindOf(err) != apperror.KindValidation {
+				t.Errorf("AddSubscription() error = %v, want a validation error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"net/http"
+	"reservation-system/internal/domain/webhook"
+	"reservation-system/pkg/egress"
+	"time"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultMaxAttempts  = 8
+	defaultBackoff      = 10 * time.Second
+	defaultTimeout      = 10 * time.Second
+	defaultBatchSize    = 50
+
+	maxBackoff = 24 * time.Hour
+)
+
+type Configuration func(s *Service) error
+
+// Service is an implementation of the Service
+type Service struct {
+	subscriptionRepository webhook.SubscriptionRepository
+	deliveryRepository     webhook.DeliveryRepository
+	policy                 *egress.Policy
+	client                 *http.Client
+
+	pollInterval time.Duration
+	maxAttempts  int
+	backoff      time.Duration
+}
+
+// New takes a variable amount of Configuration functions and returns a new Service
+// Each Configuration will be called in the order they are passed in
+func New(configs ...Configuration) (s *Service, err error) {
+	// Add the service
+	policy := egress.NewPolicy()
+	s = &Service{
+		policy:       policy,
+		client:       policy.Client(defaultTimeout),
+		pollInterval: defaultPollInterval,
+		maxAttempts:  defaultMaxAttempts,
+		backoff:      defaultBackoff,
+	}
+
+	// Apply all Configurations passed in
+	for _, cfg := range configs {
+		// Pass the service into the configuration function
+		if err = cfg(s); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func WithSubscriptionRepository(subscriptionRepository webhook.SubscriptionRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.subscriptionRepository = subscriptionRepository
+		return nil
+	}
+}
+
+func WithDeliveryRepository(deliveryRepository webhook.DeliveryRepository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.deliveryRepository = deliveryRepository
+		return nil
+	}
+}
+
+// WithPolicy sets the addresses the subscriptions may point to, the client
+// given to WithHTTPClient should enforce the same policy
+func WithPolicy(policy *egress.Policy) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if policy != nil {
+			s.policy = policy
+		}
+		return nil
+	}
+}
+
+func WithHTTPClient(client *http.Client) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if client != nil {
+			s.client = client
+		}
+		return nil
+	}
+}
+
+func WithRetries(maxAttempts int, backoff time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if maxAttempts > 0 {
+			s.maxAttempts = maxAttempts
+		}
+		if backoff > 0 {
+			s.backoff = backoff
+		}
+		return nil
+	}
+}
+
+func WithPollInterval(pollInterval time.Duration) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		if pollInterval > 0 {
+			s.pollInterval = pollInterval
+		}
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"go.uber.org/zap"
+	"reservation-system/internal/domain/webhook"
+	"reservation-system/pkg/apperror"
+	"reservation-system/pkg/log"
+	"reservation-system/pkg/store"
+	"time"
+)
+
+func (s *Service) ListSubscriptions(ctx context.Context) (res []webhook.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("ListSubscriptions")
+
+	data, err := s.subscriptionRepository.List(ctx)
+	if err != nil {
+		logger.Error("failed to select", zap.Error(err))
+		return
+	}
+	res = webhook.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddSubscription(ctx context.Context, req webhook.Request) (res webhook.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("AddSubscription")
+
+	if err = s.checkURL(ctx, req.URL); err != nil {
+		return
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			logger.Error("failed to generate secret", zap.Error(err))
+			return
+		}
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	data := webhook.Subscription{
+		URL:        &req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     &secret,
+		Active:     &active,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	data.ID, err = s.subscriptionRepository.Add(ctx, data)
+	if err != nil {
+		logger.Error("failed to create", zap.Error(err))
+		return
+	}
+	res = webhook.ParseFromEntity(data)
+	res.Secret = secret
+
+	return
+}
+
+func (s *Service) GetSubscription(ctx context.Context, id string) (res webhook.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("GetSubscription").With(zap.String("id", id))
+
+	data, err := s.subscriptionRepository.Get(ctx, id)
+	if err != nil {
+		err = repositoryError(err, "webhook", id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to get by id", zap.Error(err))
+		}
+		return
+	}
+	res = webhook.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) UpdateSubscription(ctx context.Context, id string, req webhook.Request) (res webhook.Response, err error) {
+	logger := log.LoggerFromContext(ctx).Named("UpdateSubscription").With(zap.String("id", id))
+
+	if err = s.checkURL(ctx, req.URL); err != nil {
+		return
+	}
+
+	data := webhook.Subscription{
+		URL:        &req.URL,
+		EventTypes: req.EventTypes,
+		Active:     req.Active,
+	}
+	if data.EventTypes == nil {
+		data.EventTypes = webhook.EventTypes{}
+	}
+	if req.Secret != "" {
+		data.Secret = &req.Secret
+	}
+
+	err = s.subscriptionRepository.Update(ctx, id, data)
+	if err != nil {
+		err = repositoryError(err, "webhook", id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to update by id", zap.Error(err))
+		}
+		return
+	}
+
+	return s.GetSubscription(ctx, id)
+}
+
+func (s *Service) DeleteSubscription(ctx context.Context, id string) (err error) {
+	logger := log.LoggerFromContext(ctx).Named("DeleteSubscription").With(zap.String("id", id))
+
+	err = s.subscriptionRepository.Delete(ctx, id)
+	if err != nil {
+		err = repositoryError(err, "webhook", id)
+		if apperror.KindOf(err) == apperror.KindInternal {
+			logger.Error("failed to delete by id", zap.Error(err))
+		}
+		return
+	}
+
+	return
+}
+
+// generateSecret returns a random signing secret for subscriptions created without one
+func generateSecret() (string, error) {
+	src := make([]byte, 32)
+	if _, err := rand.Read(src); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(src), nil
+}
+
+// repositoryError maps the errors of the repositories to the typed errors
+// of the service, errors it does not know about stay internal
+func repositoryError(err error, entity, id string) error {
+	if errors.Is(err, store.ErrorNotFound) {
+		return apperror.Wrap(apperror.KindNotFound, err, "%s %s not found", entity, id)
+	}
+
+	return err
+}
+
+// checkURL turns away the urls the deliveries could not be sent to, those
+// of hosts resolving to loopback, link-local or private addresses
+func (s *Service) checkURL(ctx context.Context, url string) (err error) {
+	if err = s.policy.CheckURL(ctx, url); err != nil {
+		return apperror.Wrap(apperror.KindValidation, err, "url: %v", err)
+	}
+	return
+}
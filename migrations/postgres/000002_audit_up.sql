@@ -0,0 +1,20 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS audit_log (
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            actor VARCHAR NOT NULL,
+            action VARCHAR NOT NULL,
+            entity_type VARCHAR NOT NULL,
+            entity_id VARCHAR NOT NULL,
+            changes JSONB NOT NULL DEFAULT ''{}'',
+            request_id VARCHAR NOT NULL DEFAULT ''''
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity_type, entity_id)';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,18 @@
+DO $$
+    BEGIN
+        -- COLUMNS --
+        EXECUTE 'ALTER TABLE candidates ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ';
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ';
+
+        -- CONSTRAINTS --
+        -- purged candidates must not be blocked by the recruiters referencing them
+        EXECUTE 'ALTER TABLE recruiters ALTER COLUMN candidate_id DROP NOT NULL';
+        EXECUTE 'ALTER TABLE recruiters DROP CONSTRAINT IF EXISTS recruiters_candidate_id_fkey';
+        EXECUTE 'ALTER TABLE recruiters ADD CONSTRAINT recruiters_candidate_id_fkey
+            FOREIGN KEY (candidate_id) REFERENCES candidates (id) ON DELETE SET NULL';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS candidates_deleted_at_idx ON candidates (deleted_at) WHERE deleted_at IS NOT NULL';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS recruiters_deleted_at_idx ON recruiters (deleted_at) WHERE deleted_at IS NOT NULL';
+    END
+$$ LANGUAGE plpgsql;
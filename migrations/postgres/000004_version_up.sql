@@ -0,0 +1,7 @@
+DO $$
+    BEGIN
+        -- COLUMNS --
+        EXECUTE 'ALTER TABLE candidates ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1';
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,19 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS idempotency_keys (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            expires_at TIMESTAMPTZ NOT NULL,
+            key VARCHAR PRIMARY KEY,
+            fingerprint VARCHAR NOT NULL,
+            status_code INT NOT NULL DEFAULT 0,
+            content_type VARCHAR NOT NULL DEFAULT '''',
+            body BYTEA
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at)';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,36 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            url VARCHAR NOT NULL,
+            event_types JSONB NOT NULL DEFAULT ''[]'',
+            secret VARCHAR NOT NULL,
+            active BOOLEAN NOT NULL DEFAULT TRUE
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS webhook_deliveries (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            subscription_id UUID NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+            event_id VARCHAR NOT NULL,
+            event_type VARCHAR NOT NULL,
+            payload BYTEA NOT NULL,
+            status VARCHAR NOT NULL DEFAULT ''pending'',
+            attempts INT NOT NULL DEFAULT 0,
+            response_code INT NOT NULL DEFAULT 0,
+            last_error VARCHAR NOT NULL DEFAULT '''',
+            next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            delivered_at TIMESTAMPTZ
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_idx ON webhook_deliveries (subscription_id, created_at)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = ''pending''';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,17 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS outbox (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id BIGSERIAL PRIMARY KEY,
+            event_type VARCHAR NOT NULL,
+            payload JSONB NOT NULL,
+            published_at TIMESTAMPTZ
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (id) WHERE published_at IS NULL';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,26 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS notifications (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            channel VARCHAR NOT NULL,
+            event_type VARCHAR NOT NULL,
+            recipient VARCHAR NOT NULL,
+            locale VARCHAR NOT NULL,
+            subject VARCHAR NOT NULL DEFAULT '''',
+            body TEXT NOT NULL,
+            status VARCHAR NOT NULL DEFAULT ''pending'',
+            attempts INT NOT NULL DEFAULT 0,
+            last_error VARCHAR NOT NULL DEFAULT '''',
+            next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            sent_at TIMESTAMPTZ
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS notifications_recipient_idx ON notifications (recipient, created_at)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS notifications_pending_idx ON notifications (next_attempt_at) WHERE status = ''pending''';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,13 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS notification_opt_outs (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            channel VARCHAR NOT NULL,
+            recipient VARCHAR NOT NULL,
+            PRIMARY KEY (channel, recipient)
+        )
+    ';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,40 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS interviews (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            candidate_id UUID NOT NULL REFERENCES candidates (id) ON DELETE CASCADE,
+            recruiter_id UUID NOT NULL REFERENCES recruiters (id) ON DELETE CASCADE,
+            title VARCHAR NOT NULL DEFAULT '''',
+            location VARCHAR NOT NULL DEFAULT '''',
+            starts_at TIMESTAMPTZ NOT NULL,
+            ends_at TIMESTAMPTZ NOT NULL,
+            status VARCHAR NOT NULL DEFAULT ''scheduled'',
+            version INT NOT NULL DEFAULT 1,
+            CHECK (ends_at > starts_at)
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS interview_reminders (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            interview_id UUID NOT NULL REFERENCES interviews (id) ON DELETE CASCADE,
+            offset_seconds BIGINT NOT NULL,
+            starts_at TIMESTAMPTZ NOT NULL,
+            due_at TIMESTAMPTZ NOT NULL,
+            status VARCHAR NOT NULL DEFAULT ''pending'',
+            sent_at TIMESTAMPTZ,
+            UNIQUE (interview_id, offset_seconds, starts_at)
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS interviews_candidate_idx ON interviews (candidate_id, starts_at)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS interviews_recruiter_idx ON interviews (recruiter_id, starts_at)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS interview_reminders_due_idx ON interview_reminders (due_at) WHERE status = ''pending''';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,14 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS calendar_feed_tokens (
+            created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            owner_type VARCHAR NOT NULL,
+            owner_id UUID NOT NULL,
+            token_hash VARCHAR NOT NULL,
+            PRIMARY KEY (owner_type, owner_id)
+        )
+    ';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,28 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS busy_blocks (
+            created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            recruiter_id UUID NOT NULL REFERENCES recruiters (id) ON DELETE CASCADE,
+            source VARCHAR NOT NULL,
+            starts_at TIMESTAMPTZ NOT NULL,
+            ends_at TIMESTAMPTZ NOT NULL
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS busy_feeds (
+            created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            recruiter_id UUID PRIMARY KEY REFERENCES recruiters (id) ON DELETE CASCADE,
+            url VARCHAR NOT NULL,
+            synced_at TIMESTAMPTZ,
+            last_error VARCHAR NOT NULL DEFAULT ''''
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS busy_blocks_recruiter_idx ON busy_blocks (recruiter_id, starts_at)';
+    END
+$$ LANGUAGE plpgsql;
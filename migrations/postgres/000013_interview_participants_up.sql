@@ -0,0 +1,28 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS interview_participants (
+            interview_id UUID NOT NULL REFERENCES interviews (id) ON DELETE CASCADE,
+            participant_type VARCHAR NOT NULL,
+            participant_id UUID NOT NULL,
+            position INT NOT NULL DEFAULT 0,
+            status VARCHAR NOT NULL DEFAULT ''pending'',
+            responded_at TIMESTAMPTZ,
+            PRIMARY KEY (interview_id, participant_type, participant_id)
+        )
+    ';
+
+        -- DATA --
+        EXECUTE '
+        INSERT INTO interview_participants (interview_id, participant_type, participant_id, position)
+        SELECT id, ''candidate'', candidate_id, 0 FROM interviews
+        UNION ALL
+        SELECT id, ''recruiter'', recruiter_id, 1 FROM interviews
+        ON CONFLICT DO NOTHING
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS interview_participants_participant_idx ON interview_participants (participant_type, participant_id)';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,29 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS resources (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            name VARCHAR NOT NULL,
+            kind VARCHAR NOT NULL DEFAULT ''room'',
+            capacity INT NOT NULL DEFAULT 0,
+            location VARCHAR NOT NULL DEFAULT '''',
+            equipment JSONB NOT NULL DEFAULT ''[]'',
+            version INT NOT NULL DEFAULT 1
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS interview_resources (
+            interview_id UUID NOT NULL REFERENCES interviews (id) ON DELETE CASCADE,
+            resource_id UUID NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+            PRIMARY KEY (interview_id, resource_id)
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS interview_resources_resource_idx ON interview_resources (resource_id)';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,6 @@
+DO $$
+    BEGIN
+        -- COLUMNS --
+        EXECUTE 'ALTER TABLE interviews ADD COLUMN IF NOT EXISTS meeting_url VARCHAR NOT NULL DEFAULT ''''';
+    END
+$$ LANGUAGE plpgsql;
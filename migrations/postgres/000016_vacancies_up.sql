@@ -0,0 +1,52 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS vacancies (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            title VARCHAR NOT NULL,
+            department VARCHAR NOT NULL DEFAULT '''',
+            owner_id UUID REFERENCES recruiters (id) ON DELETE SET NULL,
+            status VARCHAR NOT NULL DEFAULT ''open'',
+            stages JSONB NOT NULL DEFAULT ''[]'',
+            version INT NOT NULL DEFAULT 1
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS applications (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            candidate_id UUID NOT NULL REFERENCES candidates (id) ON DELETE CASCADE,
+            vacancy_id UUID NOT NULL REFERENCES vacancies (id) ON DELETE CASCADE,
+            stage VARCHAR NOT NULL,
+            status VARCHAR NOT NULL DEFAULT ''active'',
+            applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            version INT NOT NULL DEFAULT 1
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS application_history (
+            position BIGSERIAL PRIMARY KEY,
+            application_id UUID NOT NULL REFERENCES applications (id) ON DELETE CASCADE,
+            from_stage VARCHAR NOT NULL DEFAULT '''',
+            stage VARCHAR NOT NULL,
+            status VARCHAR NOT NULL,
+            note VARCHAR NOT NULL DEFAULT '''',
+            actor VARCHAR NOT NULL DEFAULT '''',
+            changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS vacancies_owner_idx ON vacancies (owner_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS applications_vacancy_idx ON applications (vacancy_id, stage)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS applications_candidate_idx ON applications (candidate_id)';
+        EXECUTE 'CREATE UNIQUE INDEX IF NOT EXISTS applications_active_idx ON applications (candidate_id, vacancy_id) WHERE status = ''active''';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS application_history_application_idx ON application_history (application_id)';
+    END
+$$ LANGUAGE plpgsql;
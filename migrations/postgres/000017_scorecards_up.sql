@@ -0,0 +1,35 @@
+DO $$
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS scorecard_templates (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            name VARCHAR NOT NULL,
+            criteria JSONB NOT NULL DEFAULT ''[]'',
+            version INT NOT NULL DEFAULT 1
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS scorecards (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            interview_id UUID NOT NULL REFERENCES interviews (id) ON DELETE CASCADE,
+            recruiter_id UUID NOT NULL REFERENCES recruiters (id) ON DELETE CASCADE,
+            template_id UUID NOT NULL REFERENCES scorecard_templates (id),
+            ratings JSONB NOT NULL DEFAULT ''[]'',
+            recommendation VARCHAR NOT NULL,
+            comment VARCHAR NOT NULL DEFAULT '''',
+            submitted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            version INT NOT NULL DEFAULT 1,
+            UNIQUE (interview_id, recruiter_id)
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS scorecards_template_idx ON scorecards (template_id)';
+    END
+$$ LANGUAGE plpgsql;
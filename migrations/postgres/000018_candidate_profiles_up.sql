@@ -0,0 +1,37 @@
+DO $$
+    BEGIN
+        -- COLUMNS --
+        EXECUTE 'ALTER TABLE candidates ADD COLUMN IF NOT EXISTS skills JSONB NOT NULL DEFAULT ''[]''';
+        EXECUTE 'ALTER TABLE candidates ADD COLUMN IF NOT EXISTS experience_years INT NOT NULL DEFAULT 0';
+        EXECUTE 'ALTER TABLE candidates ADD COLUMN IF NOT EXISTS location VARCHAR NOT NULL DEFAULT ''''';
+        EXECUTE 'ALTER TABLE candidates ADD COLUMN IF NOT EXISTS links JSONB NOT NULL DEFAULT ''[]''';
+
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS candidate_notes (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            candidate_id UUID NOT NULL REFERENCES candidates (id) ON DELETE CASCADE,
+            author_id UUID NOT NULL REFERENCES recruiters (id) ON DELETE CASCADE,
+            text VARCHAR NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS candidate_attachments (
+            id UUID PRIMARY KEY,
+            candidate_id UUID NOT NULL REFERENCES candidates (id) ON DELETE CASCADE,
+            file_name VARCHAR NOT NULL,
+            content_type VARCHAR NOT NULL,
+            size BIGINT NOT NULL,
+            checksum VARCHAR NOT NULL,
+            uploaded_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS candidates_skills_idx ON candidates USING GIN (skills)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS candidate_notes_candidate_idx ON candidate_notes (candidate_id, created_at DESC)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS candidate_attachments_candidate_idx ON candidate_attachments (candidate_id, uploaded_at DESC)';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,16 @@
+DO $$
+    BEGIN
+        -- COLUMNS --
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS title VARCHAR NOT NULL DEFAULT ''''';
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS team VARCHAR NOT NULL DEFAULT ''''';
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS interview_types JSONB NOT NULL DEFAULT ''[]''';
+        -- NULL keeps the working hours of the service
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS working_hours JSONB';
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS max_interviews_per_day INT NOT NULL DEFAULT 0';
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS max_interviews_per_week INT NOT NULL DEFAULT 0';
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS out_of_office JSONB NOT NULL DEFAULT ''[]''';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS recruiters_team_idx ON recruiters (LOWER(team))';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,109 @@
+DO $$
+    DECLARE
+        t TEXT;
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS organizations (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            name VARCHAR NOT NULL,
+            status VARCHAR NOT NULL DEFAULT ''active'',
+            version INT NOT NULL DEFAULT 1
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS organization_keys (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            revoked_at TIMESTAMPTZ,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            organization_id UUID NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+            name VARCHAR NOT NULL,
+            prefix VARCHAR NOT NULL,
+            secret_hash VARCHAR NOT NULL UNIQUE
+        )
+    ';
+
+        -- the data stored so far belongs to the default organization
+        EXECUTE 'INSERT INTO organizations (id, name) VALUES (''00000000-0000-0000-0000-000000000001'', ''Default'') ON CONFLICT (id) DO NOTHING';
+
+        -- COLUMNS --
+        EXECUTE 'ALTER TABLE recruiters ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE candidates ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE interviews ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE resources ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE vacancies ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE applications ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE scorecard_templates ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE scorecards ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE webhook_subscriptions ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE notifications ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE notification_opt_outs ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE busy_blocks ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE busy_feeds ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE candidate_notes ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE candidate_attachments ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+        EXECUTE 'ALTER TABLE calendar_feed_tokens ADD COLUMN IF NOT EXISTS tenant_id UUID NOT NULL DEFAULT ''00000000-0000-0000-0000-000000000001'' REFERENCES organizations (id)';
+
+        -- new rows have to name their organization
+        EXECUTE 'ALTER TABLE recruiters ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE candidates ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE interviews ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE resources ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE vacancies ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE applications ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE scorecard_templates ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE scorecards ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE audit_log ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE webhook_subscriptions ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE notifications ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE notification_opt_outs ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE busy_blocks ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE busy_feeds ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE candidate_notes ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE candidate_attachments ALTER COLUMN tenant_id DROP DEFAULT';
+        EXECUTE 'ALTER TABLE calendar_feed_tokens ALTER COLUMN tenant_id DROP DEFAULT';
+
+        -- CONSTRAINTS --
+        EXECUTE 'ALTER TABLE notification_opt_outs DROP CONSTRAINT IF EXISTS notification_opt_outs_pkey';
+        EXECUTE 'ALTER TABLE notification_opt_outs ADD PRIMARY KEY (tenant_id, channel, recipient)';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS organization_keys_organization_idx ON organization_keys (organization_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS recruiters_tenant_idx ON recruiters (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS candidates_tenant_idx ON candidates (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS interviews_tenant_idx ON interviews (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS resources_tenant_idx ON resources (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS vacancies_tenant_idx ON vacancies (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS applications_tenant_idx ON applications (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS scorecard_templates_tenant_idx ON scorecard_templates (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS scorecards_tenant_idx ON scorecards (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS audit_log_tenant_idx ON audit_log (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS webhook_subscriptions_tenant_idx ON webhook_subscriptions (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS notifications_tenant_idx ON notifications (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS busy_blocks_tenant_idx ON busy_blocks (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS busy_feeds_tenant_idx ON busy_feeds (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS calendar_feed_tokens_tenant_idx ON calendar_feed_tokens (tenant_id)';
+
+        -- ROW LEVEL SECURITY --
+        -- the transactions name their organization in app.tenant_id and only
+        -- see its rows, the queries run without it, like the ones of the
+        -- background jobs, see every row
+        FOREACH t IN ARRAY ARRAY[
+            'recruiters', 'candidates', 'interviews', 'resources', 'vacancies', 'applications',
+            'scorecard_templates', 'scorecards', 'audit_log', 'webhook_subscriptions', 'notifications',
+            'notification_opt_outs', 'busy_blocks', 'busy_feeds', 'candidate_notes', 'candidate_attachments',
+            'calendar_feed_tokens'
+        ] LOOP
+            EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+            EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+            EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+            EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (
+                NULLIF(current_setting(''app.tenant_id'', true), '''') IS NULL
+                OR tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)', t);
+        END LOOP;
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,43 @@
+DO $$
+    DECLARE
+        t TEXT;
+    BEGIN
+        -- TABLES --
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS teams (
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL REFERENCES organizations (id),
+            name VARCHAR NOT NULL,
+            strategy VARCHAR NOT NULL DEFAULT ''round_robin'',
+            last_assigned_id UUID REFERENCES recruiters (id) ON DELETE SET NULL,
+            version INT NOT NULL DEFAULT 1
+        )
+    ';
+
+        EXECUTE '
+        CREATE TABLE IF NOT EXISTS team_members (
+            tenant_id UUID NOT NULL REFERENCES organizations (id),
+            team_id UUID NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+            recruiter_id UUID NOT NULL REFERENCES recruiters (id) ON DELETE CASCADE,
+            joined_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            PRIMARY KEY (team_id, recruiter_id)
+        )
+    ';
+
+        -- INDEXES --
+        EXECUTE 'CREATE INDEX IF NOT EXISTS teams_tenant_idx ON teams (tenant_id)';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS team_members_recruiter_idx ON team_members (recruiter_id)';
+
+        -- ROW LEVEL SECURITY --
+        FOREACH t IN ARRAY ARRAY['teams', 'team_members'] LOOP
+            EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+            EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+            EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+            EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (
+                NULLIF(current_setting(''app.tenant_id'', true), '''') IS NULL
+                OR tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)', t);
+        END LOOP;
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,6 @@
+DO $$
+    BEGIN
+        -- COLUMNS --
+        EXECUTE 'ALTER TABLE organization_keys ADD COLUMN IF NOT EXISTS recruiter_id UUID REFERENCES recruiters (id) ON DELETE SET NULL';
+    END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,11 @@
+DO $$
+    BEGIN
+        -- CONSTRAINTS --
+        -- purged recruiters only leave the panels, the interviews stay with the
+        -- candidate and the rest of the panel
+        EXECUTE 'ALTER TABLE interviews ALTER COLUMN recruiter_id DROP NOT NULL';
+        EXECUTE 'ALTER TABLE interviews DROP CONSTRAINT IF EXISTS interviews_recruiter_id_fkey';
+        EXECUTE 'ALTER TABLE interviews ADD CONSTRAINT interviews_recruiter_id_fkey
+            FOREIGN KEY (recruiter_id) REFERENCES recruiters (id) ON DELETE SET NULL';
+    END
+$$ LANGUAGE plpgsql;
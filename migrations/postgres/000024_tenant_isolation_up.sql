@@ -0,0 +1,21 @@
+DO $$
+    DECLARE
+        t TEXT;
+    BEGIN
+        -- ROW LEVEL SECURITY --
+        -- the transactions name their organization in app.tenant_id and only
+        -- see its rows, the ones of the background jobs set app.bypass_rls
+        -- instead and see every row, a transaction setting neither sees none
+        FOREACH t IN ARRAY ARRAY[
+            'recruiters', 'candidates', 'interviews', 'resources', 'vacancies', 'applications',
+            'scorecard_templates', 'scorecards', 'audit_log', 'webhook_subscriptions', 'notifications',
+            'notification_opt_outs', 'busy_blocks', 'busy_feeds', 'candidate_notes', 'candidate_attachments',
+            'calendar_feed_tokens', 'teams', 'team_members'
+        ] LOOP
+            EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+            EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (
+                current_setting(''app.bypass_rls'', true) = ''on''
+                OR tenant_id = NULLIF(current_setting(''app.tenant_id'', true), '''')::uuid)', t);
+        END LOOP;
+    END
+$$ LANGUAGE plpgsql;
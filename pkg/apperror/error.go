@@ -0,0 +1,109 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies an Error, the transport layer maps it to a status code
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindForbidden
+	KindUnprocessable
+	KindPreconditionFailed
+	KindPreconditionRequired
+	KindUnauthorized
+)
+
+// Error is a typed error returned by the service layer, Detail is safe
+// to be shown to the client while Err keeps the underlying cause.
+type Error struct {
+	Kind   Kind
+	Detail string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil || e.Err.Error() == e.Detail {
+		return e.Detail
+	}
+	return e.Detail + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap returns an Error of the kind with the detail caused by err
+func Wrap(kind Kind, err error, format string, args ...any) error {
+	return &Error{
+		Kind:   kind,
+		Detail: fmt.Sprintf(format, args...),
+		Err:    err,
+	}
+}
+
+// From returns an Error of the kind that shows err to the client as is
+func From(kind Kind, err error) error {
+	return &Error{
+		Kind:   kind,
+		Detail: err.Error(),
+		Err:    err,
+	}
+}
+
+func NotFound(format string, args ...any) error {
+	return Wrap(KindNotFound, nil, format, args...)
+}
+
+func Conflict(format string, args ...any) error {
+	return Wrap(KindConflict, nil, format, args...)
+}
+
+func Validation(format string, args ...any) error {
+	return Wrap(KindValidation, nil, format, args...)
+}
+
+func Forbidden(format string, args ...any) error {
+	return Wrap(KindForbidden, nil, format, args...)
+}
+
+func Unprocessable(format string, args ...any) error {
+	return Wrap(KindUnprocessable, nil, format, args...)
+}
+
+func PreconditionFailed(format string, args ...any) error {
+	return Wrap(KindPreconditionFailed, nil, format, args...)
+}
+
+func PreconditionRequired(format string, args ...any) error {
+	return Wrap(KindPreconditionRequired, nil, format, args...)
+}
+
+func Unauthorized(format string, args ...any) error {
+	return Wrap(KindUnauthorized, nil, format, args...)
+}
+
+// KindOf returns the kind of the first Error in the chain of err,
+// errors that are not typed are internal
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// DetailOf returns the client safe detail of err
+func DetailOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Detail
+	}
+	return "internal server error"
+}
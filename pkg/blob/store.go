@@ -0,0 +1,113 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrorNotFound is returned when there is no blob under the key
+var ErrorNotFound = errors.New("blob not found")
+
+// Store keeps binary objects under slash separated keys
+type Store interface {
+	// Put stores the content of r under the key, replacing the existing blob
+	Put(ctx context.Context, key string, r io.Reader) (size int64, err error)
+	Get(ctx context.Context, key string) (rc io.ReadCloser, err error)
+	Delete(ctx context.Context, key string) (err error)
+	// DeleteAll removes the blobs whose keys start with the prefix followed by a slash
+	DeleteAll(ctx context.Context, prefix string) (err error)
+}
+
+// Local is a store keeping the blobs as files under a root directory
+type Local struct {
+	root string
+}
+
+// NewLocal returns a store keeping the blobs under root, which is created when missing
+func NewLocal(root string) (*Local, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	return &Local{root: root}, nil
+}
+
+func (s *Local) Put(ctx context.Context, key string, r io.Reader) (size int64, err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return
+	}
+
+	// the content is written aside and moved in place once complete so that
+	// readers never see a partial blob
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+
+	if size, err = io.Copy(file, r); err != nil {
+		file.Close()
+		return 0, err
+	}
+	if err = file.Close(); err != nil {
+		return 0, err
+	}
+	if err = os.Rename(file.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+func (s *Local) Get(ctx context.Context, key string) (rc io.ReadCloser, err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrorNotFound
+	}
+
+	return file, err
+}
+
+func (s *Local) Delete(ctx context.Context, key string) (err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrorNotFound
+	}
+
+	return
+}
+
+func (s *Local) DeleteAll(ctx context.Context, prefix string) (err error) {
+	path, err := s.path(prefix)
+	if err != nil {
+		return
+	}
+
+	return os.RemoveAll(path)
+}
+
+// path maps the key to a file under the root, keys escaping the root are rejected
+func (s *Local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + filepath.FromSlash(key))
+	if clean == string(filepath.Separator) || strings.Contains(key, "..") {
+		return "", errors.New("blob: invalid key " + key)
+	}
+	return filepath.Join(s.root, clean), nil
+}
@@ -0,0 +1,78 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, content := range map[string]string{
+		"candidates/1/cv":     "first",
+		"candidates/1/letter": "second",
+		"candidates/2/cv":     "third",
+	} {
+		size, err := s.Put(ctx, key, strings.NewReader(content))
+		if err != nil || size != int64(len(content)) {
+			t.Fatalf("Put(%s) = %d, %v", key, size, err)
+		}
+	}
+	// a blob is replaced as a whole
+	if _, err = s.Put(ctx, "candidates/1/cv", strings.NewReader("replaced")); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(key string) (string, error) {
+		rc, err := s.Get(ctx, key)
+		if err != nil {
+			return "", err
+		}
+		defer rc.Close()
+		src, err := io.ReadAll(rc)
+		return string(src), err
+	}
+
+	if got, err := read("candidates/1/cv"); err != nil || got != "replaced" {
+		t.Errorf("Get() = %q, %v, want the replaced content", got, err)
+	}
+
+	if err = s.Delete(ctx, "candidates/1/letter"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err = s.Delete(ctx, "candidates/1/letter"); !errors.Is(err, ErrorNotFound) {
+		t.Errorf("Delete() of a deleted blob error = %v, want ErrorNotFound", err)
+	}
+
+	if err = s.DeleteAll(ctx, "candidates/1"); err != nil {
+		t.Fatalf("DeleteAll() error = %v", err)
+	}
+	if _, err = read("candidates/1/cv"); !errors.Is(err, ErrorNotFound) {
+		t.Errorf("Get() after DeleteAll() error = %v, want ErrorNotFound", err)
+	}
+	if got, err := read("candidates/2/cv"); err != nil || got != "third" {
+		t.Errorf("Get() of another prefix = %q, %v, want it kept", got, err)
+	}
+}
+
+func TestLocalInvalidKeys(t *testing.T) {
+	s, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"", "/", "../outside", "candidates/../../outside"} {
+		t.Run(key, func(t *testing.T) {
+			if _, err := s.Put(context.Background(), key, strings.NewReader("content")); err == nil {
+				t.Errorf("Put(%q) error = nil, want an error", key)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package egress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Policy guards the requests sent to the urls users give us, such as calendar
+// feeds and webhooks, from reaching the loopback, link-local and private
+// networks of the service. The allowed hosts are let through regardless.
+type Policy struct {
+	allowed map[string]bool
+}
+
+// NewPolicy returns a policy letting the allowed hosts through, compared by
+// name without the port
+func NewPolicy(allowedHosts ...string) *Policy {
+	p := &Policy{allowed: make(map[string]bool, len(allowedHosts))}
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			p.allowed[host] = true
+		}
+	}
+	return p
+}
+
+// Public tells whether the address is reachable on the internet, the
+// loopback, private, link-local, shared and unspecified ranges are not
+func Public(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	switch {
+	case !addr.IsValid(),
+		addr.IsUnspecified(),
+		addr.IsLoopback(),
+		addr.IsPrivate(),
+		addr.IsLinkLocalUnicast(),
+		addr.IsLinkLocalMulticast(),
+		addr.IsInterfaceLocalMulticast(),
+		addr.IsMulticast(),
+		sharedRange.Contains(addr),
+		thisNetworkRange.Contains(addr):
+		return false
+	}
+	return true
+}
+
+var (
+	// sharedRange is the carrier-grade NAT range of RFC 6598
+	sharedRange = netip.MustParsePrefix("100.64.0.0/10")
+	// thisNetworkRange is the "this network" range of RFC 1122
+	thisNetworkRange = netip.MustParsePrefix("0.0.0.0/8")
+)
+
+// Allowed tells whether the host is on the allow-list
+func (p *Policy) Allowed(host string) bool {
+	return p.allowed[strings.ToLower(host)]
+}
+
+// CheckURL validates an absolute http(s) url and resolves its host, it fails
+// when the host is not allowed and resolves to an address that is not public
+func (p *Policy) CheckURL(ctx context.Context, rawURL string) (err error) {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("%q is not an absolute http(s) url", rawURL)
+	}
+
+	host := target.Hostname()
+	if p.Allowed(host) {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if !Public(addr) {
+			return fmt.Errorf("host %s resolves to %s which is not a public address", host, addr.Unmap())
+		}
+	}
+
+	return nil
+}
+
+// Client returns an http client whose connections are checked against the
+// policy once the host is resolved, so a name that changes its address
+// between the check and the request is still refused. Proxies are not used,
+// they would hide the address of the host.
+func (p *Policy) Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	guarded := &net.Dialer{Timeout: dialer.Timeout, KeepAlive: dialer.KeepAlive, Control: control}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if p.Allowed(host) {
+			return dialer.DialContext(ctx, network, address)
+		}
+		return guarded.DialContext(ctx, network, address)
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// control runs right before connecting, with the resolved address
+func control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !Public(addrPort.Addr()) {
+		return fmt.Errorf("address %s is not public", addrPort.Addr().Unmap())
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateFormat      = "20060102"
+	localTimeFormat = "20060102T150405"
+
+	// maxLineCount bounds the content lines read from a calendar
+	maxLineCount = 1_000_000
+)
+
+var ErrInvalidCalendar = errors.New("ical: invalid calendar")
+
+// property is a content line split into its name, parameters and value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Decode reads the events and free/busy periods of an iCalendar object,
+// times without a time zone are read in UTC
+func Decode(r io.Reader) (cal Calendar, err error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return
+	}
+
+	var (
+		stack    []string
+		event    *Event
+		duration time.Duration
+		hasEnd   bool
+		found    bool
+	)
+	for _, line := range lines {
+		prop, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.value))
+			if strings.EqualFold(prop.value, "VCALENDAR") {
+				found = true
+			}
+			if strings.EqualFold(prop.value, "VEVENT") {
+				event, duration, hasEnd = &Event{}, 0, false
+			}
+			continue
+		case "END":
+			if len(stack) == 0 {
+				return cal, fmt.Errorf("%w: unexpected END:%s", ErrInvalidCalendar, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+			if strings.EqualFold(prop.value, "VEVENT") && event != nil {
+				if !hasEnd {
+					switch {
+					case duration > 0:
+						event.End = event.Start.Add(duration)
+					case event.AllDay:
+						event.End = event.Start.AddDate(0, 0, 1)
+					default:
+						event.End = event.Start
+					}
+				}
+				if !event.Start.IsZero() {
+					cal.Events = append(cal.Events, *event)
+				}
+				event = nil
+			}
+			continue
+		}
+
+		if len(stack) == 0 {
+			continue
+		}
+
+		switch stack[len(stack)-1] {
+		case "VCALENDAR":
+			switch prop.name {
+			case "PRODID":
+				cal.ProdID = prop.value
+			case "METHOD":
+				cal.Method = prop.value
+			case "X-WR-CALNAME":
+				cal.Name = unescape(prop.value)
+			}
+		case "VEVENT":
+			if event == nil {
+				continue
+			}
+			switch prop.name {
+			case "UID":
+				event.UID = prop.value
+			case "SEQUENCE":
+				event.Sequence, _ = strconv.Atoi(prop.value)
+			case "SUMMARY":
+				event.Summary = unescape(prop.value)
+			case "LOCATION":
+				event.Location = unescape(prop.value)
+			case "DESCRIPTION":
+				event.Description = unescape(prop.value)
+			case "URL":
+				event.URL = prop.value
+			case "STATUS":
+				event.Status = strings.ToUpper(prop.value)
+			case "TRANSP":
+				event.Transparent = strings.EqualFold(prop.value, "TRANSPARENT")
+			case "DTSTAMP":
+				event.Stamp, _ = parseTime(prop)
+			case "DTSTART":
+				if event.Start, err = parseTime(prop); err != nil {
+					return
+				}
+				event.AllDay = isDate(prop)
+			case "DTEND":
+				if event.End, err = parseTime(prop); err != nil {
+					return
+				}
+				hasEnd = true
+			case "DURATION":
+				if duration, err = ParseDuration(prop.value); err != nil {
+					return
+				}
+			case "RRULE":
+				event.RRule = prop.value
+			case "RDATE", "EXDATE":
+				for _, value := range strings.Split(prop.value, ",") {
+					var date time.Time
+					if date, err = parseTime(property{params: prop.params, value: strings.SplitN(value, "/", 2)[0]}); err != nil {
+						return
+					}
+					if prop.name == "RDATE" {
+						event.RDates = append(event.RDates, date)
+					} else {
+						event.ExDates = append(event.ExDates, date)
+					}
+				}
+			case "RECURRENCE-ID":
+				if event.RecurrenceID, err = parseTime(prop); err != nil {
+					return
+				}
+			}
+		case "VFREEBUSY":
+			if prop.name != "FREEBUSY" {
+				continue
+			}
+			if kind := strings.ToUpper(prop.params["FBTYPE"]); kind == "FREE" {
+				continue
+			}
+			for _, value := range strings.Split(prop.value, ",") {
+				var period Period
+				if period, err = parsePeriod(value); err != nil {
+					return
+				}
+				cal.FreeBusy = append(cal.FreeBusy, period)
+			}
+		}
+	}
+
+	if !found {
+		return cal, fmt.Errorf("%w: no VCALENDAR", ErrInvalidCalendar)
+	}
+
+	return
+}
+
+// unfold reads the content lines joining the folded ones
+func unfold(r io.Reader) (lines []string, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if len(lines) == maxLineCount {
+			return nil, fmt.Errorf("%w: too many lines", ErrInvalidCalendar)
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// parseLine splits a content line, the separators within quoted parameter values are ignored
+func parseLine(line string) (prop property, ok bool) {
+	quoted := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return
+	}
+
+	prop.value = line[colon+1:]
+	prop.params = make(map[string]string)
+
+	parts := splitParams(line[:colon])
+	prop.name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		name, value, found := strings.Cut(param, "=")
+		if !found {
+			continue
+		}
+		prop.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+
+	return prop, prop.name != ""
+}
+
+func splitParams(s string) (parts []string) {
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func isDate(prop property) bool {
+	return strings.EqualFold(prop.params["VALUE"], "DATE") || len(prop.value) == len(dateFormat)
+}
+
+// parseTime reads a DATE or DATE-TIME value in UTC, in the zone of its TZID
+// parameter, or in UTC for floating times and unknown zones
+func parseTime(prop property) (time.Time, error) {
+	value := strings.TrimSpace(prop.value)
+
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(strings.TrimPrefix(tzid, "/")); err == nil {
+			loc = zone
+		}
+	}
+
+	switch {
+	case isDate(prop):
+		return time.ParseInLocation(dateFormat, value, loc)
+	case strings.HasSuffix(value, "Z"):
+		return time.Parse(timeFormat, value)
+	default:
+		return time.ParseInLocation(localTimeFormat, value, loc)
+	}
+}
+
+// parsePeriod reads a PERIOD value, either start/end or start/duration
+func parsePeriod(value string) (period Period, err error) {
+	start, end, found := strings.Cut(value, "/")
+	if !found {
+		return period, fmt.Errorf("%w: period %q", ErrInvalidCalendar, value)
+	}
+
+	if period.Start, err = time.Parse(timeFormat, start); err != nil {
+		return
+	}
+	if strings.HasPrefix(end, "P") || strings.HasPrefix(end, "+P") {
+		var duration time.Duration
+		if duration, err = ParseDuration(end); err != nil {
+			return
+		}
+		period.End = period.Start.Add(duration)
+		return
+	}
+	period.End, err = time.Parse(timeFormat, end)
+
+	return
+}
+
+// ParseDuration reads an RFC 5545 DURATION value such as P1W, P1DT2H or PT30M
+func ParseDuration(value string) (duration time.Duration, err error) {
+	s := strings.TrimPrefix(value, "+")
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("%w: duration %q", ErrInvalidCalendar, value)
+	}
+
+	inTime := false
+	number := 0
+	digits := false
+	for _, r := range s[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			number = number*10 + int(r-'0')
+			digits = true
+			continue
+		case r == 'T':
+			inTime = true
+			continue
+		}
+		if !digits {
+			return 0, fmt.Errorf("%w: duration %q", ErrInvalidCalendar, value)
+		}
+
+		switch {
+		case r == 'W' && !inTime:
+			duration += time.Duration(number) * 7 * 24 * time.Hour
+		case r == 'D' && !inTime:
+			duration += time.Duration(number) * 24 * time.Hour
+		case r == 'H' && inTime:
+			duration += time.Duration(number) * time.Hour
+		case r == 'M' && inTime:
+			duration += time.Duration(number) * time.Minute
+		case r == 'S' && inTime:
+			duration += time.Duration(number) * time.Second
+		default:
+			return 0, fmt.Errorf("%w: duration %q", ErrInvalidCalendar, value)
+		}
+		number, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("%w: duration %q", ErrInvalidCalendar, value)
+	}
+
+	if negative {
+		duration = -duration
+	}
+	return
+}
+
+func unescape(s string) string {
+	return strings.NewReplacer(
+		`\\`, `\`,
+		`\;`, ";",
+		`\,`, ",",
+		`\n`, "\n",
+		`\N`, "\n",
+	).Replace(s)
+}
@@ -0,0 +1,231 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func calendar(lines ...string) string {
+	return "BEGIN:VCALENDAR\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VCALENDAR\r\n"
+}
+
+func TestDecode(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []Event
+	}{
+		{
+			name: "utc times",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"UID:1",
+				"DTSTART:20260105T090000Z",
+				"DTEND:20260105T100000Z",
+				"SUMMARY:Standup",
+				"END:VEVENT",
+			),
+			want: []Event{{
+				UID:     "1",
+				Start:   time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+				End:     time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+				Summary: "Standup",
+			}},
+		},
+		{
+			name: "time zone and duration",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"UID:2",
+				"DTSTART;TZID=Europe/Berlin:20260105T090000",
+				"DURATION:PT45M",
+				"END:VEVENT",
+			),
+			want: []Event{{
+				UID:   "2",
+				Start: time.Date(2026, 1, 5, 9, 0, 0, 0, berlin),
+				End:   time.Date(2026, 1, 5, 9, 45, 0, 0, berlin),
+			}},
+		},
+		{
+			name: "all day event lasts a day",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"UID:3",
+				"DTSTART;VALUE=DATE:20260105",
+				"END:VEVENT",
+			),
+			want: []Event{{
+				UID:    "3",
+				Start:  time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+				End:    time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC),
+				AllDay: true,
+			}},
+		},
+		{
+			name: "folded and escaped text",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"UID:4",
+				"DTSTART:20260105T090000Z",
+				"DTEND:20260105T100000Z",
+				"SUMMARY:Interview\\, round",
+				"  two\\; final",
+				"END:VEVENT",
+			),
+			want: []Event{{
+				UID:     "4",
+				Start:   time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+				End:     time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+				Summary: "Interview, round two; final",
+			}},
+		},
+		{
+			name: "quoted parameter with separators",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"UID:5",
+				"DTSTART:20260105T090000Z",
+				"DTEND:20260105T100000Z",
+				`ORGANIZER;CN="Doe; Jane: HR":mailto:jane@example.com`,
+				"END:VEVENT",
+			),
+			want: []Event{{
+				UID:   "5",
+				Start: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			name: "event without start is skipped",
+			input: calendar(
+				"BEGIN:VEVENT",
+				"UID:6",
+				"SUMMARY:No start",
+				"END:VEVENT",
+			),
+			want: nil,
+		},
+		{
+			name: "events of other components are ignored",
+			input: calendar(
+				"BEGIN:VTODO",
+				"UID:7",
+				"DTSTART:20260105T090000Z",
+				"END:VTODO",
+			),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal, err := Decode(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if len(cal.Events) != len(tt.want) {
+				t.Fatalf("Decode() got %d events, want %d", len(cal.Events), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := cal.Events[i]
+				if got.UID != want.UID || !got.Start.Equal(want.Start) || !got.End.Equal(want.End) ||
+					got.Summary != want.Summary || got.AllDay != want.AllDay {
+					t.Errorf("Decode() event %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "no calendar", input: "BEGIN:VEVENT\r\nEND:VEVENT\r\n"},
+		{name: "unbalanced end", input: "END:VCALENDAR\r\n"},
+		{name: "bad start", input: calendar("BEGIN:VEVENT", "DTSTART:tomorrow", "END:VEVENT")},
+		{name: "bad duration", input: calendar("BEGIN:VEVENT", "DTSTART:20260105T090000Z", "DURATION:PT", "END:VEVENT")},
+		{name: "bad free/busy period", input: calendar("BEGIN:VFREEBUSY", "FREEBUSY:20260105T090000Z", "END:VFREEBUSY")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(strings.NewReader(tt.input)); err == nil {
+				t.Fatal("Decode() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestDecodeFreeBusy(t *testing.T) {
+	input := calendar(
+		"BEGIN:VFREEBUSY",
+		"FREEBUSY:20260105T090000Z/20260105T100000Z,20260105T140000Z/PT30M",
+		"FREEBUSY;FBTYPE=FREE:20260105T110000Z/20260105T120000Z",
+		"END:VFREEBUSY",
+	)
+
+	cal, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	want := []Period{
+		{Start: time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 5, 14, 30, 0, 0, time.UTC)},
+	}
+	if len(cal.FreeBusy) != len(want) {
+		t.Fatalf("Decode() got %d periods, want %d", len(cal.FreeBusy), len(want))
+	}
+	for i := range want {
+		if !cal.FreeBusy[i].Start.Equal(want[i].Start) || !cal.FreeBusy[i].End.Equal(want[i].End) {
+			t.Errorf("Decode() period %d = %v, want %v", i, cal.FreeBusy[i], want[i])
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT30M", want: 30 * time.Minute},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "P1W", want: 7 * 24 * time.Hour},
+		{value: "+PT1H30M15S", want: time.Hour + 30*time.Minute + 15*time.Second},
+		{value: "-PT15M", want: -15 * time.Minute},
+		{value: "PT", wantErr: true},
+		{value: "P1H", wantErr: true},
+		{value: "PT1D", wantErr: true},
+		{value: "PT15", wantErr: true},
+		{value: "15M", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseDuration(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCalendar) {
+					t.Fatalf("ParseDuration() error = %v, want ErrInvalidCalendar", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDuration() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
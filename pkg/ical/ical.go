@@ -0,0 +1,205 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MethodPublish = "PUBLISH"
+	MethodRequest = "REQUEST"
+	MethodCancel  = "CANCEL"
+
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+
+	PartStatNeedsAction = "NEEDS-ACTION"
+	PartStatAccepted    = "ACCEPTED"
+	PartStatTentative   = "TENTATIVE"
+	PartStatDeclined    = "DECLINED"
+
+	// maxLineLength is the length in octets after which content lines are folded
+	maxLineLength = 75
+
+	timeFormat = "20060102T150405Z"
+)
+
+// Calendar is an RFC 5545 iCalendar object
+type Calendar struct {
+	ProdID   string
+	Method   string
+	Name     string
+	Events   []Event
+	FreeBusy []Period
+}
+
+// Period is the half-open interval of time [Start, End)
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Event is a VEVENT component, UID stays the same for the life of the event
+// and Sequence grows with every change so that clients apply updates in order
+type Event struct {
+	UID         string
+	Sequence    int
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	URL         string
+	Status      string
+	Organizer   *Attendee
+	Attendees   []Attendee
+
+	// the properties below are read by Decode and not written by Encode
+	AllDay       bool
+	Transparent  bool
+	RRule        string
+	RDates       []time.Time
+	ExDates      []time.Time
+	RecurrenceID time.Time
+}
+
+// Attendee is a calendar user addressed by email
+type Attendee struct {
+	Name   string
+	Email  string
+	Status string
+}
+
+// Encode writes the calendar in the iCalendar format
+func (c Calendar) Encode(w io.Writer) (err error) {
+	bw := bufio.NewWriter(w)
+	e := encoder{w: bw}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", c.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		e.line("METHOD", c.Method)
+	}
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", escape(c.Name))
+	}
+
+	for _, event := range c.Events {
+		e.line("BEGIN", "VEVENT")
+		e.line("UID", event.UID)
+		e.line("SEQUENCE", strconv.Itoa(event.Sequence))
+		e.line("DTSTAMP", event.Stamp.UTC().Format(timeFormat))
+		e.line("DTSTART", event.Start.UTC().Format(timeFormat))
+		e.line("DTEND", event.End.UTC().Format(timeFormat))
+		e.line("SUMMARY", escape(event.Summary))
+		if event.Location != "" {
+			e.line("LOCATION", escape(event.Location))
+		}
+		if event.Description != "" {
+			e.line("DESCRIPTION", escape(event.Description))
+		}
+		if event.URL != "" {
+			e.line("URL", event.URL)
+		}
+		if event.Status != "" {
+			e.line("STATUS", event.Status)
+		}
+		if event.Organizer != nil && event.Organizer.Email != "" {
+			e.line("ORGANIZER"+name(event.Organizer.Name), "mailto:"+event.Organizer.Email)
+		}
+		for _, attendee := range event.Attendees {
+			if attendee.Email == "" {
+				continue
+			}
+			status := attendee.Status
+			if status == "" {
+				status = PartStatNeedsAction
+			}
+			e.line("ATTENDEE"+name(attendee.Name)+";ROLE=REQ-PARTICIPANT;PARTSTAT="+status, "mailto:"+attendee.Email)
+		}
+		e.line("END", "VEVENT")
+	}
+
+	e.line("END", "VCALENDAR")
+
+	if e.err != nil {
+		return e.err
+	}
+	return bw.Flush()
+}
+
+// Bytes returns the calendar in the iCalendar format
+func (c Calendar) Bytes() []byte {
+	var buf bytes.Buffer
+	c.Encode(&buf)
+	return buf.Bytes()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a content line terminated by CRLF and folded after 75 octets
+// without splitting UTF-8 sequences
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+
+	s := name + ":" + value
+	for first := true; ; first = false {
+		limit := maxLineLength
+		if !first {
+			// the leading space of a continuation line counts towards its length
+			limit--
+			e.w.WriteByte(' ')
+		}
+		if len(s) <= limit {
+			break
+		}
+
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.w.WriteString(s[:cut])
+		e.w.WriteString("\r\n")
+		s = s[cut:]
+	}
+	_, e.err = e.w.WriteString(s + "\r\n")
+}
+
+// escape escapes a TEXT value
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// name returns the CN parameter of a calendar user, quoted since names may
+// contain separators, double quotes are not allowed within parameter values
+func name(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '"' || r < ' ' {
+			return -1
+		}
+		return r
+	}, s)
+	if s == "" {
+		return ""
+	}
+	return `;CN="` + s + `"`
+}
@@ -0,0 +1,105 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeFolding(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary string
+	}{
+		{name: "short", summary: "Interview"},
+		{name: "ascii", summary: strings.Repeat("a", 200)},
+		{name: "multibyte", summary: strings.Repeat("ü", 100)},
+		{name: "separators", summary: strings.Repeat("a, b; c\\d\n", 20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal := Calendar{
+				ProdID: "-//test//EN",
+				Events: []Event{{
+					UID:     "1@test",
+					Start:   time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+					End:     time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+					Summary: tt.summary,
+				}},
+			}
+
+			var buf bytes.Buffer
+			if err := cal.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			out := buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatal("Encode() output does not end with CRLF")
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+				if len(line) > maxLineLength {
+					t.Errorf("line of %d octets is longer than %d: %q", len(line), maxLineLength, line)
+				}
+				if !utf8Valid(line) {
+					t.Errorf("line splits a UTF-8 sequence: %q", line)
+				}
+			}
+
+			decoded, err := Decode(strings.NewReader(out))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if len(decoded.Events) != 1 {
+				t.Fatalf("Decode() got %d events, want 1", len(decoded.Events))
+			}
+			got := decoded.Events[0]
+			if got.Summary != tt.summary {
+				t.Errorf("Summary = %q, want %q", got.Summary, tt.summary)
+			}
+			if !got.Start.Equal(cal.Events[0].Start) || !got.End.Equal(cal.Events[0].End) {
+				t.Errorf("times = %v - %v, want %v - %v", got.Start, got.End, cal.Events[0].Start, cal.Events[0].End)
+			}
+		})
+	}
+}
+
+func TestEncodeAttendees(t *testing.T) {
+	cal := Calendar{
+		ProdID: "-//test//EN",
+		Method: MethodRequest,
+		Events: []Event{{
+			UID:       "1@test",
+			Start:     time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC),
+			End:       time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC),
+			Organizer: &Attendee{Name: `Jane "HR" Doe`, Email: "jane@example.com"},
+			Attendees: []Attendee{
+				{Name: "John", Email: "john@example.com"},
+				{Name: "Nobody"},
+				{Name: "Ann", Email: "ann@example.com", Status: PartStatAccepted},
+			},
+		}},
+	}
+
+	// unfold the content lines before looking for them
+	out := strings.ReplaceAll(string(cal.Bytes()), "\r\n ", "")
+	for _, want := range []string{
+		"METHOD:REQUEST\r\n",
+		`ORGANIZER;CN="Jane HR Doe":mailto:jane@example.com` + "\r\n",
+		`ATTENDEE;CN="John";ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:john@example.com` + "\r\n",
+		`ATTENDEE;CN="Ann";ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:ann@example.com` + "\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Bytes() does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Nobody") {
+		t.Errorf("Bytes() contains an attendee without email:\n%s", out)
+	}
+}
+
+func utf8Valid(s string) bool {
+	return strings.ToValidUTF8(s, "\uFFFD") == s
+}
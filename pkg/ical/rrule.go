@@ -0,0 +1,369 @@
+package ical
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxOccurrences bounds the instances generated for a recurring event
+const maxOccurrences = 10_000
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Rule is a recurrence rule, the BYDAY, BYMONTHDAY and BYMONTH parts are
+// supported, the others are ignored
+type Rule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+}
+
+// WeekdayNum is a BYDAY value, N selects the Nth (from the end when negative)
+// weekday of the month, 0 selects every such weekday
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+func ParseRule(value string) (rule Rule, err error) {
+	rule.Interval = 1
+
+	for _, part := range strings.Split(value, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(value)
+		case "INTERVAL":
+			if rule.Interval, err = strconv.Atoi(value); err != nil || rule.Interval < 1 {
+				return rule, fmt.Errorf("%w: rrule interval %q", ErrInvalidCalendar, value)
+			}
+		case "COUNT":
+			if rule.Count, err = strconv.Atoi(value); err != nil {
+				return rule, fmt.Errorf("%w: rrule count %q", ErrInvalidCalendar, value)
+			}
+		case "UNTIL":
+			if rule.Until, err = parseTime(property{value: value}); err != nil {
+				return
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				day = strings.ToUpper(strings.TrimSpace(day))
+				if len(day) < 2 {
+					return rule, fmt.Errorf("%w: rrule byday %q", ErrInvalidCalendar, value)
+				}
+				weekday, ok := weekdays[day[len(day)-2:]]
+				if !ok {
+					return rule, fmt.Errorf("%w: rrule byday %q", ErrInvalidCalendar, value)
+				}
+				n := 0
+				if prefix := day[:len(day)-2]; prefix != "" {
+					if n, err = strconv.Atoi(prefix); err != nil {
+						return rule, fmt.Errorf("%w: rrule byday %q", ErrInvalidCalendar, value)
+					}
+				}
+				rule.ByDay = append(rule.ByDay, WeekdayNum{N: n, Weekday: weekday})
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				var n int
+				if n, err = strconv.Atoi(day); err != nil || n == 0 || n < -31 || n > 31 {
+					return rule, fmt.Errorf("%w: rrule bymonthday %q", ErrInvalidCalendar, value)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, month := range strings.Split(value, ",") {
+				var n int
+				if n, err = strconv.Atoi(month); err != nil || n < 1 || n > 12 {
+					return rule, fmt.Errorf("%w: rrule bymonth %q", ErrInvalidCalendar, value)
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(n))
+			}
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return rule, fmt.Errorf("%w: rrule freq %q", ErrInvalidCalendar, rule.Freq)
+	}
+
+	return
+}
+
+// Occurrences returns the starts of the instances of the rule from start, in
+// order and up to before, always including start itself
+func (rule Rule) Occurrences(start, before time.Time) (dest []time.Time) {
+	dest = append(dest, start)
+	count := 1
+
+	limit := before
+	if !rule.Until.IsZero() && rule.Until.Before(limit) {
+		limit = rule.Until.Add(time.Second)
+	}
+
+	// every period yields at least one candidate for the rules without filters,
+	// the periods are bounded as well for the filters that never match
+	for period := 0; period < maxOccurrences*4 && len(dest) < maxOccurrences; period++ {
+		candidates := rule.candidates(start, period)
+		if len(candidates) > 0 && !candidates[0].Before(limit) {
+			return
+		}
+		if len(candidates) == 0 && rule.periodStart(start, period).After(limit) {
+			return
+		}
+
+		for _, candidate := range candidates {
+			if !candidate.After(start) {
+				continue
+			}
+			if !candidate.Before(limit) {
+				return
+			}
+			if rule.Count > 0 && count >= rule.Count {
+				return
+			}
+			dest = append(dest, candidate)
+			count++
+		}
+	}
+
+	return
+}
+
+// periodStart returns the first day of the period-th period of the rule
+func (rule Rule) periodStart(start time.Time, period int) time.Time {
+	step := period * rule.Interval
+	y, m, d := start.Date()
+
+	switch rule.Freq {
+	case "DAILY":
+		return time.Date(y, m, d+step, 0, 0, 0, 0, start.Location())
+	case "WEEKLY":
+		// weeks start on monday
+		offset := (int(start.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset+7*step, 0, 0, 0, 0, start.Location())
+	case "MONTHLY":
+		return time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, start.Location())
+	default:
+		return time.Date(y+step, 1, 1, 0, 0, 0, 0, start.Location())
+	}
+}
+
+// candidates returns the sorted instances of the period-th period of the rule
+func (rule Rule) candidates(start time.Time, period int) (dest []time.Time) {
+	from := rule.periodStart(start, period)
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+	}
+
+	var days []time.Time
+	switch rule.Freq {
+	case "DAILY":
+		days = []time.Time{at(from.Year(), from.Month(), from.Day())}
+	case "WEEKLY":
+		if len(rule.ByDay) == 0 {
+			offset := (int(start.Weekday()) + 6) % 7
+			days = []time.Time{at(from.Year(), from.Month(), from.Day()+offset)}
+			break
+		}
+		for i := 0; i < 7; i++ {
+			day := at(from.Year(), from.Month(), from.Day()+i)
+			if rule.matchWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case "MONTHLY":
+		days = rule.monthDays(start, from.Year(), from.Month(), at)
+	case "YEARLY":
+		months := rule.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{start.Month()}
+		}
+		for _, month := range months {
+			days = append(days, rule.monthDays(start, from.Year(), month, at)...)
+		}
+	}
+
+	for _, day := range days {
+		if rule.match(day) {
+			dest = append(dest, day)
+		}
+	}
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Before(dest[j])
+	})
+
+	return
+}
+
+// monthDays returns the days of the month selected by BYMONTHDAY and BYDAY,
+// or the day of the month of start when neither is given
+func (rule Rule) monthDays(start time.Time, y int, m time.Month, at func(int, time.Month, int) time.Time) (dest []time.Time) {
+	last := time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	if len(rule.ByMonthDay) == 0 && len(rule.ByDay) == 0 {
+		if start.Day() <= last {
+			dest = append(dest, at(y, m, start.Day()))
+		}
+		return
+	}
+
+	for d := 1; d <= last; d++ {
+		day := at(y, m, d)
+		if len(rule.ByMonthDay) > 0 && !rule.matchMonthDay(d, last) {
+			continue
+		}
+		if len(rule.ByDay) > 0 && !rule.matchNthWeekday(day, d, last) {
+			continue
+		}
+		dest = append(dest, day)
+	}
+
+	return
+}
+
+// match applies the BYMONTH part, and BYDAY and BYMONTHDAY as filters of daily rules
+func (rule Rule) match(day time.Time) bool {
+	if len(rule.ByMonth) > 0 {
+		found := false
+		for _, month := range rule.ByMonth {
+			found = found || day.Month() == month
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if rule.Freq == "DAILY" {
+		last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if len(rule.ByDay) > 0 && !rule.matchWeekday(day) {
+			return false
+		}
+		if len(rule.ByMonthDay) > 0 && !rule.matchMonthDay(day.Day(), last) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (rule Rule) matchWeekday(day time.Time) bool {
+	for _, weekday := range rule.ByDay {
+		if weekday.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule Rule) matchNthWeekday(day time.Time, d, last int) bool {
+	for _, weekday := range rule.ByDay {
+		if weekday.Weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case weekday.N == 0:
+			return true
+		case weekday.N > 0 && (d-1)/7+1 == weekday.N:
+			return true
+		case weekday.N < 0 && (last-d)/7+1 == -weekday.N:
+			return true
+		}
+	}
+	return false
+}
+
+func (rule Rule) matchMonthDay(d, last int) bool {
+	for _, n := range rule.ByMonthDay {
+		if n == d || (n < 0 && last+n+1 == d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Busy returns the periods of [from, to) the calendar is busy in, from its
+// free/busy periods and its opaque events that are not cancelled, with
+// recurring events expanded and their overridden instances replaced
+func (c Calendar) Busy(from, to time.Time) (dest []Period, err error) {
+	overrides := make(map[string]map[int64]bool)
+	for _, event := range c.Events {
+		if event.RecurrenceID.IsZero() {
+			continue
+		}
+		if overrides[event.UID] == nil {
+			overrides[event.UID] = make(map[int64]bool)
+		}
+		overrides[event.UID][event.RecurrenceID.Unix()] = true
+	}
+
+	add := func(period Period) {
+		if period.End.After(from) && period.Start.Before(to) && period.End.After(period.Start) {
+			dest = append(dest, Period{Start: period.Start.UTC(), End: period.End.UTC()})
+		}
+	}
+
+	for _, period := range c.FreeBusy {
+		add(period)
+	}
+
+	for _, event := range c.Events {
+		if event.Status == StatusCancelled || event.Transparent {
+			continue
+		}
+
+		duration := event.End.Sub(event.Start)
+		if event.RRule == "" && len(event.RDates) == 0 || !event.RecurrenceID.IsZero() {
+			add(Period{Start: event.Start, End: event.End})
+			continue
+		}
+
+		starts := []time.Time{event.Start}
+		if event.RRule != "" {
+			var rule Rule
+			if rule, err = ParseRule(event.RRule); err != nil {
+				return
+			}
+			starts = rule.Occurrences(event.Start, to)
+		}
+		starts = append(starts, event.RDates...)
+
+		excluded := make(map[int64]bool, len(event.ExDates))
+		for _, date := range event.ExDates {
+			excluded[date.Unix()] = true
+		}
+
+		for _, start := range starts {
+			if excluded[start.Unix()] || overrides[event.UID][start.Unix()] {
+				continue
+			}
+			add(Period{Start: start, End: start.Add(duration)})
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Start.Before(dest[j].Start)
+	})
+
+	return
+}
@@ -0,0 +1,191 @@
+package ical
+
+import (
+	"testing"
+	"time"
+)
+
+func date(month time.Month, day, hour int) time.Time {
+	return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{value: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4"},
+		{value: "FREQ=MONTHLY;BYDAY=-1FR"},
+		{value: "FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=1"},
+		{value: "FREQ=DAILY;UNTIL=20260110T000000Z"},
+		{value: "FREQ=HOURLY", wantErr: true},
+		{value: "BYDAY=MO", wantErr: true},
+		{value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{value: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{value: "FREQ=YEARLY;BYMONTH=13", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, err := ParseRule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleOccurrences(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		start  time.Time
+		before time.Time
+		want   []time.Time
+	}{
+		{
+			name:   "daily with count",
+			rule:   "FREQ=DAILY;COUNT=3",
+			start:  date(1, 5, 9),
+			before: date(2, 1, 0),
+			want:   []time.Time{date(1, 5, 9), date(1, 6, 9), date(1, 7, 9)},
+		},
+		{
+			name:   "daily until is inclusive",
+			rule:   "FREQ=DAILY;UNTIL=20260107T090000Z",
+			start:  date(1, 5, 9),
+			before: date(2, 1, 0),
+			want:   []time.Time{date(1, 5, 9), date(1, 6, 9), date(1, 7, 9)},
+		},
+		{
+			name:   "every other day up to before",
+			rule:   "FREQ=DAILY;INTERVAL=2",
+			start:  date(1, 5, 9),
+			before: date(1, 10, 0),
+			want:   []time.Time{date(1, 5, 9), date(1, 7, 9), date(1, 9, 9)},
+		},
+		{
+			name:   "weekly on monday and wednesday",
+			rule:   "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+			start:  date(1, 5, 9),
+			before: date(3, 1, 0),
+			want:   []time.Time{date(1, 5, 9), date(1, 7, 9), date(1, 12, 9), date(1, 14, 9)},
+		},
+		{
+			name:   "weekly days before the start are skipped",
+			rule:   "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3",
+			start:  date(1, 7, 9),
+			before: date(3, 1, 0),
+			want:   []time.Time{date(1, 7, 9), date(1, 9, 9), date(1, 12, 9)},
+		},
+		{
+			name:   "monthly on the last friday",
+			rule:   "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start:  date(1, 30, 9),
+			before: date(12, 1, 0),
+			want:   []time.Time{date(1, 30, 9), date(2, 27, 9), date(3, 27, 9)},
+		},
+		{
+			name:   "monthly on the 31st skips the short months",
+			rule:   "FREQ=MONTHLY;COUNT=3",
+			start:  date(1, 31, 9),
+			before: date(12, 1, 0),
+			want:   []time.Time{date(1, 31, 9), date(3, 31, 9), date(5, 31, 9)},
+		},
+		{
+			name:   "monthly on the last day",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			start:  date(1, 31, 9),
+			before: date(12, 1, 0),
+			want:   []time.Time{date(1, 31, 9), date(2, 28, 9), date(3, 31, 9)},
+		},
+		{
+			name:   "daily on weekdays only",
+			rule:   "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=6",
+			start:  date(1, 8, 9),
+			before: date(2, 1, 0),
+			want:   []time.Time{date(1, 8, 9), date(1, 9, 9), date(1, 12, 9), date(1, 13, 9), date(1, 14, 9), date(1, 15, 9)},
+		},
+		{
+			name:   "yearly in january and july",
+			rule:   "FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=1;COUNT=3",
+			start:  date(1, 1, 9),
+			before: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:   []time.Time{date(1, 1, 9), date(7, 1, 9), time.Date(2027, 1, 1, 9, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRule() error = %v", err)
+			}
+
+			got := rule.Occurrences(tt.start, tt.before)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Occurrences()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarBusy(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []Event
+		want   []Period
+	}{
+		{
+			name: "cancelled and transparent events are free",
+			events: []Event{
+				{UID: "1", Start: date(1, 5, 9), End: date(1, 5, 10), Status: StatusCancelled},
+				{UID: "2", Start: date(1, 5, 11), End: date(1, 5, 12), Transparent: true},
+				{UID: "3", Start: date(1, 5, 13), End: date(1, 5, 14)},
+			},
+			want: []Period{{Start: date(1, 5, 13), End: date(1, 5, 14)}},
+		},
+		{
+			name: "recurring event with an exception and an override",
+			events: []Event{
+				{UID: "1", Start: date(1, 5, 9), End: date(1, 5, 10), RRule: "FREQ=DAILY;COUNT=3", ExDates: []time.Time{date(1, 6, 9)}},
+				{UID: "1", Start: date(1, 7, 15), End: date(1, 7, 16), RecurrenceID: date(1, 7, 9)},
+			},
+			want: []Period{
+				{Start: date(1, 5, 9), End: date(1, 5, 10)},
+				{Start: date(1, 7, 15), End: date(1, 7, 16)},
+			},
+		},
+		{
+			name: "events outside of the range are left out",
+			events: []Event{
+				{UID: "1", Start: date(1, 1, 9), End: date(1, 1, 10)},
+				{UID: "2", Start: date(1, 20, 9), End: date(1, 20, 10)},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Calendar{Events: tt.events}.Busy(date(1, 5, 0), date(1, 10, 0))
+			if err != nil {
+				t.Fatalf("Busy() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Busy() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("Busy()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
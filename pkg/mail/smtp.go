@@ -0,0 +1,100 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// Message is an HTML email to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+}
+
+// SMTP sends messages through an SMTP server, upgrading the connection with
+// STARTTLS when the server offers it
+type SMTP struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+func (s SMTP) Send(ctx context.Context, msg Message) (err error) {
+	if s.Host == "" {
+		return errors.New("mail: undefined smtp host")
+	}
+
+	dialer := net.Dialer{Timeout: s.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.Host, s.Port))
+	if err != nil {
+		return
+	}
+	if s.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return
+		}
+	}
+	if s.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return
+		}
+	}
+
+	if err = client.Mail(s.From); err != nil {
+		return
+	}
+	if err = client.Rcpt(msg.To); err != nil {
+		return
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return
+	}
+	if _, err = w.Write(s.compose(msg)); err != nil {
+		return
+	}
+	if err = w.Close(); err != nil {
+		return
+	}
+
+	return client.Quit()
+}
+
+func (s SMTP) compose(msg Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", s.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.HTML)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,200 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// server is an SMTP stand-in accepting a session at a time, it records the
+// commands and the message and refuses the recipients in reject
+type server struct {
+	listener net.Listener
+	auth     bool
+	reject   string
+
+	mu       sync.Mutex
+	commands []string
+	data     string
+}
+
+func newServer(t *testing.T, auth bool, reject string) *server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &server{listener: listener, auth: auth, reject: reject}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *server) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		s.mu.Lock()
+		s.commands = append(s.commands, line)
+		s.mu.Unlock()
+
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch {
+		case verb == "EHLO" && s.auth:
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case verb == "EHLO":
+			reply("250 localhost")
+		case verb == "AUTH" && s.auth:
+			reply("235 2.7.0 Authentication successful")
+		case verb == "RCPT" && s.reject != "" && strings.Contains(line, s.reject):
+			reply("550 5.1.1 No such user")
+		case verb == "MAIL", verb == "RCPT", verb == "RSET", verb == "NOOP":
+			reply("250 OK")
+		case verb == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+			reply("250 OK")
+		case verb == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *server) client(username, password string) SMTP {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return SMTP{
+		Host:     "127.0.0.1",
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     "noreply@example.com",
+		Timeout:  5 * time.Second,
+	}
+}
+
+func (s *server) has(prefix string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, command := range s.commands {
+		if strings.HasPrefix(command, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *server) message() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data
+}
+
+func TestSMTPSend(t *testing.T) {
+	srv := newServer(t, false, "")
+	msg := Message{To: "jane@example.com", Subject: "Willkommen, Jürgen", HTML: "<p>Hello</p>"}
+
+	if err := srv.client("", "").Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	for _, want := range []string{"MAIL FROM:<noreply@example.com>", "RCPT TO:<jane@example.com>", "QUIT"} {
+		if !srv.has(want) {
+			t.Errorf("server did not receive %q", want)
+		}
+	}
+	if srv.has("AUTH") {
+		t.Error("server received AUTH without credentials")
+	}
+
+	for _, want := range []string{
+		"From: noreply@example.com\r\n",
+		"To: jane@example.com\r\n",
+		"Subject: =?utf-8?q?Willkommen,_J=C3=BCrgen?=\r\n",
+		"Content-Type: text/html; charset=UTF-8\r\n",
+		"\r\n\r\n<p>Hello</p>",
+	} {
+		if data := srv.message(); !strings.Contains(data, want) {
+			t.Errorf("message does not contain %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestSMTPAuth(t *testing.T) {
+	srv := newServer(t, true, "")
+
+	if err := srv.client("user", "pass").Send(context.Background(), Message{To: "jane@example.com"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	want := "AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00user\x00pass"))
+	if !srv.has(want) {
+		t.Errorf("server did not receive %q", want)
+	}
+}
+
+func TestSMTPErrors(t *testing.T) {
+	srv := newServer(t, false, "nobody@example.com")
+
+	tests := []struct {
+		name   string
+		client SMTP
+		to     string
+	}{
+		{name: "rejected recipient", client: srv.client("", ""), to: "nobody@example.com"},
+		{name: "auth not offered", client: srv.client("user", "pass"), to: "jane@example.com"},
+		{name: "no host", client: SMTP{}, to: "jane@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.client.Send(context.Background(), Message{To: tt.to}); err == nil {
+				t.Fatal("Send() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package response
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"reservation-system/pkg/apperror"
+)
+
+var codesByKind = map[apperror.Kind]codes.Code{
+	apperror.KindInternal:             codes.Internal,
+	apperror.KindNotFound:             codes.NotFound,
+	apperror.KindConflict:             codes.AlreadyExists,
+	apperror.KindValidation:           codes.InvalidArgument,
+	apperror.KindForbidden:            codes.PermissionDenied,
+	apperror.KindUnprocessable:        codes.FailedPrecondition,
+	apperror.KindPreconditionFailed:   codes.Aborted,
+	apperror.KindPreconditionRequired: codes.FailedPrecondition,
+	apperror.KindUnauthorized:         codes.Unauthenticated,
+}
+
+// Status converts err to a gRPC status the same way Error derives the HTTP
+// status code, untyped errors never leak their message
+func Status(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return status.Error(codesByKind[apperror.KindOf(err)], apperror.DetailOf(err))
+}
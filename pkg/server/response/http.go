@@ -1,16 +1,43 @@
 package response
 
 import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"net/http"
+	"reservation-system/pkg/apperror"
 )
 
+const ContentTypeProblem = "application/problem+json"
+
 type Object struct {
 	Success bool   `json:"success"`
 	Message string `json:"message,omitempty"`
 	Data    any    `json:"data,omitempty"`
 }
 
+// Problem is the RFC 7807 problem details object written for failed requests
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+var statuses = map[apperror.Kind]int{
+	apperror.KindInternal:             http.StatusInternalServerError,
+	apperror.KindNotFound:             http.StatusNotFound,
+	apperror.KindConflict:             http.StatusConflict,
+	apperror.KindValidation:           http.StatusBadRequest,
+	apperror.KindForbidden:            http.StatusForbidden,
+	apperror.KindUnprocessable:        http.StatusUnprocessableEntity,
+	apperror.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	apperror.KindPreconditionRequired: http.StatusPreconditionRequired,
+	apperror.KindUnauthorized:         http.StatusUnauthorized,
+}
+
 func OK(w http.ResponseWriter, r *http.Request, data any) {
 	render.Status(r, http.StatusOK)
 
@@ -21,33 +48,35 @@ func OK(w http.ResponseWriter, r *http.Request, data any) {
 	render.JSON(w, r, v)
 }
 
-func BadRequest(w http.ResponseWriter, r *http.Request, err error, data any) {
-	render.Status(r, http.StatusBadRequest)
+func Created(w http.ResponseWriter, r *http.Request, data any) {
+	render.Status(r, http.StatusCreated)
 
 	v := Object{
-		Success: false,
+		Success: true,
 		Data:    data,
-		Message: err.Error(),
 	}
 	render.JSON(w, r, v)
 }
 
-func NotFound(w http.ResponseWriter, r *http.Request, err error) {
-	render.Status(r, http.StatusNotFound)
-
-	v := Object{
-		Success: false,
-		Message: err.Error(),
-	}
-	render.JSON(w, r, v)
+func NoContent(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
 }
 
-func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	render.Status(r, http.StatusInternalServerError)
+// Error writes err as a problem details object, the status code is derived
+// from the apperror.Kind of err and untyped errors never leak their message
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	status := statuses[apperror.KindOf(err)]
 
-	v := Object{
-		Success: false,
-		Message: err.Error(),
+	v := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    apperror.DetailOf(err),
+		Instance:  r.URL.Path,
+		RequestID: middleware.GetReqID(r.Context()),
 	}
-	render.JSON(w, r, v)
+
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
 }
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"net/http"
+	"net/http/httptest"
+	"reservation-system/pkg/apperror"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantDetail string
+	}{
+		{name: "not found", err: apperror.NotFound("candidate %s not found", "1"), wantStatus: http.StatusNotFound, wantDetail: "candidate 1 not found"},
+		{name: "conflict", err: apperror.Conflict("recruiter is busy"), wantStatus: http.StatusConflict, wantDetail: "recruiter is busy"},
+		{name: "validation", err: apperror.Validation("email: cannot be blank"), wantStatus: http.StatusBadRequest, wantDetail: "email: cannot be blank"},
+		{name: "forbidden", err: apperror.Forbidden("admin key only"), wantStatus: http.StatusForbidden, wantDetail: "admin key only"},
+		{name: "unprocessable", err: apperror.Unprocessable("unknown recruiter"), wantStatus: http.StatusUnprocessableEntity, wantDetail: "unknown recruiter"},
+		{name: "precondition failed", err: apperror.PreconditionFailed("stale version"), wantStatus: http.StatusPreconditionFailed, wantDetail: "stale version"},
+		{name: "precondition required", err: apperror.PreconditionRequired("If-Match: header is required"), wantStatus: http.StatusPreconditionRequired, wantDetail: "If-Match: header is required"},
+		{name: "unauthorized", err: apperror.Unauthorized("invalid key"), wantStatus: http.StatusUnauthorized, wantDetail: "invalid key"},
+		{name: "wrapped", err: fmt.Errorf("service: %w", apperror.NotFound("gone")), wantStatus: http.StatusNotFound, wantDetail: "gone"},
+		{name: "untyped error is not leaked", err: errors.New("pq: connection refused"), wantStatus: http.StatusInternalServerError, wantDetail: "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/candidates/1", nil)
+			r = r.WithContext(context.WithValue(r.Context(), middleware.RequestIDKey, "request-1"))
+			w := httptest.NewRecorder()
+
+			Error(w, r, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); got != ContentTypeProblem {
+				t.Errorf("Content-Type = %s, want %s", got, ContentTypeProblem)
+			}
+
+			var got Problem
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			want := Problem{
+				Type:      "about:blank",
+				Title:     http.StatusText(tt.wantStatus),
+				Status:    tt.wantStatus,
+				Detail:    tt.wantDetail,
+				Instance:  "/candidates/1",
+				RequestID: "request-1",
+			}
+			if got != want {
+				t.Errorf("problem = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
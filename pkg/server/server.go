@@ -2,7 +2,6 @@ package server
 
 import (
 	"context"
-	"fmt"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"net"
@@ -70,13 +69,13 @@ func (s *Server) Stop(ctx context.Context) (err error) {
 	return
 }
 
-func WithGRPCServer(port string) Configuration {
+func WithGRPCServer(server *grpc.Server, host, port string) Configuration {
 	return func(s *Server) (err error) {
-		s.listener, err = net.Listen("tcp", fmt.Sprintf("localhost:%s", port))
+		s.listener, err = net.Listen("tcp", net.JoinHostPort(host, port))
 		if err != nil {
 			return
 		}
-		s.grpc = &grpc.Server{}
+		s.grpc = server
 
 		return
 	}
@@ -0,0 +1,44 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Provider sends a text message to a phone number
+type Provider interface {
+	Send(ctx context.Context, to, text string) error
+}
+
+// Writer is a development provider that writes the messages to w instead of sending them
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewConsole returns a provider printing the messages to stdout
+func NewConsole() *Writer {
+	return &Writer{w: os.Stdout}
+}
+
+// NewFile returns a provider appending the messages to the file at path
+func NewFile(path string) (*Writer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{w: file}, nil
+}
+
+func (p *Writer) Send(ctx context.Context, to, text string) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = fmt.Fprintf(p.w, "%s SMS to %s: %s\n", time.Now().UTC().Format(time.RFC3339), to, text)
+
+	return
+}
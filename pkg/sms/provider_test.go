@@ -0,0 +1,37 @@
+package sms
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sms.log")
+
+	p, err := NewFile(path)
+	if err != nil {
+		t.Fatalf("NewFile() error = %v", err)
+	}
+	for _, text := range []string{"first", "second"} {
+		if err = p.Send(context.Background(), "4915112345678", text); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(src), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), src)
+	}
+	for i, want := range []string{" SMS to 4915112345678: first", " SMS to 4915112345678: second"} {
+		if !strings.HasSuffix(lines[i], want) {
+			t.Errorf("line %d = %q, want it to end with %q", i, lines[i], want)
+		}
+	}
+}
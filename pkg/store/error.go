@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrorNotFound = errors.New("error not found")
+	ErrorConflict = errors.New("error version conflict")
 )